@@ -1,6 +1,7 @@
 package ast
 
 import (
+	"dxkite.cn/c/literal"
 	"dxkite.cn/c/token"
 	"fmt"
 	"strings"
@@ -56,6 +57,10 @@ type (
 	BasicLit struct {
 		// token.INT token.FLOAT token.CHAR token.STRING
 		token.Token
+		// 相邻字符串连接前的各部分
+		Parts []token.Token
+		// 字符串编码
+		Encoding literal.Encoding
 	}
 
 	// 类型初始化表达式
@@ -166,6 +171,77 @@ type (
 		*Range
 		Type Typename
 	}
+
+	// _Alignof ( type-name )
+	AlignOfExpr struct {
+		*Range
+		Type Typename
+	}
+
+	// 泛型选择表达式
+	// _Generic ( assignment-expression , generic-assoc-list )
+	GenericSelectionExpr struct {
+		Generic token.Position // _Generic
+		Lparen  token.Position // (
+		X       Expr
+		Assocs  []*GenericAssoc
+		Rparen  token.Position // )
+		// 选中的关联下标，无法确定类型时为 -1
+		Selected int
+	}
+
+	// 泛型关联 type-name : assignment-expression
+	// default : assignment-expression
+	GenericAssoc struct {
+		Type    Typename       // default 时为 nil
+		Default token.Position // default
+		Colon   token.Position // :
+		X       Expr
+	}
+
+	// GNU 语句表达式 ({ ... })
+	StmtExpr struct {
+		Lparen token.Position // (
+		Body   *CompoundStmt
+		Rparen token.Position // )
+	}
+
+	// GNU 标签地址 &&label
+	LabelAddrExpr struct {
+		AndAnd token.Position // &&
+		Label  *Ident
+	}
+
+	// GNU 省略中间操作数的条件表达式 x ?: y
+	BinaryCondExpr struct {
+		X     Expr
+		Op    token.Token    // ?
+		Colon token.Position // :
+		Else  Expr
+	}
+
+	// __extension__ cast-expression
+	ExtensionExpr struct {
+		Extension token.Position // __extension__
+		X         Expr
+	}
+
+	// __builtin_va_arg ( assignment-expression , type-name )
+	VaArgExpr struct {
+		Builtin token.Position // __builtin_va_arg
+		X       Expr
+		Type    Typename
+		Rparen  token.Position // )
+	}
+
+	// __builtin_offsetof ( type-name , member-designator )
+	// 成员由 Ident、SelectorExpr 与 IndexExpr 组成
+	OffsetOfExpr struct {
+		Builtin token.Position // __builtin_offsetof
+		Type    Typename
+		Member  Expr
+		Rparen  token.Position // )
+	}
 )
 
 func (*BadExpr) expr()                 {}
@@ -185,12 +261,25 @@ func (e *Ident) End() token.Position {
 	return pos
 }
 
-func (*BasicLit) expr()                 {}
-func (e *BasicLit) String() string      { return e.Literal() }
+func (*BasicLit) expr() {}
+func (e *BasicLit) String() string {
+	if len(e.Parts) > 1 {
+		lit := make([]string, len(e.Parts))
+		for i, v := range e.Parts {
+			lit[i] = v.Literal()
+		}
+		return strings.Join(lit, " ")
+	}
+	return e.Literal()
+}
 func (e *BasicLit) Beg() token.Position { return e.Position() }
 func (e *BasicLit) End() token.Position {
-	pos := e.Position()
-	pos.Column += utf8.RuneCountInString(e.Literal())
+	last := e.Token
+	if n := len(e.Parts); n > 0 {
+		last = e.Parts[n-1]
+	}
+	pos := last.Position()
+	pos.Column += utf8.RuneCountInString(last.Literal())
 	return pos
 }
 
@@ -258,6 +347,38 @@ func (*SizeOfExpr) expr()                 {}
 func (e *SizeOfExpr) Beg() token.Position { return e.Range.Begin }
 func (e *SizeOfExpr) End() token.Position { return e.Range.End }
 
+func (*AlignOfExpr) expr()                 {}
+func (e *AlignOfExpr) Beg() token.Position { return e.Range.Begin }
+func (e *AlignOfExpr) End() token.Position { return e.Range.End }
+
+func (*GenericSelectionExpr) expr()                 {}
+func (e *GenericSelectionExpr) Beg() token.Position { return e.Generic }
+func (e *GenericSelectionExpr) End() token.Position { return e.Rparen }
+
+func (*StmtExpr) expr()                 {}
+func (e *StmtExpr) Beg() token.Position { return e.Lparen }
+func (e *StmtExpr) End() token.Position { return e.Rparen }
+
+func (*LabelAddrExpr) expr()                 {}
+func (e *LabelAddrExpr) Beg() token.Position { return e.AndAnd }
+func (e *LabelAddrExpr) End() token.Position { return e.Label.End() }
+
+func (*BinaryCondExpr) expr()                 {}
+func (e *BinaryCondExpr) Beg() token.Position { return e.X.Beg() }
+func (e *BinaryCondExpr) End() token.Position { return e.Else.End() }
+
+func (*ExtensionExpr) expr()                 {}
+func (e *ExtensionExpr) Beg() token.Position { return e.Extension }
+func (e *ExtensionExpr) End() token.Position { return e.X.End() }
+
+func (*VaArgExpr) expr()                 {}
+func (e *VaArgExpr) Beg() token.Position { return e.Builtin }
+func (e *VaArgExpr) End() token.Position { return e.Rparen }
+
+func (*OffsetOfExpr) expr()                 {}
+func (e *OffsetOfExpr) Beg() token.Position { return e.Builtin }
+func (e *OffsetOfExpr) End() token.Position { return e.Rparen }
+
 type (
 	Qualifier map[string]token.Position
 
@@ -270,19 +391,24 @@ type (
 		Completed bool
 		Lbrace    token.Position // {
 		Fields    []*RecordField
-		Rbrace    token.Position // }
+		Asserts   []*StaticAssertDecl // 成员中的静态断言
+		Rbrace    token.Position      // }
+		Attrs     []*Attribute        // 属性
 	}
 
 	RecordField struct {
-		Type Typename
-		Name *Ident
-		Bit  Expr
+		Type  Typename
+		Name  *Ident
+		Bit   Expr
+		Align []*AlignSpec // 对齐说明符
+		Attrs []*Attribute // 属性
 	}
 
 	// 枚举类型
 	EnumFieldDecl struct {
-		Name *Ident
-		Val  Expr
+		Name  *Ident
+		Val   Expr
+		Attrs []*Attribute // 属性
 	}
 
 	EnumType struct {
@@ -294,6 +420,7 @@ type (
 		Lbrace    token.Position // {
 		List      []*EnumFieldDecl
 		Rbrace    token.Position // }
+		Attrs     []*Attribute   // 属性
 	}
 
 	// 内置类型
@@ -311,6 +438,7 @@ type (
 
 		Pointer token.Position
 		Type    Typename
+		Attrs   []*Attribute // * 之后的属性
 	}
 
 	// 数组类型
@@ -332,9 +460,10 @@ type (
 
 	// 函数类型
 	ParamVarDecl struct {
-		Qua  *StorageSpecifier
-		Name *Ident
-		Type Typename
+		Qua   *StorageSpecifier
+		Name  *Ident
+		Type  Typename
+		Attrs []*Attribute // 属性
 	}
 
 	ParamList []*ParamVarDecl
@@ -355,11 +484,26 @@ type (
 		Type   Typename
 		Rparen token.Position // )
 	}
+
+	// typeof ( expression ) | typeof ( type-name )
+	TypeofType struct {
+		Qua *Qualifier
+
+		Typeof token.Token    // typeof typeof_unqual
+		X      Expr           // 表达式形式
+		Type   Typename       // 类型名称或表达式的类型，无法确定时为 nil
+		Rparen token.Position // )
+	}
 )
 
-func (*RecordType) typeName()               {}
-func (t *RecordType) Qualifier() *Qualifier { return t.Qua }
-func (t *RecordType) Beg() token.Position   { return t.Type.Position() }
+func (*RecordType) typeName() {}
+func (t *RecordType) Qualifier() *Qualifier {
+	if t.Qua == nil {
+		t.Qua = &Qualifier{}
+	}
+	return t.Qua
+}
+func (t *RecordType) Beg() token.Position { return t.Type.Position() }
 func (t *RecordType) End() token.Position {
 	if !t.Completed {
 		return t.Name.End()
@@ -427,6 +571,16 @@ func (t *ParenType) Qualifier() *Qualifier { return t.Type.Qualifier() }
 func (t *ParenType) Beg() token.Position   { return t.Lparen }
 func (t *ParenType) End() token.Position   { return t.Rparen }
 
+func (*TypeofType) typeName() {}
+func (t *TypeofType) Qualifier() *Qualifier {
+	if t.Qua == nil {
+		t.Qua = &Qualifier{}
+	}
+	return t.Qua
+}
+func (t *TypeofType) Beg() token.Position { return t.Typeof.Position() }
+func (t *TypeofType) End() token.Position { return t.Rparen }
+
 func (q *Qualifier) String() string {
 	if q == nil {
 		return ""
@@ -468,7 +622,15 @@ func (t *PointerType) String() string {
 }
 
 func (t *ArrayType) String() string {
-	return fmt.Sprintf("%s %s[]", t.Qualifier().String(), t.Type.String())
+	size := ""
+	if v, ok := t.Size.(*ConstantExpr); ok {
+		if lit, ok := v.X.(*BasicLit); ok {
+			size = lit.Literal()
+		}
+	} else if lit, ok := t.Size.(*BasicLit); ok {
+		size = lit.Literal()
+	}
+	return fmt.Sprintf("%s %s[%s]", t.Qualifier().String(), t.Type.String(), size)
 }
 
 func funcParamString(t *FuncType) string {
@@ -497,16 +659,50 @@ func (t *ParenType) String() string {
 	return fmt.Sprintf("(%s)", t.Type.String())
 }
 
+func (t *TypeofType) String() string {
+	if t.Type == nil {
+		return fmt.Sprintf("%s %s(...)", t.Qualifier().String(), t.Typeof.Literal())
+	}
+	return fmt.Sprintf("%s %s(%s)", t.Qualifier().String(), t.Typeof.Literal(), t.Type.String())
+}
+
 type (
 	// 定义语句
 	DeclStmt []Decl
 
-	// typedef /extern /static /auto /register
+	// typedef /extern /static /auto /register /_Thread_local
 	StorageSpecifier map[string]token.Position
 
+	// inline /_Noreturn
+	FunctionSpecifier map[string]token.Position
+
+	// 对齐说明符
+	// _Alignas ( type-name )
+	// _Alignas ( constant-expression )
+	AlignSpec struct {
+		Alignas token.Position // _Alignas
+		Type    Typename
+		X       Expr
+		Rparen  token.Position // )
+		// 计算得到的对齐值，无效或为 0 时不影响对齐
+		Align int64
+	}
+
 	LabelStmt struct {
-		Id   *Ident
-		Stmt Stmt
+		Id    *Ident
+		Attrs []*Attribute // 属性
+		Stmt  Stmt
+	}
+
+	// 空语句
+	EmptyStmt struct {
+		Semicolon token.Position // ;
+	}
+
+	// 带属性的语句
+	AttributedStmt struct {
+		Attrs []*Attribute
+		Stmt  Stmt
 	}
 
 	CaseStmt struct {
@@ -515,6 +711,15 @@ type (
 		Stmt Stmt
 	}
 
+	// GNU case 范围 case low ... high:
+	CaseRangeStmt struct {
+		Case     token.Position // case
+		Low      Expr
+		Ellipsis token.Position // ...
+		High     Expr
+		Stmt     Stmt
+	}
+
 	DefaultStmt struct {
 		Default token.Position // case
 		Stmt    Stmt
@@ -572,6 +777,21 @@ type (
 		Semicolon token.Position // ;
 	}
 
+	// GNU 计算跳转 goto *expr;
+	IndirectGotoStmt struct {
+		Goto      token.Position
+		Star      token.Position // *
+		X         Expr
+		Semicolon token.Position // ;
+	}
+
+	// GNU 局部标签声明 __label__ identifier-list ;
+	LabelDeclStmt struct {
+		Label     token.Position // __label__
+		Names     []*Ident
+		Semicolon token.Position // ;
+	}
+
 	ContinueStmt struct {
 		Continue  token.Position
 		Semicolon token.Position // ;
@@ -589,7 +809,35 @@ type (
 	}
 )
 
-func (*DeclStmt) stmt()                 {}
+func (*DeclStmt) stmt() {}
+
+// 存储期
+type StorageDuration int
+
+const (
+	AutomaticStorage StorageDuration = iota // 自动存储期
+	StaticStorage                           // 静态存储期
+	ThreadStorage                           // 线程存储期
+)
+
+// 变量的存储期，fileScope 表示是否在文件作用域声明
+func (s *StorageSpecifier) Duration(fileScope bool) StorageDuration {
+	if s != nil {
+		if _, ok := (*s)["_Thread_local"]; ok {
+			return ThreadStorage
+		}
+		_, static := (*s)["static"]
+		_, extern := (*s)["extern"]
+		if static || extern {
+			return StaticStorage
+		}
+	}
+	if fileScope {
+		return StaticStorage
+	}
+	return AutomaticStorage
+}
+
 func (s *DeclStmt) Beg() token.Position { return (*s)[0].Beg() }
 func (s *DeclStmt) End() token.Position { return (*s)[len(*s)-1].Beg() }
 
@@ -597,10 +845,22 @@ func (*LabelStmt) stmt()                 {}
 func (s *LabelStmt) Beg() token.Position { return s.Id.Beg() }
 func (s *LabelStmt) End() token.Position { return s.Stmt.End() }
 
+func (*EmptyStmt) stmt()                 {}
+func (s *EmptyStmt) Beg() token.Position { return s.Semicolon }
+func (s *EmptyStmt) End() token.Position { return s.Semicolon }
+
+func (*AttributedStmt) stmt()                 {}
+func (s *AttributedStmt) Beg() token.Position { return s.Attrs[0].Name.Position() }
+func (s *AttributedStmt) End() token.Position { return s.Stmt.End() }
+
 func (*CaseStmt) stmt()                 {}
 func (s *CaseStmt) Beg() token.Position { return s.Case }
 func (s *CaseStmt) End() token.Position { return s.Stmt.End() }
 
+func (*CaseRangeStmt) stmt()                 {}
+func (s *CaseRangeStmt) Beg() token.Position { return s.Case }
+func (s *CaseRangeStmt) End() token.Position { return s.Stmt.End() }
+
 func (*DefaultStmt) stmt()                 {}
 func (s *DefaultStmt) Beg() token.Position { return s.Default }
 func (s *DefaultStmt) End() token.Position { return s.Stmt.End() }
@@ -642,6 +902,14 @@ func (*GotoStmt) stmt()                 {}
 func (s *GotoStmt) Beg() token.Position { return s.Goto }
 func (s *GotoStmt) End() token.Position { return s.Semicolon }
 
+func (*IndirectGotoStmt) stmt()                 {}
+func (s *IndirectGotoStmt) Beg() token.Position { return s.Goto }
+func (s *IndirectGotoStmt) End() token.Position { return s.Semicolon }
+
+func (*LabelDeclStmt) stmt()                 {}
+func (s *LabelDeclStmt) Beg() token.Position { return s.Label }
+func (s *LabelDeclStmt) End() token.Position { return s.Semicolon }
+
 func (*ContinueStmt) stmt()                 {}
 func (s *ContinueStmt) Beg() token.Position { return s.Continue }
 func (s *ContinueStmt) End() token.Position { return s.Semicolon }
@@ -669,17 +937,25 @@ type (
 
 	// 函数定义
 	FuncDecl struct {
+		Qua  *StorageSpecifier
+		Spec *FunctionSpecifier
 		Name *Ident
 		Type *FuncType
 		Decl []Decl
 		Body *CompoundStmt
+		// 内联定义，不提供外部定义
+		InlineDef bool
+		Attrs     []*Attribute // 属性
 	}
 
 	// 变量定义
 	VarDecl struct {
-		Type Typename
-		Name *Ident
-		Init Expr
+		Qua   *StorageSpecifier
+		Type  Typename
+		Name  *Ident
+		Init  Expr
+		Align []*AlignSpec // 对齐说明符
+		Attrs []*Attribute // 属性
 	}
 
 	// 类型定义
@@ -688,6 +964,27 @@ type (
 		Typedef token.Position
 		Type    Typename
 		Name    *Ident
+		Attrs   []*Attribute // 属性
+	}
+
+	// 属性
+	// __attribute__ (( name ( args ) ))
+	// [[ prefix :: name ( args ) ]]
+	Attribute struct {
+		Prefix token.Token // 没有前缀时为 nil
+		Name   token.Token
+		Args   []Expr
+		Std    bool // C23 [[ ]] 形式
+	}
+
+	// 静态断言
+	// _Static_assert ( constant-expression , string-literal ) ;
+	// C23 可以省略 string-literal
+	StaticAssertDecl struct {
+		StaticAssert token.Position // _Static_assert
+		Cond         Expr
+		Msg          *BasicLit
+		Semicolon    token.Position // ;
 	}
 )
 
@@ -721,6 +1018,13 @@ func (t *TypedefDecl) End() token.Position {
 	return t.Name.End()
 }
 
+func (*StaticAssertDecl) decl() {}
+func (t *StaticAssertDecl) Ident() *Ident {
+	return nil
+}
+func (t *StaticAssertDecl) Beg() token.Position { return t.StaticAssert }
+func (t *StaticAssertDecl) End() token.Position { return t.Semicolon }
+
 func (*ParamVarDecl) decl() {}
 func (t *ParamVarDecl) Ident() *Ident {
 	return t.Name
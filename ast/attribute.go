@@ -0,0 +1,94 @@
+package ast
+
+import "strings"
+
+// 属性的语法形式
+type AttributeSyntax int
+
+const (
+	AttrGNU AttributeSyntax = 1 << iota // __attribute__ 或 [[gnu::name]]
+	AttrStd                             // C23 标准属性 [[name]]
+)
+
+// 已知属性的说明
+type AttributeSpec struct {
+	Name    string
+	MinArgs int
+	MaxArgs int // 小于 0 时不限制
+	Syntax  AttributeSyntax
+}
+
+// 已注册的属性
+var attributes = map[string]*AttributeSpec{}
+
+func init() {
+	for _, spec := range []*AttributeSpec{
+		{"packed", 0, 0, AttrGNU}, {"aligned", 0, 1, AttrGNU}, {"deprecated", 0, 1, AttrGNU},
+		{"unavailable", 0, 1, AttrGNU}, {"visibility", 1, 1, AttrGNU}, {"format", 3, 3, AttrGNU},
+		{"format_arg", 1, 1, AttrGNU}, {"section", 1, 1, AttrGNU}, {"alias", 1, 1, AttrGNU},
+		{"weak", 0, 0, AttrGNU}, {"weakref", 0, 1, AttrGNU}, {"used", 0, 0, AttrGNU},
+		{"unused", 0, 0, AttrGNU}, {"noreturn", 0, 0, AttrGNU}, {"const", 0, 0, AttrGNU},
+		{"pure", 0, 0, AttrGNU}, {"malloc", 0, 2, AttrGNU}, {"nonnull", 0, -1, AttrGNU},
+		{"returns_nonnull", 0, 0, AttrGNU}, {"nothrow", 0, 0, AttrGNU}, {"leaf", 0, 0, AttrGNU},
+		{"always_inline", 0, 0, AttrGNU}, {"noinline", 0, 0, AttrGNU}, {"gnu_inline", 0, 0, AttrGNU},
+		{"artificial", 0, 0, AttrGNU}, {"cold", 0, 0, AttrGNU}, {"hot", 0, 0, AttrGNU},
+		{"warn_unused_result", 0, 0, AttrGNU}, {"sentinel", 0, 1, AttrGNU}, {"constructor", 0, 1, AttrGNU},
+		{"destructor", 0, 1, AttrGNU}, {"cleanup", 1, 1, AttrGNU}, {"mode", 1, 1, AttrGNU},
+		{"may_alias", 0, 0, AttrGNU}, {"transparent_union", 0, 0, AttrGNU}, {"vector_size", 1, 1, AttrGNU},
+		{"alloc_size", 1, 2, AttrGNU}, {"alloc_align", 1, 1, AttrGNU}, {"access", 2, 4, AttrGNU},
+		{"warning", 1, 1, AttrGNU}, {"error", 1, 1, AttrGNU}, {"nonstring", 0, 0, AttrGNU},
+		{"fallthrough", 0, 0, AttrGNU}, {"common", 0, 0, AttrGNU}, {"nocommon", 0, 0, AttrGNU},
+		{"externally_visible", 0, 0, AttrGNU}, {"no_instrument_function", 0, 0, AttrGNU}, {"noclone", 0, 0, AttrGNU},
+		{"noipa", 0, 0, AttrGNU}, {"returns_twice", 0, 0, AttrGNU}, {"designated_init", 0, 0, AttrGNU},
+		{"deprecated", 0, 1, AttrStd}, {"fallthrough", 0, 0, AttrStd}, {"maybe_unused", 0, 0, AttrStd},
+		{"nodiscard", 0, 1, AttrStd}, {"noreturn", 0, 0, AttrStd}, {"_Noreturn", 0, 0, AttrStd},
+		{"unsequenced", 0, 0, AttrStd}, {"reproducible", 0, 0, AttrStd},
+	} {
+		RegisterAttribute(spec)
+	}
+}
+
+// 注册属性，后续阶段通过 LookupAttribute 查找已知属性
+// 同名属性合并语法形式
+func RegisterAttribute(spec *AttributeSpec) {
+	name := AttributeName(spec.Name)
+	if old, ok := attributes[name]; ok {
+		spec.Syntax |= old.Syntax
+	}
+	attributes[name] = spec
+}
+
+// 查找已注册的属性，名称可以使用 __name__ 形式
+func LookupAttribute(name string) (*AttributeSpec, bool) {
+	spec, ok := attributes[AttributeName(name)]
+	return spec, ok
+}
+
+// 属性名称的规范写法，去掉 __name__ 形式的下划线
+func AttributeName(name string) string {
+	if len(name) > 4 && strings.HasPrefix(name, "__") && strings.HasSuffix(name, "__") {
+		return name[2 : len(name)-2]
+	}
+	return name
+}
+
+// 属性的规范名称，gnu 以外的前缀保留在名称中
+func (a *Attribute) Canonical() string {
+	name := AttributeName(a.Name.Literal())
+	if a.Prefix != nil {
+		if prefix := AttributeName(a.Prefix.Literal()); prefix != "gnu" {
+			return prefix + "::" + name
+		}
+	}
+	return name
+}
+
+// 查找指定名称的属性，没有时返回 nil
+func FindAttribute(attrs []*Attribute, name string) *Attribute {
+	for _, attr := range attrs {
+		if attr.Canonical() == name {
+			return attr
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+// Code generated by "stringer -type BasicType -linecomment"; DO NOT EDIT.
+
+package ast
+
+import "strconv"
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[UnknownType-0]
+	_ = x[Void-1]
+	_ = x[Bool-2]
+	_ = x[Char-3]
+	_ = x[SignedChar-4]
+	_ = x[UnsignedChar-5]
+	_ = x[Short-6]
+	_ = x[UnsignedShort-7]
+	_ = x[Int-8]
+	_ = x[UnsignedInt-9]
+	_ = x[Long-10]
+	_ = x[UnsignedLong-11]
+	_ = x[LongLong-12]
+	_ = x[UnsignedLongLong-13]
+	_ = x[Float-14]
+	_ = x[Double-15]
+	_ = x[LongDouble-16]
+	_ = x[FloatComplex-17]
+	_ = x[DoubleComplex-18]
+	_ = x[LongDoubleComplex-19]
+	_ = x[FloatImaginary-20]
+	_ = x[DoubleImaginary-21]
+	_ = x[LongDoubleImaginary-22]
+	_ = x[UnsignedPointer-23]
+}
+
+const _BasicType_name = "UnknownTypevoid_Boolcharsigned charunsigned charshortunsigned shortintunsigned intlongunsigned longlong longunsigned long longfloatdoublelong doublefloat _Complexdouble _Complexlong double _Complexfloat _Imaginarydouble _Imaginarylong double _Imaginary无符号指针"
+
+var _BasicType_index = [...]uint16{0, 11, 15, 20, 24, 35, 48, 53, 67, 70, 82, 86, 99, 108, 126, 131, 137, 148, 162, 177, 197, 213, 230, 252, 267}
+
+func (i BasicType) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_BasicType_index)-1 {
+		return "BasicType(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _BasicType_name[_BasicType_index[idx]:_BasicType_index[idx+1]]
+}
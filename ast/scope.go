@@ -35,6 +35,9 @@ type Object struct {
 
 	Decl     Decl     // 定义语句
 	Typename Typename // 类型名称
+
+	Attrs []*Attribute // 各次声明的属性
+	Used  bool         // 是否被引用
 }
 
 func NewObject(typ ObjectType, ident *Ident) *Object {
@@ -1,4 +1,4 @@
-//go:generate stringer -type BuildInType -linecomment
+//go:generate stringer -type BasicType -linecomment
 package ast
 
 import (
@@ -15,44 +15,67 @@ const (
 	Void // void
 	// bool
 	Bool // _Bool
-	// int8 char
-	Char // char
-	// int16 short
-	Short // short
-	// int32 int/long
-	Int // int
-	// int64 long long
-	LongLong // long long
-	// float32 float
-	Float // float
-	// float64
-	Double // double
+	// 符号由目标平台决定
+	Char       // char
+	SignedChar // signed char
 	// uint8
 	UnsignedChar // unsigned char
+	// int16 short
+	Short // short
 	// uint16
 	UnsignedShort // unsigned short
+	// int32 int
+	Int // int
 	// uint32
 	UnsignedInt // unsigned int
+	// 大小由目标平台决定
+	Long         // long
+	UnsignedLong // unsigned long
+	// int64 long long
+	LongLong // long long
 	// uint64
 	UnsignedLongLong // unsigned long long
+	// float32 float
+	Float // float
+	// float64
+	Double     // double
+	LongDouble // long double
+	// 复数类型
+	FloatComplex      // float _Complex
+	DoubleComplex     // double _Complex
+	LongDoubleComplex // long double _Complex
+	// 虚数类型
+	FloatImaginary      // float _Imaginary
+	DoubleImaginary     // double _Imaginary
+	LongDoubleImaginary // long double _Imaginary
 	// uintptr
 	UnsignedPointer // 无符号指针
 )
 
 var sizeof = map[BasicType]int{
-	Void:             1,
-	Bool:             1,
-	Char:             1,
-	Short:            2,
-	Int:              4,
-	LongLong:         8,
-	Float:            4,
-	Double:           8,
-	UnsignedChar:     1,
-	UnsignedShort:    2,
-	UnsignedInt:      4,
-	UnsignedLongLong: 8,
-	UnsignedPointer:  4,
+	Void:                1,
+	Bool:                1,
+	Char:                1,
+	SignedChar:          1,
+	UnsignedChar:        1,
+	Short:               2,
+	UnsignedShort:       2,
+	Int:                 4,
+	UnsignedInt:         4,
+	Long:                8,
+	UnsignedLong:        8,
+	LongLong:            8,
+	UnsignedLongLong:    8,
+	Float:               4,
+	Double:              8,
+	LongDouble:          16,
+	FloatComplex:        8,
+	DoubleComplex:       16,
+	LongDoubleComplex:   32,
+	FloatImaginary:      4,
+	DoubleImaginary:     8,
+	LongDoubleImaginary: 16,
+	UnsignedPointer:     4,
 }
 
 func (t BasicType) Size() int {
@@ -62,66 +85,163 @@ func (t BasicType) Size() int {
 	return 4
 }
 
-// 解析内置类型
-func ParseBuildInType(lit []token.Token) (BasicType, *errors.Error) {
-	var base BasicType
-	long := 0
-	signed := false
-	unsigned := false
+// 自然对齐，与类型大小相同，复数与实数部分对齐
+func (t BasicType) Align() int {
+	return t.Real().Size()
+}
+
+// 整数类型，包括 _Bool 与字符类型
+func (t BasicType) IsInteger() bool {
+	return t >= Bool && t <= UnsignedLongLong || t == UnsignedPointer
+}
 
+// 无符号整数类型，char 的符号由目标平台决定，不算在内
+func (t BasicType) IsUnsigned() bool {
+	switch t {
+	case Bool, UnsignedChar, UnsignedShort, UnsignedInt, UnsignedLong, UnsignedLongLong, UnsignedPointer:
+		return true
+	}
+	return false
+}
+
+// 浮点类型，包括复数与虚数类型
+func (t BasicType) IsFloating() bool {
+	return t >= Float && t <= LongDoubleImaginary
+}
+
+// 复数与虚数类型对应的实数类型，其他类型返回自身
+func (t BasicType) Real() BasicType {
+	switch t {
+	case FloatComplex, FloatImaginary:
+		return Float
+	case DoubleComplex, DoubleImaginary:
+		return Double
+	case LongDoubleComplex, LongDoubleImaginary:
+		return LongDouble
+	}
+	return t
+}
+
+func (t BasicType) IsComplex() bool {
+	return t >= FloatComplex && t <= LongDoubleComplex
+}
+
+func (t BasicType) IsImaginary() bool {
+	return t >= FloatImaginary && t <= LongDoubleImaginary
+}
+
+// 可以组合使用的类型说明符，long 可以出现两次
+var specifierPairs = map[[2]string]bool{
+	{"signed", "char"}: true, {"unsigned", "char"}: true,
+	{"signed", "short"}: true, {"unsigned", "short"}: true, {"short", "int"}: true,
+	{"signed", "int"}: true, {"unsigned", "int"}: true, {"long", "int"}: true,
+	{"signed", "long"}: true, {"unsigned", "long"}: true, {"long", "long"}: true,
+	{"long", "double"}: true, {"long", "_Complex"}: true, {"long", "_Imaginary"}: true,
+	{"float", "_Complex"}: true, {"double", "_Complex"}: true,
+	{"float", "_Imaginary"}: true, {"double", "_Imaginary"}: true,
+}
+
+var buildInSpecifier = map[string]bool{
+	"void": true, "char": true, "short": true, "int": true, "long": true, "float": true, "double": true,
+	"signed": true, "unsigned": true, "_Bool": true, "_Complex": true, "_Imaginary": true,
+}
+
+func compatibleSpecifier(a, b string) bool {
+	return specifierPairs[[2]string{a, b}] || specifierPairs[[2]string{b, a}]
+}
+
+// 解析内置类型，说明符可以任意顺序出现 (C11 6.7.2)
+func ParseBuildInType(lit []token.Token) (BasicType, *errors.Error) {
+	count := map[string]int{}
+	var seen []string
 	for _, v := range lit {
-		switch v.Literal() {
-		case "void", "_Bool", "char", "short", "float", "double":
-			if base != UnknownType {
-				return Int, errors.New(v.Position(), errors.ErrSyntaxUnexpectedTypeSpecifier, v.Literal())
-			}
+		name := v.Literal()
+		if !buildInSpecifier[name] {
+			return Int, errors.New(v.Position(), errors.ErrSyntaxUnexpectedTypeSpecifier, name)
 		}
-		switch v.Literal() {
-		case "void":
-			if unsigned || signed {
-				return Int, errors.New(v.Position(), errors.ErrSyntaxUnexpectedTypeSpecifier, v.Literal())
-			}
-			base = Void
-		case "_Bool":
-			if unsigned || signed {
-				return Int, errors.New(v.Position(), errors.ErrSyntaxUnexpectedTypeSpecifier, v.Literal())
+		if count[name] > 0 && name != "long" {
+			return Int, errors.New(v.Position(), errors.ErrSyntaxDuplicateTypeSpecifier, name)
+		}
+		if name == "long" && count[name] == 2 {
+			return Int, errors.New(v.Position(), errors.ErrSyntaxTypeSpecifierTooLong)
+		}
+		for _, prev := range seen {
+			if !compatibleSpecifier(name, prev) {
+				return Int, errors.New(v.Position(), errors.ErrSyntaxTypeSpecifierConflict, name, prev)
 			}
-			base = Bool
-		case "char":
-			base = Char
-		case "int":
-			base = Int
-		case "short":
-			base = Short
-		case "float":
+		}
+		// long double 只能有一个 long
+		if name == "long" && count["long"] == 1 && count["double"] > 0 {
+			return Int, errors.New(v.Position(), errors.ErrSyntaxTypeSpecifierConflict, name, "long double")
+		}
+		if name == "double" && count["long"] == 2 {
+			return Int, errors.New(v.Position(), errors.ErrSyntaxTypeSpecifierConflict, name, "long long")
+		}
+		if count[name] == 0 {
+			seen = append(seen, name)
+		}
+		count[name]++
+	}
+
+	unsigned := count["unsigned"] > 0
+	switch {
+	case count["_Complex"] > 0 || count["_Imaginary"] > 0:
+		name := "_Complex"
+		if count["_Imaginary"] > 0 {
+			name = "_Imaginary"
+		}
+		base := UnknownType
+		switch {
+		case count["float"] > 0:
 			base = Float
-		case "double":
+		case count["double"] > 0 && count["long"] > 0:
+			base = LongDouble
+		case count["double"] > 0:
 			base = Double
-		case "unsigned":
-			if unsigned || signed {
-				return Int, errors.New(v.Position(), errors.ErrSyntaxUnexpectedTypeSpecifier, v.Literal())
-			}
-			unsigned = true
-		case "signed":
-			if unsigned || signed {
-				return Int, errors.New(v.Position(), errors.ErrSyntaxUnexpectedTypeSpecifier, v.Literal())
-			}
-			signed = true
-		case "long":
-			long++
-			if long > 2 {
-				return Int, errors.New(v.Position(), errors.ErrSyntaxUnexpectedTypeSpecifier, v.Literal())
-			}
-			if long == 2 {
-				base = LongLong
-			}
 		default:
-			return Int, errors.New(v.Position(), errors.ErrSyntaxUnexpectedTypeSpecifier, v.Literal())
+			return Int, errors.New(lit[0].Position(), errors.ErrSyntaxTypeSpecifierIncomplete, name)
+		}
+		if name == "_Imaginary" {
+			return base - Float + FloatImaginary, nil
+		}
+		return base - Float + FloatComplex, nil
+	case count["void"] > 0:
+		return Void, nil
+	case count["_Bool"] > 0:
+		return Bool, nil
+	case count["float"] > 0:
+		return Float, nil
+	case count["double"] > 0:
+		if count["long"] > 0 {
+			return LongDouble, nil
+		}
+		return Double, nil
+	case count["char"] > 0:
+		if unsigned {
+			return UnsignedChar, nil
+		}
+		if count["signed"] > 0 {
+			return SignedChar, nil
+		}
+		return Char, nil
+	case count["short"] > 0:
+		if unsigned {
+			return UnsignedShort, nil
+		}
+		return Short, nil
+	case count["long"] == 2:
+		if unsigned {
+			return UnsignedLongLong, nil
+		}
+		return LongLong, nil
+	case count["long"] == 1:
+		if unsigned {
+			return UnsignedLong, nil
 		}
+		return Long, nil
 	}
-	// 无符号数据
-	if unsigned && (base >= Char && base <= LongLong) {
-		base += UnsignedChar - Char
+	if unsigned {
+		return UnsignedInt, nil
 	}
-	return base, nil // 默认类型int
+	return Int, nil
 }
@@ -0,0 +1,71 @@
+// cfix 修复代码中的全角符号与输入法输入的中文符号
+//
+// 用法：
+//
+//	cfix [-w] [-encoding 编码] 文件...
+//
+// 默认输出修复建议，-w 时将修复后的代码写回文件，写回的文件使用 UTF-8 编码
+package main
+
+import (
+	"dxkite.cn/c/errors"
+	"dxkite.cn/c/scanner"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+var (
+	write    = flag.Bool("w", false, "将修复结果写回文件")
+	encoding = flag.String("encoding", "auto", "源码编码")
+)
+
+func main() {
+	flag.Usage = func() {
+		_, _ = fmt.Fprintf(os.Stderr, "用法: cfix [-w] [-encoding 编码] 文件...\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	enc, ok := scanner.LookupEncoding(*encoding)
+	if !ok {
+		_, _ = fmt.Fprintf(os.Stderr, "未知的编码 %s\n", *encoding)
+		os.Exit(2)
+	}
+	code := 0
+	for _, filename := range flag.Args() {
+		if err := fix(filename, &scanner.Option{Encoding: enc}); err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+			code = 1
+		}
+	}
+	os.Exit(code)
+}
+
+func fix(filename string, opt *scanner.Option) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	fixes, src := scanner.ScanFixIts(filename, f, opt)
+	_ = f.Close()
+	if !*write {
+		for _, v := range fixes {
+			fmt.Println(errors.New(v.Pos, errors.ErrScanFullWidth, v.Old, v.New))
+		}
+		return nil
+	}
+	if len(fixes) == 0 {
+		return nil
+	}
+	return ioutil.WriteFile(filename, scanner.ApplyFixIts(src, fixes), fi.Mode())
+}
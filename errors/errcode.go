@@ -8,33 +8,44 @@ const (
 	ErrUnKnown  ErrCode = iota // 未知错误
 	ErrReadFile                // 代码文件读取失败
 	// 基础扫描错误
-	scanErr               ErrCode = 1000 + iota
-	ErrScanUncloseChar            // 字符缺少关闭的 ' 符号
-	ErrScanUncloseString          // 字符串缺少关闭的 " 符号
-	ErrScanUncloseComment         // 多行注释缺少对应的关闭 */ 符号
-	ErrScanHexFormat              // 符号 %c 不是一个16进制编码字符
-	ErrScanUnicodeFormat          // 符号 %c 不是一个Unicode编码字符
+	scanErr                 ErrCode = 1000 + iota
+	ErrScanUncloseChar              // 字符缺少关闭的 ' 符号
+	ErrScanUncloseString            // 字符串缺少关闭的 " 符号
+	ErrScanUncloseComment           // 多行注释缺少对应的关闭 */ 符号
+	ErrScanHexFormat                // 符号 %c 不是一个16进制编码字符
+	ErrScanUnicodeFormat            // 符号 %c 不是一个Unicode编码字符
+	ErrScanTrigraph                 // 三字符组 %s 被替换为 %c
+	ErrScanTrigraphIgnored          // 忽略了三字符组 %s，替换后为 %c
+	ErrScanInvalidUTF8              // 文件包含无效的 UTF-8 编码，之后的内容按 %s 编码读取
+	ErrScanIdentUCN                 // 通用字符名 %s 不能用于标识符
+	ErrScanIdentConfusable          // 标识符 %s 容易与 %s 混淆
+	ErrScanIdentMixedScript         // 标识符 %s 混合使用了 %s 文字
+	ErrScanFullWidth                // 全角字符 %s 应替换为 %s
 	//预处理错误
-	macroErr                     ErrCode = 2000 + iota
-	ErrMacroHashHashPos                  // ## 不能出现在宏表达式的起始或结束位置
-	ErrMacroHashHashExpr                 // ## 不能用来连接 %s 和 %s
-	ErrMacroHashExpr                     // # 符号后面必须跟着一个宏参数
-	ErrMacroCallParamCount               // 宏调用参数数量错误，支持%d个参数，使用了%d个参数
-	ErrMacroUnexpectedElseIf             // 不应该出现的 #elif 宏
-	ErrMacroUnexpectedElse               // 不应该出现的 #else 宏
-	ErrMacroUnexpectedEndIf              // 不应该出现的 #endif 宏
-	ErrMacroExpectedIdent                // 这里应该是一个名称，不应该出现 %s 符号
-	ErrMacroExpectedGot                  // 这里应该是一个 %s ，不应该出现 %s
-	ErrMacroExpectedPunctuator           // 这里应该是一个 %s 符号，不应该出现 %s 符号
-	ErrMacroEnd                          // 这里应该是宏结尾了，不应该出现 %s 符号
-	ErrMacroExpectedTokenGotEof          // 需要符号为 %s，意外的遇到了文件尾
-	ErrMacroConstExpr                    // 错误的宏常量表达式 %s
-	ErrMacroDuplicateIdent               // 重复定义了符号 %s
-	ErrMacroInvalidIncludeString         // #include 包含错误的字符串 %s
-	ErrMacroInvalidIncludeMacro          // 错误的 #include 宏
-	ErrMacroIncludeFileRead              // #include的文件 %s 读取错误 %s
-	ErrMacroIncludeFileNoFound           // #include的文件不存在 %s
-	ErrMacroExprUnexpectedToken          // 非预期的宏表达式符号%s
+	macroErr                      ErrCode = 2000 + iota
+	ErrMacroHashHashPos                   // ## 不能出现在宏表达式的起始或结束位置
+	ErrMacroHashHashExpr                  // ## 不能用来连接 %s 和 %s
+	ErrMacroHashExpr                      // # 符号后面必须跟着一个宏参数
+	ErrMacroCallParamCount                // 宏调用参数数量错误，支持%d个参数，使用了%d个参数
+	ErrMacroUnexpectedElseIf              // 不应该出现的 #elif 宏
+	ErrMacroUnexpectedElse                // 不应该出现的 #else 宏
+	ErrMacroUnexpectedEndIf               // 不应该出现的 #endif 宏
+	ErrMacroExpectedIdent                 // 这里应该是一个名称，不应该出现 %s 符号
+	ErrMacroExpectedGot                   // 这里应该是一个 %s ，不应该出现 %s
+	ErrMacroExpectedPunctuator            // 这里应该是一个 %s 符号，不应该出现 %s 符号
+	ErrMacroEnd                           // 这里应该是宏结尾了，不应该出现 %s 符号
+	ErrMacroExpectedTokenGotEof           // 需要符号为 %s，意外的遇到了文件尾
+	ErrMacroConstExpr                     // 错误的宏常量表达式 %s
+	ErrMacroDuplicateIdent                // 重复定义了符号 %s
+	ErrMacroInvalidIncludeString          // #include 包含错误的字符串 %s
+	ErrMacroInvalidIncludeMacro           // 错误的 #include 宏
+	ErrMacroIncludeFileRead               // #include的文件 %s 读取错误 %s
+	ErrMacroIncludeFileNoFound            // #include的文件不存在 %s
+	ErrMacroExprUnexpectedToken           // 非预期的宏表达式符号%s
+	ErrMacroDeadCondition                 // 条件 %s 永远不会成立
+	ErrMacroUndefinedTested               // 宏 %s 被用于条件判断，但从未被定义
+	ErrMacroUnterminatedCondition         // #%s 缺少对应的 #endif
+	ErrMacroConditionCrossFile            // #%s 不能结束在 %s 打开的条件编译 #%s
 	// 语法错误
 	syntaxError                       ErrCode = 3000 + iota
 	ErrSyntaxExpectedGot                      // 这里应该是一个 %s ，不应该出现 %s
@@ -55,6 +66,60 @@ const (
 	ErrSyntaxUndefinedLabel                   // 未定义的标签 %s
 	ErrSyntaxIncompleteStruct                 // 不完全的结构体类型 %s
 	ErrSyntaxIncompleteUnion                  // 不完全的联合体类型 %s
+	ErrSyntaxNotConstant                      // 这里应该是一个整数常量表达式
+	ErrSyntaxDivideByZero                     // 常量表达式中除数为零
+	ErrSyntaxIncompleteType                   // 不能计算不完全类型 %s 的大小
+	ErrSyntaxStaticAssertFailed               // 静态断言失败
+	ErrSyntaxStaticAssertFailedMsg            // 静态断言失败：%s
+	ErrSyntaxGenericDuplicateDefault          // 重复的 default 泛型关联，上次出现的位置 %s
+	ErrSyntaxGenericCompatible                // 泛型关联的类型 %s 与 %s 兼容，上次出现的位置 %s
+	ErrSyntaxGenericNoMatch                   // 没有与类型 %s 匹配的泛型关联
+	ErrSyntaxAlignasNotAllowed                // 这里不能使用 _Alignas
+	ErrSyntaxAlignNotPowerOfTwo               // 对齐值 %s 不是 2 的幂
+	ErrSyntaxAlignTooWeak                     // 对齐值 %s 小于类型 %s 的自然对齐 %s
+	ErrSyntaxAtomicInvalidType                // 不能对类型 %s 使用 _Atomic
+	ErrSyntaxStorageNotAllowed                // 这里不能使用存储类说明符 %s
+	ErrSyntaxStorageConflict                  // 存储类说明符 %s 不能与 %s 同时使用
+	ErrSyntaxThreadLocalBlockScope            // 块作用域中的 _Thread_local 变量需要同时声明为 static 或 extern
+	ErrSyntaxFuncSpecNotFunc                  // %s 只能用于函数声明
+	ErrSyntaxInlineNotDefined                 // 内联函数 %s 已声明但没有定义
+	ErrSyntaxInlineStaticVar                  // 具有外部链接的内联函数 %s 中不能定义可修改的静态变量 %s
+	ErrSyntaxInlineInternalRef                // 具有外部链接的内联函数 %s 中不能引用具有内部链接的 %s
+	ErrSyntaxNoreturnReturn                   // _Noreturn 函数 %s 中不应该有 return 语句
+	ErrSyntaxNoreturnFallOff                  // _Noreturn 函数 %s 可能会执行到函数末尾
+	ErrSyntaxTypeSpecifierConflict            // 类型说明符 %s 不能与 %s 组合使用
+	ErrSyntaxTypeSpecifierTooLong             // 类型说明符中的 long 过多
+	ErrSyntaxTypeSpecifierIncomplete          // %s 需要与 float、double 或 long double 组合使用
+	ErrSyntaxImaginaryUnsupported             // 不支持虚数类型 %s
+	ErrSyntaxAttributeUnknown                 // 未知的属性 %s，已忽略
+	ErrSyntaxAttributeArgCount                // 属性 %s 的参数数量应为 %s，使用了 %d 个参数
+	ErrSyntaxDeprecated                       // %s 已弃用%s
+	ErrSyntaxNodiscard                        // 忽略了 nodiscard 函数 %s 的返回值%s
+	ErrSyntaxFallthroughMisplaced             // fallthrough 属性只能用于 case 或 default 标签之前的空语句
+	ErrSyntaxUnusedVar                        // 变量 %s 未使用
+	ErrSyntaxUnusedFunc                       // 静态函数 %s 已定义但未使用
+	ErrSyntaxGNUExtension                     // %s 是 GNU 扩展，需要启用 GNU 模式
+	ErrSyntaxStmtExprOutsideFunc              // 语句表达式只能在函数内使用
+	ErrSyntaxCaseRangeEmpty                   // case 范围 %d ... %d 为空
+	ErrSyntaxNoMember                         // %s 中没有名为 %s 的成员
+	ErrSyntaxLabelDeclNotAtStart              // 局部标签声明只能位于块的开头
+	ErrSyntaxDialectMismatch                  // 扫描器方言与解析选项不一致，%s 被扫描为%s，解析选项 GNU 为 %v
 	typeError                         ErrCode = 4000 + iota
 	ErrTypeImmediateMakeAddress               // 无法对临时变量进行取地址操作
+	// 字面量错误
+	literalErr                 ErrCode = 5000 + iota
+	ErrLiteralInvalidDigit             // 数字 %s 中包含无效的数字 %s
+	ErrLiteralInvalidSuffix            // 数字 %s 的后缀 %s 无效
+	ErrLiteralSeparator                // 数字 %s 中的分隔符 ' 位置错误
+	ErrLiteralNoDigits                 // 数字 %s 缺少有效数字
+	ErrLiteralExponent                 // 数字 %s 的指数部分缺少数字
+	ErrLiteralHexFloatExponent         // 十六进制浮点数 %s 缺少 p 指数
+	ErrLiteralIntRange                 // 整数 %s 超出了可表示的范围
+	ErrLiteralFloatRange               // 浮点数 %s 超出了 %s 可表示的范围
+	ErrLiteralUnknownEscape            // 未知的转义序列 %s
+	ErrLiteralEscapeRange              // 转义序列 %s 超出了 %s 编码单元的范围
+	ErrLiteralInvalidUCN               // 无效的通用字符名 %s
+	ErrLiteralEmptyChar                // 空的字符常量
+	ErrLiteralCharRange                // 字符常量 %s 无法用单个编码单元表示
+	ErrLiteralStringEncoding           // 不能连接不同编码的字符串 %s 和 %s
 )
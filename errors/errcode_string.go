@@ -16,74 +16,150 @@ func _() {
 	_ = x[ErrScanUncloseComment-1005]
 	_ = x[ErrScanHexFormat-1006]
 	_ = x[ErrScanUnicodeFormat-1007]
-	_ = x[macroErr-2008]
-	_ = x[ErrMacroHashHashPos-2009]
-	_ = x[ErrMacroHashHashExpr-2010]
-	_ = x[ErrMacroHashExpr-2011]
-	_ = x[ErrMacroCallParamCount-2012]
-	_ = x[ErrMacroUnexpectedElseIf-2013]
-	_ = x[ErrMacroUnexpectedElse-2014]
-	_ = x[ErrMacroUnexpectedEndIf-2015]
-	_ = x[ErrMacroExpectedIdent-2016]
-	_ = x[ErrMacroExpectedGot-2017]
-	_ = x[ErrMacroExpectedPunctuator-2018]
-	_ = x[ErrMacroEnd-2019]
-	_ = x[ErrMacroExpectedTokenGotEof-2020]
-	_ = x[ErrMacroConstExpr-2021]
-	_ = x[ErrMacroDuplicateIdent-2022]
-	_ = x[ErrMacroInvalidIncludeString-2023]
-	_ = x[ErrMacroInvalidIncludeMacro-2024]
-	_ = x[ErrMacroIncludeFileRead-2025]
-	_ = x[ErrMacroIncludeFileNoFound-2026]
-	_ = x[ErrMacroExprUnexpectedToken-2027]
-	_ = x[syntaxError-3028]
-	_ = x[ErrSyntaxExpectedGot-3029]
-	_ = x[ErrSyntaxExpectedIdentGot-3030]
-	_ = x[ErrSyntaxUnexpectedTypeSpecifier-3031]
-	_ = x[ErrSyntaxDuplicateTypeSpecifier-3032]
-	_ = x[ErrSyntaxDuplicateTypeQualifier-3033]
-	_ = x[ErrSyntaxExpectedRecordMemberName-3034]
-	_ = x[ErrSyntaxRedefineFunc-3035]
-	_ = x[ErrSyntaxRedefineVar-3036]
-	_ = x[ErrSyntaxRedefineIdent-3037]
-	_ = x[ErrSyntaxRedefinedType-3038]
-	_ = x[ErrSyntaxRedefinedStruct-3039]
-	_ = x[ErrSyntaxRedefinedUnion-3040]
-	_ = x[ErrSyntaxRedefinedEnum-3041]
-	_ = x[ErrSyntaxRedefinedLabel-3042]
-	_ = x[ErrSyntaxUndefinedIdent-3043]
-	_ = x[ErrSyntaxUndefinedLabel-3044]
-	_ = x[ErrSyntaxIncompleteStruct-3045]
-	_ = x[ErrSyntaxIncompleteUnion-3046]
+	_ = x[ErrScanTrigraph-1008]
+	_ = x[ErrScanTrigraphIgnored-1009]
+	_ = x[ErrScanInvalidUTF8-1010]
+	_ = x[ErrScanIdentUCN-1011]
+	_ = x[ErrScanIdentConfusable-1012]
+	_ = x[ErrScanIdentMixedScript-1013]
+	_ = x[ErrScanFullWidth-1014]
+	_ = x[macroErr-2015]
+	_ = x[ErrMacroHashHashPos-2016]
+	_ = x[ErrMacroHashHashExpr-2017]
+	_ = x[ErrMacroHashExpr-2018]
+	_ = x[ErrMacroCallParamCount-2019]
+	_ = x[ErrMacroUnexpectedElseIf-2020]
+	_ = x[ErrMacroUnexpectedElse-2021]
+	_ = x[ErrMacroUnexpectedEndIf-2022]
+	_ = x[ErrMacroExpectedIdent-2023]
+	_ = x[ErrMacroExpectedGot-2024]
+	_ = x[ErrMacroExpectedPunctuator-2025]
+	_ = x[ErrMacroEnd-2026]
+	_ = x[ErrMacroExpectedTokenGotEof-2027]
+	_ = x[ErrMacroConstExpr-2028]
+	_ = x[ErrMacroDuplicateIdent-2029]
+	_ = x[ErrMacroInvalidIncludeString-2030]
+	_ = x[ErrMacroInvalidIncludeMacro-2031]
+	_ = x[ErrMacroIncludeFileRead-2032]
+	_ = x[ErrMacroIncludeFileNoFound-2033]
+	_ = x[ErrMacroExprUnexpectedToken-2034]
+	_ = x[ErrMacroDeadCondition-2035]
+	_ = x[ErrMacroUndefinedTested-2036]
+	_ = x[ErrMacroUnterminatedCondition-2037]
+	_ = x[ErrMacroConditionCrossFile-2038]
+	_ = x[syntaxError-3039]
+	_ = x[ErrSyntaxExpectedGot-3040]
+	_ = x[ErrSyntaxExpectedIdentGot-3041]
+	_ = x[ErrSyntaxUnexpectedTypeSpecifier-3042]
+	_ = x[ErrSyntaxDuplicateTypeSpecifier-3043]
+	_ = x[ErrSyntaxDuplicateTypeQualifier-3044]
+	_ = x[ErrSyntaxExpectedRecordMemberName-3045]
+	_ = x[ErrSyntaxRedefineFunc-3046]
+	_ = x[ErrSyntaxRedefineVar-3047]
+	_ = x[ErrSyntaxRedefineIdent-3048]
+	_ = x[ErrSyntaxRedefinedType-3049]
+	_ = x[ErrSyntaxRedefinedStruct-3050]
+	_ = x[ErrSyntaxRedefinedUnion-3051]
+	_ = x[ErrSyntaxRedefinedEnum-3052]
+	_ = x[ErrSyntaxRedefinedLabel-3053]
+	_ = x[ErrSyntaxUndefinedIdent-3054]
+	_ = x[ErrSyntaxUndefinedLabel-3055]
+	_ = x[ErrSyntaxIncompleteStruct-3056]
+	_ = x[ErrSyntaxIncompleteUnion-3057]
+	_ = x[ErrSyntaxNotConstant-3058]
+	_ = x[ErrSyntaxDivideByZero-3059]
+	_ = x[ErrSyntaxIncompleteType-3060]
+	_ = x[ErrSyntaxStaticAssertFailed-3061]
+	_ = x[ErrSyntaxStaticAssertFailedMsg-3062]
+	_ = x[ErrSyntaxGenericDuplicateDefault-3063]
+	_ = x[ErrSyntaxGenericCompatible-3064]
+	_ = x[ErrSyntaxGenericNoMatch-3065]
+	_ = x[ErrSyntaxAlignasNotAllowed-3066]
+	_ = x[ErrSyntaxAlignNotPowerOfTwo-3067]
+	_ = x[ErrSyntaxAlignTooWeak-3068]
+	_ = x[ErrSyntaxAtomicInvalidType-3069]
+	_ = x[ErrSyntaxStorageNotAllowed-3070]
+	_ = x[ErrSyntaxStorageConflict-3071]
+	_ = x[ErrSyntaxThreadLocalBlockScope-3072]
+	_ = x[ErrSyntaxFuncSpecNotFunc-3073]
+	_ = x[ErrSyntaxInlineNotDefined-3074]
+	_ = x[ErrSyntaxInlineStaticVar-3075]
+	_ = x[ErrSyntaxInlineInternalRef-3076]
+	_ = x[ErrSyntaxNoreturnReturn-3077]
+	_ = x[ErrSyntaxNoreturnFallOff-3078]
+	_ = x[ErrSyntaxTypeSpecifierConflict-3079]
+	_ = x[ErrSyntaxTypeSpecifierTooLong-3080]
+	_ = x[ErrSyntaxTypeSpecifierIncomplete-3081]
+	_ = x[ErrSyntaxImaginaryUnsupported-3082]
+	_ = x[ErrSyntaxAttributeUnknown-3083]
+	_ = x[ErrSyntaxAttributeArgCount-3084]
+	_ = x[ErrSyntaxDeprecated-3085]
+	_ = x[ErrSyntaxNodiscard-3086]
+	_ = x[ErrSyntaxFallthroughMisplaced-3087]
+	_ = x[ErrSyntaxUnusedVar-3088]
+	_ = x[ErrSyntaxUnusedFunc-3089]
+	_ = x[ErrSyntaxGNUExtension-3090]
+	_ = x[ErrSyntaxStmtExprOutsideFunc-3091]
+	_ = x[ErrSyntaxCaseRangeEmpty-3092]
+	_ = x[ErrSyntaxNoMember-3093]
+	_ = x[ErrSyntaxLabelDeclNotAtStart-3094]
+	_ = x[ErrSyntaxDialectMismatch-3095]
+	_ = x[typeError-4096]
+	_ = x[ErrTypeImmediateMakeAddress-4097]
+	_ = x[literalErr-5098]
+	_ = x[ErrLiteralInvalidDigit-5099]
+	_ = x[ErrLiteralInvalidSuffix-5100]
+	_ = x[ErrLiteralSeparator-5101]
+	_ = x[ErrLiteralNoDigits-5102]
+	_ = x[ErrLiteralExponent-5103]
+	_ = x[ErrLiteralHexFloatExponent-5104]
+	_ = x[ErrLiteralIntRange-5105]
+	_ = x[ErrLiteralFloatRange-5106]
+	_ = x[ErrLiteralUnknownEscape-5107]
+	_ = x[ErrLiteralEscapeRange-5108]
+	_ = x[ErrLiteralInvalidUCN-5109]
+	_ = x[ErrLiteralEmptyChar-5110]
+	_ = x[ErrLiteralCharRange-5111]
+	_ = x[ErrLiteralStringEncoding-5112]
 }
 
 const (
 	_ErrCode_name_0 = "未知错误代码文件读取失败"
-	_ErrCode_name_1 = "scanErr字符缺少关闭的 ' 符号字符串缺少关闭的 \" 符号多行注释缺少对应的关闭 */ 符号符号 %c 不是一个16进制编码字符符号 %c 不是一个Unicode编码字符"
-	_ErrCode_name_2 = "macroErr## 不能出现在宏表达式的起始或结束位置## 不能用来连接 %s 和 %s# 符号后面必须跟着一个宏参数宏调用参数数量错误，支持%d个参数，使用了%d个参数不应该出现的 #elif 宏不应该出现的 #else 宏不应该出现的 #endif 宏这里应该是一个名称，不应该出现 %s 符号这里应该是一个 %s ，不应该出现 %s这里应该是一个 %s 符号，不应该出现 %s 符号这里应该是宏结尾了，不应该出现 %s 符号需要符号为 %s，意外的遇到了文件尾错误的宏常量表达式 %s重复定义了符号 %s#include 包含错误的字符串 %s错误的 #include 宏#include的文件 %s 读取错误 %s#include的文件不存在 %s非预期的宏表达式符号%s"
-	_ErrCode_name_3 = "syntaxError这里应该是一个 %s ，不应该出现 %s这里应该是一个名称，不应该出现 %s 符号非预期的类型定义符号 %s重复的类型定义符号 %s重复的类型修饰符号 %s类型定义符号之后应该是成员变量的名称重复声明函数 %s，上次声明的位置 %s重复声明的变量名 %s，上次声明的位置 %s重复的标识符 %s，上次声明的位置 %s重复定义的类型 %s，上次定义的位置 %s重复定义的结构体 %s，上次定义的位置 %s重复定义的联合体 %s，上次定义的位置 %s重复定义的枚举 %s，上次定义的位置 %s重复定义的标签 %s，上次定义的位置 %s未定义的标识符 %s未定义的标签 %s不完全的结构体类型 %s不完全的联合体类型 %s"
+	_ErrCode_name_1 = "scanErr字符缺少关闭的 ' 符号字符串缺少关闭的 \" 符号多行注释缺少对应的关闭 */ 符号符号 %c 不是一个16进制编码字符符号 %c 不是一个Unicode编码字符三字符组 %s 被替换为 %c忽略了三字符组 %s，替换后为 %c文件包含无效的 UTF-8 编码，之后的内容按 %s 编码读取通用字符名 %s 不能用于标识符标识符 %s 容易与 %s 混淆标识符 %s 混合使用了 %s 文字全角字符 %s 应替换为 %s"
+	_ErrCode_name_2 = "macroErr## 不能出现在宏表达式的起始或结束位置## 不能用来连接 %s 和 %s# 符号后面必须跟着一个宏参数宏调用参数数量错误，支持%d个参数，使用了%d个参数不应该出现的 #elif 宏不应该出现的 #else 宏不应该出现的 #endif 宏这里应该是一个名称，不应该出现 %s 符号这里应该是一个 %s ，不应该出现 %s这里应该是一个 %s 符号，不应该出现 %s 符号这里应该是宏结尾了，不应该出现 %s 符号需要符号为 %s，意外的遇到了文件尾错误的宏常量表达式 %s重复定义了符号 %s#include 包含错误的字符串 %s错误的 #include 宏#include的文件 %s 读取错误 %s#include的文件不存在 %s非预期的宏表达式符号%s条件 %s 永远不会成立宏 %s 被用于条件判断，但从未被定义#%s 缺少对应的 #endif#%s 不能结束在 %s 打开的条件编译 #%s"
+	_ErrCode_name_3 = "syntaxError这里应该是一个 %s ，不应该出现 %s这里应该是一个名称，不应该出现 %s 符号非预期的类型定义符号 %s重复的类型定义符号 %s重复的类型修饰符号 %s类型定义符号之后应该是成员变量的名称重复声明函数 %s，上次声明的位置 %s重复声明的变量名 %s，上次声明的位置 %s重复的标识符 %s，上次声明的位置 %s重复定义的类型 %s，上次定义的位置 %s重复定义的结构体 %s，上次定义的位置 %s重复定义的联合体 %s，上次定义的位置 %s重复定义的枚举 %s，上次定义的位置 %s重复定义的标签 %s，上次定义的位置 %s未定义的标识符 %s未定义的标签 %s不完全的结构体类型 %s不完全的联合体类型 %s这里应该是一个整数常量表达式常量表达式中除数为零不能计算不完全类型 %s 的大小静态断言失败静态断言失败：%s重复的 default 泛型关联，上次出现的位置 %s泛型关联的类型 %s 与 %s 兼容，上次出现的位置 %s没有与类型 %s 匹配的泛型关联这里不能使用 _Alignas对齐值 %s 不是 2 的幂对齐值 %s 小于类型 %s 的自然对齐 %s不能对类型 %s 使用 _Atomic这里不能使用存储类说明符 %s存储类说明符 %s 不能与 %s 同时使用块作用域中的 _Thread_local 变量需要同时声明为 static 或 extern%s 只能用于函数声明内联函数 %s 已声明但没有定义具有外部链接的内联函数 %s 中不能定义可修改的静态变量 %s具有外部链接的内联函数 %s 中不能引用具有内部链接的 %s_Noreturn 函数 %s 中不应该有 return 语句_Noreturn 函数 %s 可能会执行到函数末尾类型说明符 %s 不能与 %s 组合使用类型说明符中的 long 过多%s 需要与 float、double 或 long double 组合使用不支持虚数类型 %s未知的属性 %s，已忽略属性 %s 的参数数量应为 %s，使用了 %d 个参数%s 已弃用%s忽略了 nodiscard 函数 %s 的返回值%sfallthrough 属性只能用于 case 或 default 标签之前的空语句变量 %s 未使用静态函数 %s 已定义但未使用%s 是 GNU 扩展，需要启用 GNU 模式语句表达式只能在函数内使用case 范围 %d ... %d 为空%s 中没有名为 %s 的成员局部标签声明只能位于块的开头扫描器方言与解析选项不一致，%s 被扫描为%s，解析选项 GNU 为 %v"
+	_ErrCode_name_4 = "typeError无法对临时变量进行取地址操作"
+	_ErrCode_name_5 = "literalErr数字 %s 中包含无效的数字 %s数字 %s 的后缀 %s 无效数字 %s 中的分隔符 ' 位置错误数字 %s 缺少有效数字数字 %s 的指数部分缺少数字十六进制浮点数 %s 缺少 p 指数整数 %s 超出了可表示的范围浮点数 %s 超出了 %s 可表示的范围未知的转义序列 %s转义序列 %s 超出了 %s 编码单元的范围无效的通用字符名 %s空的字符常量字符常量 %s 无法用单个编码单元表示不能连接不同编码的字符串 %s 和 %s"
 )
 
 var (
 	_ErrCode_index_0 = [...]uint8{0, 12, 36}
-	_ErrCode_index_1 = [...]uint8{0, 7, 37, 70, 113, 155, 196}
-	_ErrCode_index_2 = [...]uint16{0, 8, 62, 93, 134, 204, 232, 260, 289, 344, 390, 449, 504, 552, 582, 606, 642, 664, 700, 729, 761}
-	_ErrCode_index_3 = [...]uint16{0, 11, 57, 112, 145, 175, 205, 259, 307, 361, 409, 460, 514, 568, 619, 670, 694, 715, 745, 775}
+	_ErrCode_index_1 = [...]uint16{0, 7, 37, 70, 113, 155, 196, 227, 269, 340, 380, 412, 450, 481}
+	_ErrCode_index_2 = [...]uint16{0, 8, 62, 93, 134, 204, 232, 260, 289, 344, 390, 449, 504, 552, 582, 606, 642, 664, 700, 729, 761, 789, 838, 864, 912}
+	_ErrCode_index_3 = [...]uint16{0, 11, 57, 112, 145, 175, 205, 259, 307, 361, 409, 460, 514, 568, 619, 670, 694, 715, 745, 775, 817, 847, 887, 905, 928, 985, 1050, 1090, 1117, 1145, 1192, 1225, 1264, 1311, 1389, 1416, 1456, 1535, 1611, 1660, 1710, 1754, 1787, 1843, 1867, 1897, 1956, 1970, 2014, 2086, 2105, 2142, 2185, 2224, 2252, 2283, 2325, 2410}
+	_ErrCode_index_4 = [...]uint8{0, 9, 51}
+	_ErrCode_index_5 = [...]uint16{0, 10, 47, 76, 116, 144, 181, 221, 258, 302, 326, 376, 403, 421, 470, 516}
 )
 
 func (i ErrCode) String() string {
 	switch {
 	case 0 <= i && i <= 1:
 		return _ErrCode_name_0[_ErrCode_index_0[i]:_ErrCode_index_0[i+1]]
-	case 1002 <= i && i <= 1007:
+	case 1002 <= i && i <= 1014:
 		i -= 1002
 		return _ErrCode_name_1[_ErrCode_index_1[i]:_ErrCode_index_1[i+1]]
-	case 2008 <= i && i <= 2027:
-		i -= 2008
+	case 2015 <= i && i <= 2038:
+		i -= 2015
 		return _ErrCode_name_2[_ErrCode_index_2[i]:_ErrCode_index_2[i+1]]
-	case 3028 <= i && i <= 3046:
-		i -= 3028
+	case 3039 <= i && i <= 3095:
+		i -= 3039
 		return _ErrCode_name_3[_ErrCode_index_3[i]:_ErrCode_index_3[i+1]]
+	case 4096 <= i && i <= 4097:
+		i -= 4096
+		return _ErrCode_name_4[_ErrCode_index_4[i]:_ErrCode_index_4[i+1]]
+	case 5098 <= i && i <= 5112:
+		i -= 5098
+		return _ErrCode_name_5[_ErrCode_index_5[i]:_ErrCode_index_5[i+1]]
 	default:
 		return "ErrCode(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
@@ -1,3 +1,5 @@
 module dxkite.cn/c
 
-go 1.16
+go 1.17
+
+require golang.org/x/text v0.13.0
@@ -0,0 +1,265 @@
+// Package literal 解析C语言字面量
+package literal
+
+import (
+	"dxkite.cn/c/errors"
+	"dxkite.cn/c/target"
+	"dxkite.cn/c/token"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// 数值字面量
+type Number struct {
+	Lit   string
+	Type  Type
+	Base  int     // 进制 2 8 10 16
+	Int   uint64  // 整数值
+	Float float64 // 浮点数值，long double 按 double 精度计算
+}
+
+// 是否为浮点数
+func (n *Number) IsFloat() bool {
+	return n.Type.IsFloat()
+}
+
+// 判断预处理数字是否为浮点数
+func IsFloat(lit string) bool {
+	lit = strings.ToLower(lit)
+	switch {
+	case strings.HasPrefix(lit, "0x"):
+		return strings.ContainsAny(lit, ".p")
+	case strings.HasPrefix(lit, "0b"):
+		return false
+	}
+	return strings.ContainsAny(lit, ".e")
+}
+
+// 解析数值字面量，返回值以及C语言类型
+func ParseNumber(tok token.Token, tgt *target.Target) (*Number, *errors.Error) {
+	lit := tok.Literal()
+	n := &Number{Lit: lit, Type: Invalid, Base: 10}
+	p := &numberParser{lit: lit, pos: tok.Position()}
+	s := lit
+	if len(s) > 1 && s[0] == '0' {
+		switch s[1] {
+		case 'x', 'X':
+			n.Base = 16
+			p.i = 2
+		case 'b', 'B':
+			n.Base = 2
+			p.i = 2
+		}
+	}
+	digitBase := 10
+	if n.Base == 16 {
+		digitBase = 16
+	}
+	mant := p.digits(digitBase)
+	float := false
+	frac := ""
+	exp := ""
+	if n.Base != 2 && p.peek() == '.' {
+		float = true
+		p.i++
+		frac = p.digits(digitBase)
+	}
+	if p.err != nil {
+		return n, p.err
+	}
+	if e := lower(p.peek()); n.Base != 2 && (n.Base == 16 && e == 'p' || n.Base == 10 && e == 'e') {
+		float = true
+		p.i++
+		sign := ""
+		if c := p.peek(); c == '+' || c == '-' {
+			sign = string(c)
+			p.i++
+		}
+		if exp = p.digits(10); p.err != nil {
+			return n, p.err
+		}
+		if exp == "" {
+			return n, p.newErr(errors.ErrLiteralExponent, lit)
+		}
+		exp = sign + exp
+	}
+	if mant == "" && frac == "" {
+		return n, p.newErr(errors.ErrLiteralNoDigits, lit)
+	}
+	suffix := lit[p.i:]
+	if float {
+		if n.Base == 16 && exp == "" {
+			return n, p.newErr(errors.ErrLiteralHexFloatExponent, lit)
+		}
+		return n, p.parseFloat(n, mant, frac, exp, suffix)
+	}
+	if n.Base == 10 && mant[0] == '0' {
+		n.Base = 8
+	}
+	return n, p.parseInt(n, mant, suffix, tgt)
+}
+
+type numberParser struct {
+	lit string
+	pos token.Position
+	i   int
+	err *errors.Error
+}
+
+func (p *numberParser) peek() byte {
+	if p.i < len(p.lit) {
+		return p.lit[p.i]
+	}
+	return 0
+}
+
+func (p *numberParser) newErr(code errors.ErrCode, params ...interface{}) *errors.Error {
+	return errors.New(p.pos, code, params...)
+}
+
+// 读取数字序列，去除分隔符
+func (p *numberParser) digits(base int) string {
+	var b strings.Builder
+	for p.i < len(p.lit) {
+		c := p.lit[p.i]
+		if c == '\'' {
+			// 分隔符只能出现在两个数字之间
+			if b.Len() == 0 || p.i+1 >= len(p.lit) || digitVal(p.lit[p.i+1]) >= base {
+				if p.err == nil {
+					p.err = p.newErr(errors.ErrLiteralSeparator, p.lit)
+				}
+				return b.String()
+			}
+			p.i++
+			continue
+		}
+		if digitVal(c) >= base {
+			break
+		}
+		b.WriteByte(c)
+		p.i++
+	}
+	return b.String()
+}
+
+func (p *numberParser) parseFloat(n *Number, mant, frac, exp, suffix string) *errors.Error {
+	switch suffix {
+	case "":
+		n.Type = Double
+	case "f", "F":
+		n.Type = Float
+	case "l", "L":
+		n.Type = LongDouble
+	default:
+		return p.newErr(errors.ErrLiteralInvalidSuffix, p.lit, suffix)
+	}
+	s := mant
+	if frac != "" {
+		s += "." + frac
+	}
+	if n.Base == 16 {
+		s = "0x" + s + "p" + exp
+	} else if exp != "" {
+		s += "e" + exp
+	}
+	size := 64
+	if n.Type == Float {
+		size = 32
+	}
+	v, err := strconv.ParseFloat(s, size)
+	n.Float = v
+	if err != nil {
+		return p.newErr(errors.ErrLiteralFloatRange, p.lit, n.Type.String())
+	}
+	return nil
+}
+
+func (p *numberParser) parseInt(n *Number, mant, suffix string, tgt *target.Target) *errors.Error {
+	var v uint64
+	overflow := false
+	for i := 0; i < len(mant); i++ {
+		d := digitVal(mant[i])
+		if d >= n.Base {
+			return p.newErr(errors.ErrLiteralInvalidDigit, p.lit, string(mant[i]))
+		}
+		hi, lo := bits.Mul64(v, uint64(n.Base))
+		lo, carry := bits.Add64(lo, uint64(d), 0)
+		overflow = overflow || hi != 0 || carry != 0
+		v = lo
+	}
+	n.Int = v
+	types, ok := intTypes(suffix, n.Base == 10)
+	if !ok {
+		return p.newErr(errors.ErrLiteralInvalidSuffix, p.lit, suffix)
+	}
+	n.Type = types[len(types)-1]
+	if overflow {
+		return p.newErr(errors.ErrLiteralIntRange, p.lit)
+	}
+	// 选择第一个可以表示该值的类型
+	for _, t := range types {
+		if v <= t.max(tgt) {
+			n.Type = t
+			return nil
+		}
+	}
+	return p.newErr(errors.ErrLiteralIntRange, p.lit)
+}
+
+// 整数后缀对应的候选类型
+func intTypes(suffix string, decimal bool) ([]Type, bool) {
+	unsigned := false
+	if i := strings.IndexAny(suffix, "uU"); i == 0 {
+		unsigned = true
+		suffix = suffix[1:]
+	} else if i > 0 && i == len(suffix)-1 {
+		unsigned = true
+		suffix = suffix[:i]
+	}
+	switch suffix {
+	case "":
+		if unsigned {
+			return []Type{UnsignedInt, UnsignedLong, UnsignedLongLong}, true
+		}
+		if decimal {
+			return []Type{Int, Long, LongLong}, true
+		}
+		return []Type{Int, UnsignedInt, Long, UnsignedLong, LongLong, UnsignedLongLong}, true
+	case "l", "L":
+		if unsigned {
+			return []Type{UnsignedLong, UnsignedLongLong}, true
+		}
+		if decimal {
+			return []Type{Long, LongLong}, true
+		}
+		return []Type{Long, UnsignedLong, LongLong, UnsignedLongLong}, true
+	case "ll", "LL":
+		if unsigned {
+			return []Type{UnsignedLongLong}, true
+		}
+		if decimal {
+			return []Type{LongLong}, true
+		}
+		return []Type{LongLong, UnsignedLongLong}, true
+	case "z", "Z":
+		if unsigned {
+			return []Type{Size}, true
+		}
+		return []Type{SignedSize}, true
+	}
+	return nil, false
+}
+
+func lower(c byte) byte { return c | ('a' - 'A') }
+
+// 数字的值，非数字返回 16 以上
+func digitVal(c byte) int {
+	switch {
+	case '0' <= c && c <= '9':
+		return int(c - '0')
+	case 'a' <= lower(c) && lower(c) <= 'f':
+		return int(lower(c) - 'a' + 10)
+	}
+	return 16
+}
@@ -0,0 +1,94 @@
+package literal
+
+import (
+	"dxkite.cn/c/errors"
+	"dxkite.cn/c/target"
+	"dxkite.cn/c/token"
+	"testing"
+)
+
+type literalToken string
+
+func (t literalToken) Position() token.Position {
+	return token.Position{Filename: "literal.c", Line: 1, Column: 1}
+}
+func (t literalToken) Type() token.Type { return token.INT }
+func (t literalToken) Literal() string  { return string(t) }
+
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		lit   string
+		tgt   *target.Target
+		typ   Type
+		int   uint64
+		float float64
+		code  errors.ErrCode
+	}{
+		{"0", target.LP64, Int, 0, 0, 0},
+		{"10", target.LP64, Int, 10, 0, 0},
+		{"10L", target.LP64, Long, 10, 0, 0},
+		{"10l", target.LP64, Long, 10, 0, 0},
+		{"10ull", target.LP64, UnsignedLongLong, 10, 0, 0},
+		{"10LLu", target.LP64, UnsignedLongLong, 10, 0, 0},
+		{"10uz", target.LP64, Size, 10, 0, 0},
+		{"10z", target.LP64, SignedSize, 10, 0, 0},
+		{"017", target.LP64, Int, 15, 0, 0},
+		{"0x1F", target.LP64, Int, 31, 0, 0},
+		{"0b1010", target.LP64, Int, 10, 0, 0},
+		{"1'000'000", target.LP64, Int, 1000000, 0, 0},
+		{"2147483647", target.LP64, Int, 2147483647, 0, 0},
+		{"2147483648", target.LP64, Long, 2147483648, 0, 0},
+		{"2147483648", target.ILP32, LongLong, 2147483648, 0, 0},
+		{"0x80000000", target.LP64, UnsignedInt, 0x80000000, 0, 0},
+		{"0x8000000000000000", target.LP64, UnsignedLong, 0x8000000000000000, 0, 0},
+		{"0x8000000000000000", target.LLP64, UnsignedLongLong, 0x8000000000000000, 0, 0},
+		{"4294967296u", target.ILP32, UnsignedLongLong, 4294967296, 0, 0},
+		{"1.5", target.LP64, Double, 0, 1.5, 0},
+		{".5f", target.LP64, Float, 0, 0.5, 0},
+		{"1e3L", target.LP64, LongDouble, 0, 1000, 0},
+		{"0x1.8p1", target.LP64, Double, 0, 3, 0},
+		{"1'0.2'5", target.LP64, Double, 0, 10.25, 0},
+		{"09", target.LP64, Invalid, 0, 0, errors.ErrLiteralInvalidDigit},
+		{"0b102", target.LP64, Invalid, 0, 0, errors.ErrLiteralInvalidDigit},
+		{"10lL", target.LP64, Invalid, 0, 0, errors.ErrLiteralInvalidSuffix},
+		{"10uu", target.LP64, Invalid, 0, 0, errors.ErrLiteralInvalidSuffix},
+		{"1.5u", target.LP64, Invalid, 0, 0, errors.ErrLiteralInvalidSuffix},
+		{"1'", target.LP64, Invalid, 0, 0, errors.ErrLiteralSeparator},
+		{"0x'1", target.LP64, Invalid, 0, 0, errors.ErrLiteralSeparator},
+		{"0x", target.LP64, Invalid, 0, 0, errors.ErrLiteralNoDigits},
+		{"1e+", target.LP64, Invalid, 0, 0, errors.ErrLiteralExponent},
+		{"0x1.8", target.LP64, Invalid, 0, 0, errors.ErrLiteralHexFloatExponent},
+		{"18446744073709551616", target.LP64, LongLong, 0, 0, errors.ErrLiteralIntRange},
+		{"9223372036854775808", target.LP64, LongLong, 9223372036854775808, 0, errors.ErrLiteralIntRange},
+		{"1e40f", target.LP64, Float, 0, 0, errors.ErrLiteralFloatRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.lit, func(t *testing.T) {
+			n, err := ParseNumber(literalToken(tt.lit), tt.tgt)
+			if err != nil || tt.code != 0 {
+				if err == nil || err.Code != tt.code {
+					t.Fatalf("want error %v got %v", tt.code, err)
+				}
+				if tt.typ != Invalid && n.Type != tt.typ {
+					t.Errorf("want type %v got %v", tt.typ, n.Type)
+				}
+				return
+			}
+			if n.Type != tt.typ || n.Int != tt.int || n.Float != tt.float {
+				t.Errorf("want %v %v %v got %v %v %v", tt.typ, tt.int, tt.float, n.Type, n.Int, n.Float)
+			}
+		})
+	}
+}
+
+func TestIsFloat(t *testing.T) {
+	tests := map[string]bool{
+		"10L": false, "10lu": false, "0x1e": false, "0b11": false,
+		"1.": true, "1e3": true, "0x1p3": true, ".5f": true,
+	}
+	for lit, want := range tests {
+		if got := IsFloat(lit); got != want {
+			t.Errorf("IsFloat(%s) want %v got %v", lit, want, got)
+		}
+	}
+}
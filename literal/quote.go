@@ -0,0 +1,304 @@
+package literal
+
+import (
+	"dxkite.cn/c/errors"
+	"dxkite.cn/c/target"
+	"dxkite.cn/c/token"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// 字符串与字符常量的编码
+type Encoding int
+
+const (
+	EncodingNone  Encoding = iota // 无前缀 char
+	EncodingUTF8                  // u8
+	EncodingUTF16                 // u char16_t
+	EncodingUTF32                 // U char32_t
+	EncodingWide                  // L wchar_t
+)
+
+// 编码前缀
+func (e Encoding) Prefix() string {
+	switch e {
+	case EncodingUTF8:
+		return "u8"
+	case EncodingUTF16:
+		return "u"
+	case EncodingUTF32:
+		return "U"
+	case EncodingWide:
+		return "L"
+	}
+	return ""
+}
+
+func (e Encoding) String() string {
+	if e == EncodingNone {
+		return "char"
+	}
+	return e.Prefix()
+}
+
+// 编码单元的大小
+func (e Encoding) UnitSize(tgt *target.Target) int {
+	switch e {
+	case EncodingUTF16:
+		return 2
+	case EncodingUTF32:
+		return 4
+	case EncodingWide:
+		return tgt.WChar
+	}
+	return 1
+}
+
+// 字符串字面量
+type String struct {
+	Encoding Encoding
+	Units    []uint32 // 编码单元，不包含结尾的 0
+}
+
+// 字符串数组的大小，包含结尾的 0
+func (s *String) Size(tgt *target.Target) int {
+	return (len(s.Units) + 1) * s.Encoding.UnitSize(tgt)
+}
+
+// 字符常量
+type Char struct {
+	Encoding Encoding
+	Value    int64
+	Multi    bool // 多字符常量 'ab'
+}
+
+// 解析字符串字面量
+func ParseString(tok token.Token, tgt *target.Target) (*String, *errors.Error) {
+	return ParseStrings([]token.Token{tok}, tgt)
+}
+
+// 解析并连接相邻的字符串字面量
+func ParseStrings(toks []token.Token, tgt *target.Target) (*String, *errors.Error) {
+	s := &String{}
+	enc, err := StringEncoding(toks)
+	if err != nil {
+		return s, err
+	}
+	s.Encoding = enc
+	for _, tok := range toks {
+		_, body := splitPrefix(tok.Literal(), '"')
+		d := &quoteDecoder{pos: tok.Position(), enc: enc, size: enc.UnitSize(tgt)}
+		s.Units = append(s.Units, d.decode(body)...)
+		if d.err != nil {
+			return s, d.err
+		}
+	}
+	return s, nil
+}
+
+// 相邻字符串连接后的编码，无前缀的字符串使用其他部分的编码
+func StringEncoding(toks []token.Token) (Encoding, *errors.Error) {
+	enc := EncodingNone
+	var prev token.Token
+	for _, tok := range toks {
+		e, _ := splitPrefix(tok.Literal(), '"')
+		if e == EncodingNone {
+			continue
+		}
+		if enc != EncodingNone && e != enc {
+			return enc, errors.New(tok.Position(), errors.ErrLiteralStringEncoding, prev.Literal(), tok.Literal())
+		}
+		enc = e
+		prev = tok
+	}
+	return enc, nil
+}
+
+// 解析字符常量
+func ParseChar(tok token.Token, tgt *target.Target) (*Char, *errors.Error) {
+	enc, body := splitPrefix(tok.Literal(), '\'')
+	c := &Char{Encoding: enc}
+	d := &quoteDecoder{pos: tok.Position(), enc: enc, size: enc.UnitSize(tgt)}
+	units := d.decode(body)
+	if d.err != nil {
+		return c, d.err
+	}
+	if len(units) == 0 {
+		return c, errors.New(tok.Position(), errors.ErrLiteralEmptyChar)
+	}
+	if enc != EncodingNone {
+		// 带前缀的字符常量只能有一个编码单元
+		if len(units) > 1 {
+			return c, errors.New(tok.Position(), errors.ErrLiteralCharRange, tok.Literal())
+		}
+		c.Value = int64(units[0])
+		if enc == EncodingWide && tgt.WChar == 4 {
+			c.Value = int64(int32(units[0]))
+		}
+		return c, nil
+	}
+	// 多字符常量按字节依次拼接，值为 int
+	if len(units) == 1 {
+		c.Value = int64(uint8(units[0]))
+		if tgt.CharSigned {
+			c.Value = int64(int8(units[0]))
+		}
+		return c, nil
+	}
+	c.Multi = true
+	var v uint32
+	for _, u := range units {
+		v = v<<8 | u
+	}
+	c.Value = int64(int32(v))
+	return c, nil
+}
+
+// 分离编码前缀与引号内的内容
+func splitPrefix(lit string, quote byte) (Encoding, string) {
+	enc := EncodingNone
+	switch {
+	case strings.HasPrefix(lit, "u8"):
+		enc, lit = EncodingUTF8, lit[2:]
+	case strings.HasPrefix(lit, "u"):
+		enc, lit = EncodingUTF16, lit[1:]
+	case strings.HasPrefix(lit, "U"):
+		enc, lit = EncodingUTF32, lit[1:]
+	case strings.HasPrefix(lit, "L"):
+		enc, lit = EncodingWide, lit[1:]
+	}
+	if len(lit) > 0 && lit[0] == quote {
+		lit = lit[1:]
+	}
+	if len(lit) > 0 && lit[len(lit)-1] == quote {
+		lit = lit[:len(lit)-1]
+	}
+	return enc, lit
+}
+
+// 转义序列解码
+type quoteDecoder struct {
+	pos  token.Position
+	enc  Encoding
+	size int // 编码单元大小
+	err  *errors.Error
+}
+
+func (d *quoteDecoder) newErr(code errors.ErrCode, params ...interface{}) {
+	if d.err == nil {
+		d.err = errors.New(d.pos, code, params...)
+	}
+}
+
+// 编码单元的最大值
+func (d *quoteDecoder) max() uint64 {
+	return 1<<(uint(d.size)*8) - 1
+}
+
+func (d *quoteDecoder) decode(s string) (units []uint32) {
+	for i := 0; i < len(s); {
+		if s[i] != '\\' {
+			r, w := utf8.DecodeRuneInString(s[i:])
+			units = d.encode(units, r)
+			i += w
+			continue
+		}
+		if i+1 >= len(s) {
+			d.newErr(errors.ErrLiteralUnknownEscape, `\`)
+			return
+		}
+		start := i
+		c := s[i+1]
+		i += 2
+		switch c {
+		case 'a':
+			units = append(units, 7)
+		case 'b':
+			units = append(units, 8)
+		case 'f':
+			units = append(units, 12)
+		case 'n':
+			units = append(units, 10)
+		case 'r':
+			units = append(units, 13)
+		case 't':
+			units = append(units, 9)
+		case 'v':
+			units = append(units, 11)
+		case '\\', '\'', '"', '?':
+			units = append(units, uint32(c))
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			v := uint64(c - '0')
+			for n := 1; n < 3 && i < len(s) && s[i] >= '0' && s[i] <= '7'; n++ {
+				v = v*8 + uint64(s[i]-'0')
+				i++
+			}
+			units = d.unit(units, v, s[start:i])
+		case 'x':
+			v := uint64(0)
+			overflow := false
+			for ; i < len(s) && digitVal(s[i]) < 16; i++ {
+				overflow = overflow || v>>60 != 0
+				v = v*16 + uint64(digitVal(s[i]))
+			}
+			if i == start+2 {
+				d.newErr(errors.ErrLiteralUnknownEscape, s[start:i])
+				return
+			}
+			if overflow {
+				v = d.max() + 1
+			}
+			units = d.unit(units, v, s[start:i])
+		case 'u', 'U':
+			n := 4
+			if c == 'U' {
+				n = 8
+			}
+			v := uint64(0)
+			for ; n > 0 && i < len(s) && digitVal(s[i]) < 16; n-- {
+				v = v*16 + uint64(digitVal(s[i]))
+				i++
+			}
+			r := rune(v)
+			if n > 0 || v > utf8.MaxRune || r >= 0xd800 && r <= 0xdfff {
+				d.newErr(errors.ErrLiteralInvalidUCN, s[start:i])
+				return
+			}
+			units = d.encode(units, r)
+		default:
+			_, w := utf8.DecodeRuneInString(s[i-1:])
+			d.newErr(errors.ErrLiteralUnknownEscape, s[start:i-1+w])
+			return
+		}
+	}
+	return
+}
+
+// 数字转义序列直接作为编码单元
+func (d *quoteDecoder) unit(units []uint32, v uint64, esc string) []uint32 {
+	if v > d.max() {
+		d.newErr(errors.ErrLiteralEscapeRange, esc, d.enc.String())
+	}
+	return append(units, uint32(v))
+}
+
+// 按编码转换字符
+func (d *quoteDecoder) encode(units []uint32, r rune) []uint32 {
+	switch d.size {
+	case 1:
+		var b [utf8.UTFMax]byte
+		n := utf8.EncodeRune(b[:], r)
+		for _, c := range b[:n] {
+			units = append(units, uint32(c))
+		}
+	case 2:
+		if r1, r2 := utf16.EncodeRune(r); r1 != utf8.RuneError {
+			return append(units, uint32(r1), uint32(r2))
+		}
+		units = append(units, uint32(r))
+	default:
+		units = append(units, uint32(r))
+	}
+	return units
+}
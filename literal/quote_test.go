@@ -0,0 +1,92 @@
+package literal
+
+import (
+	"dxkite.cn/c/errors"
+	"dxkite.cn/c/target"
+	"dxkite.cn/c/token"
+	"reflect"
+	"testing"
+)
+
+func TestParseChar(t *testing.T) {
+	tests := []struct {
+		lit   string
+		tgt   *target.Target
+		value int64
+		multi bool
+		code  errors.ErrCode
+	}{
+		{`'a'`, target.LP64, 'a', false, 0},
+		{`'\n'`, target.LP64, '\n', false, 0},
+		{`'\0'`, target.LP64, 0, false, 0},
+		{`'\101'`, target.LP64, 'A', false, 0},
+		{`'\x41'`, target.LP64, 'A', false, 0},
+		{`'\xff'`, target.LP64, -1, false, 0},
+		{`'ab'`, target.LP64, 'a'<<8 | 'b', true, 0},
+		{`u8'a'`, target.LP64, 'a', false, 0},
+		{`u'中'`, target.LP64, '中', false, 0},
+		{`U'\U0001F600'`, target.LP64, 0x1F600, false, 0},
+		{`L'\xffffffff'`, target.LP64, -1, false, 0},
+		{`L'中'`, target.LLP64, 0x4e2d, false, 0},
+		{`''`, target.LP64, 0, false, errors.ErrLiteralEmptyChar},
+		{`'\q'`, target.LP64, 0, false, errors.ErrLiteralUnknownEscape},
+		{`'\x100'`, target.LP64, 0, false, errors.ErrLiteralEscapeRange},
+		{`'\777'`, target.LP64, 0, false, errors.ErrLiteralEscapeRange},
+		{`'\u12'`, target.LP64, 0, false, errors.ErrLiteralInvalidUCN},
+		{`'\ud800'`, target.LP64, 0, false, errors.ErrLiteralInvalidUCN},
+		{`u'\U0001F600'`, target.LP64, 0, false, errors.ErrLiteralCharRange},
+		{`u8'中'`, target.LP64, 0, false, errors.ErrLiteralCharRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.lit, func(t *testing.T) {
+			c, err := ParseChar(literalToken(tt.lit), tt.tgt)
+			if err != nil || tt.code != 0 {
+				if err == nil || err.Code != tt.code {
+					t.Fatalf("want error %v got %v", tt.code, err)
+				}
+				return
+			}
+			if c.Value != tt.value || c.Multi != tt.multi {
+				t.Errorf("want %d %v got %d %v", tt.value, tt.multi, c.Value, c.Multi)
+			}
+		})
+	}
+}
+
+func TestParseStrings(t *testing.T) {
+	tests := []struct {
+		name  string
+		parts []string
+		enc   Encoding
+		units []uint32
+		size  int
+		code  errors.ErrCode
+	}{
+		{"plain", []string{`"a\tb"`}, EncodingNone, []uint32{'a', '\t', 'b'}, 4, 0},
+		{"utf8", []string{`u8"中"`}, EncodingUTF8, []uint32{0xe4, 0xb8, 0xad}, 4, 0},
+		{"utf16", []string{`u"\U0001F600"`}, EncodingUTF16, []uint32{0xd83d, 0xde00}, 6, 0},
+		{"utf32", []string{`U"a中"`}, EncodingUTF32, []uint32{'a', 0x4e2d}, 12, 0},
+		{"hex-split", []string{`"\x1"`, `"2"`}, EncodingNone, []uint32{1, '2'}, 3, 0},
+		{"concat-prefix", []string{`"a"`, `L"b"`, `"c"`}, EncodingWide, []uint32{'a', 'b', 'c'}, 16, 0},
+		{"concat-mixed", []string{`u8"a"`, `L"b"`}, EncodingUTF8, nil, 0, errors.ErrLiteralStringEncoding},
+		{"range", []string{`u"\x10000"`}, EncodingUTF16, nil, 0, errors.ErrLiteralEscapeRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var toks []token.Token
+			for _, p := range tt.parts {
+				toks = append(toks, literalToken(p))
+			}
+			s, err := ParseStrings(toks, target.LP64)
+			if err != nil || tt.code != 0 {
+				if err == nil || err.Code != tt.code {
+					t.Fatalf("want error %v got %v", tt.code, err)
+				}
+				return
+			}
+			if s.Encoding != tt.enc || !reflect.DeepEqual(s.Units, tt.units) || s.Size(target.LP64) != tt.size {
+				t.Errorf("want %v %v %d got %v %v %d", tt.enc, tt.units, tt.size, s.Encoding, s.Units, s.Size(target.LP64))
+			}
+		})
+	}
+}
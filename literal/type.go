@@ -0,0 +1,69 @@
+//go:generate stringer -type Type -linecomment
+package literal
+
+import "dxkite.cn/c/target"
+
+// 字面量的C语言类型
+type Type int
+
+const (
+	Invalid          Type = iota // invalid
+	Int                          // int
+	UnsignedInt                  // unsigned int
+	Long                         // long
+	UnsignedLong                 // unsigned long
+	LongLong                     // long long
+	UnsignedLongLong             // unsigned long long
+	SignedSize                   // signed size_t
+	Size                         // size_t
+	Float                        // float
+	Double                       // double
+	LongDouble                   // long double
+)
+
+// 是否为浮点类型
+func (t Type) IsFloat() bool {
+	return t >= Float && t <= LongDouble
+}
+
+// 是否为无符号整数类型
+func (t Type) IsUnsigned() bool {
+	switch t {
+	case UnsignedInt, UnsignedLong, UnsignedLongLong, Size:
+		return true
+	}
+	return false
+}
+
+// 类型在目标平台上的大小
+func (t Type) Size(tgt *target.Target) int {
+	switch t {
+	case Int, UnsignedInt:
+		return tgt.Int
+	case Long, UnsignedLong:
+		return tgt.Long
+	case LongLong, UnsignedLongLong:
+		return tgt.LongLong
+	case SignedSize, Size:
+		return tgt.SizeT
+	case Float:
+		return tgt.Float
+	case Double:
+		return tgt.Double
+	case LongDouble:
+		return tgt.LongDouble
+	}
+	return 0
+}
+
+// 整数类型可表示的最大值
+func (t Type) max(tgt *target.Target) uint64 {
+	bits := uint(t.Size(tgt) * 8)
+	if !t.IsUnsigned() {
+		bits--
+	}
+	if bits >= 64 {
+		return 1<<64 - 1
+	}
+	return 1<<bits - 1
+}
@@ -0,0 +1,35 @@
+// Code generated by "stringer -type Type -linecomment"; DO NOT EDIT.
+
+package literal
+
+import "strconv"
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Invalid-0]
+	_ = x[Int-1]
+	_ = x[UnsignedInt-2]
+	_ = x[Long-3]
+	_ = x[UnsignedLong-4]
+	_ = x[LongLong-5]
+	_ = x[UnsignedLongLong-6]
+	_ = x[SignedSize-7]
+	_ = x[Size-8]
+	_ = x[Float-9]
+	_ = x[Double-10]
+	_ = x[LongDouble-11]
+}
+
+const _Type_name = "invalidintunsigned intlongunsigned longlong longunsigned long longsigned size_tsize_tfloatdoublelong double"
+
+var _Type_index = [...]uint8{0, 7, 10, 22, 26, 39, 48, 66, 79, 85, 90, 96, 107}
+
+func (i Type) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Type_index)-1 {
+		return "Type(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Type_name[_Type_index[idx]:_Type_index[idx+1]]
+}
@@ -0,0 +1,416 @@
+package parser
+
+import (
+	"dxkite.cn/c/ast"
+	"dxkite.cn/c/errors"
+	"dxkite.cn/c/token"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GNU 属性说明符，标准模式下 __attribute__ 是标识符
+func (p *parser) isGNUAttribute() bool {
+	if t := p.cur.Type(); t != token.KEYWORD && t != token.IDENT {
+		return false
+	}
+	lit := p.cur.Literal()
+	return lit == "__attribute__" || lit == "__attribute"
+}
+
+// [[ 开始属性说明符
+func (p *parser) isAttributeSpecifier() bool {
+	return p.cur.Type() == token.PUNCTUATOR && p.cur.Literal() == "[" && p.peekOne().Literal() == "["
+}
+
+// 是否为属性说明符
+func (p *parser) isAttribute() bool {
+	return p.isGNUAttribute() || p.isAttributeSpecifier()
+}
+
+// 连续的属性说明符，GNU 与 C23 形式可以混用
+func (p *parser) parseAttributes() []*ast.Attribute {
+	var attrs []*ast.Attribute
+	for {
+		switch {
+		case p.isGNUAttribute():
+			attrs = append(attrs, p.parseGNUAttributes()...)
+		case p.isAttributeSpecifier():
+			attrs = append(attrs, p.parseAttributeSpecifier()...)
+		default:
+			return attrs
+		}
+	}
+}
+
+// [[ attribute-list ]]，属性可以带有 prefix :: 前缀
+func (p *parser) parseAttributeSpecifier() []*ast.Attribute {
+	p.next() // [
+	p.next() // [
+	var attrs []*ast.Attribute
+	for p.until("]") {
+		if p.cur.Literal() == "," {
+			p.next()
+			continue
+		}
+		attr := &ast.Attribute{Name: p.cur, Std: true}
+		if t := p.cur.Type(); t != token.IDENT && t != token.KEYWORD {
+			p.addErr(p.cur.Position(), errors.ErrSyntaxExpectedIdentGot, p.cur.Literal())
+		}
+		p.next()
+		// prefix :: name
+		if p.cur.Literal() == ":" && p.peekOne().Literal() == ":" {
+			p.next()
+			p.next()
+			attr.Prefix = attr.Name
+			attr.Name = p.cur
+			p.next()
+		}
+		if p.cur.Literal() == "(" {
+			// 未知属性的参数是任意的符号序列
+			if _, ok := lookupAttribute(attr); ok {
+				p.next() // (
+				attr.Args = p.parseAttributeArgs()
+				p.exceptPunctuator(")")
+			} else {
+				p.skipBalanced()
+			}
+		}
+		p.checkAttribute(attr)
+		attrs = append(attrs, attr)
+		if p.cur.Literal() != "," {
+			break
+		}
+	}
+	p.exceptPunctuator("]")
+	p.exceptPunctuator("]")
+	return attrs
+}
+
+// 跳过成对的括号
+func (p *parser) skipBalanced() {
+	depth := 0
+	for p.cur.Type() != token.EOF {
+		switch p.cur.Literal() {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+		}
+		p.next()
+		if depth == 0 {
+			return
+		}
+	}
+}
+
+// 连续的 __attribute__ (( attribute-list ))
+func (p *parser) parseGNUAttributes() []*ast.Attribute {
+	var attrs []*ast.Attribute
+	for p.isGNUAttribute() {
+		p.next() // __attribute__
+		p.exceptPunctuator("(")
+		p.exceptPunctuator("(")
+		for p.until(")") {
+			// 属性列表中可以有空的项
+			if p.cur.Literal() == "," {
+				p.next()
+				continue
+			}
+			attrs = append(attrs, p.parseGNUAttribute())
+			if p.cur.Literal() != "," {
+				break
+			}
+		}
+		p.exceptPunctuator(")")
+		p.exceptPunctuator(")")
+	}
+	return attrs
+}
+
+// name 或 name ( args )，属性名称可以是关键字
+func (p *parser) parseGNUAttribute() *ast.Attribute {
+	attr := &ast.Attribute{Name: p.cur}
+	if t := p.cur.Type(); t != token.IDENT && t != token.KEYWORD {
+		p.addErr(p.cur.Position(), errors.ErrSyntaxExpectedIdentGot, p.cur.Literal())
+	}
+	p.next()
+	if p.cur.Literal() == "(" {
+		p.next() // (
+		attr.Args = p.parseAttributeArgs()
+		p.exceptPunctuator(")")
+	}
+	p.checkAttribute(attr)
+	return attr
+}
+
+// 属性参数，单独的标识符不作为变量引用解析，如 format(printf, 1, 2)
+func (p *parser) parseAttributeArgs() []ast.Expr {
+	var args []ast.Expr
+	for p.until(")") {
+		if next := p.peekOne().Literal(); p.cur.Type() == token.IDENT && (next == "," || next == ")") {
+			args = append(args, &ast.Ident{Token: p.cur})
+			p.next()
+		} else {
+			args = append(args, p.parseAssignExpr())
+		}
+		if p.cur.Literal() != "," {
+			break
+		}
+		p.next() // ,
+	}
+	return args
+}
+
+// 查找属性的说明，[[ ]] 中不带前缀的为标准属性，gnu 前缀与 __attribute__ 为 GNU 属性
+func lookupAttribute(attr *ast.Attribute) (*ast.AttributeSpec, bool) {
+	if strings.Contains(attr.Canonical(), "::") {
+		return nil, false
+	}
+	spec, ok := ast.LookupAttribute(attr.Name.Literal())
+	if !ok {
+		return nil, false
+	}
+	syntax := ast.AttrGNU
+	if attr.Std && attr.Prefix == nil {
+		syntax = ast.AttrStd
+	}
+	return spec, spec.Syntax&syntax != 0
+}
+
+func attributeString(attr *ast.Attribute) string {
+	if attr.Prefix != nil {
+		return attr.Prefix.Literal() + "::" + attr.Name.Literal()
+	}
+	return attr.Name.Literal()
+}
+
+// 检查已知属性的参数数量，未知属性给出警告
+func (p *parser) checkAttribute(attr *ast.Attribute) {
+	spec, ok := lookupAttribute(attr)
+	if !ok {
+		// 其他实现的属性直接忽略
+		if !strings.Contains(attr.Canonical(), "::") {
+			p.addWarn(attr.Name.Position(), errors.ErrSyntaxAttributeUnknown, attributeString(attr))
+		}
+		return
+	}
+	n := len(attr.Args)
+	if n >= spec.MinArgs && (spec.MaxArgs < 0 || n <= spec.MaxArgs) {
+		p.checkAlignedAttr(attr)
+		return
+	}
+	want := strconv.Itoa(spec.MinArgs)
+	if spec.MaxArgs < 0 {
+		want = "至少 " + want
+	} else if spec.MaxArgs != spec.MinArgs {
+		want += " 到 " + strconv.Itoa(spec.MaxArgs)
+	}
+	p.addErr(attr.Name.Position(), errors.ErrSyntaxAttributeArgCount, attributeString(attr), want, n)
+}
+
+// noreturn 属性与 _Noreturn 等价
+func withNoreturn(spec *ast.FunctionSpecifier, attrs []*ast.Attribute) *ast.FunctionSpecifier {
+	attr := ast.FindAttribute(attrs, "noreturn")
+	if attr == nil {
+		attr = ast.FindAttribute(attrs, "_Noreturn")
+	}
+	if attr == nil {
+		return spec
+	}
+	if _, ok := (*spec)["_Noreturn"]; ok {
+		return spec
+	}
+	// 同一声明中的声明符共用说明符
+	s := ast.FunctionSpecifier{"_Noreturn": attr.Name.Position()}
+	for k, v := range *spec {
+		s[k] = v
+	}
+	return &s
+}
+
+// 属性的说明文字，如 deprecated("reason")
+func attrReason(attr *ast.Attribute) string {
+	if len(attr.Args) > 0 {
+		if lit, ok := attr.Args[0].(*ast.BasicLit); ok && lit.Type() == token.STRING {
+			return "，" + lit.Literal()
+		}
+	}
+	return ""
+}
+
+// 声明中的属性
+func declAttrs(decl ast.Decl) []*ast.Attribute {
+	switch d := decl.(type) {
+	case *ast.VarDecl:
+		return d.Attrs
+	case *ast.FuncDecl:
+		return d.Attrs
+	case *ast.TypedefDecl:
+		return d.Attrs
+	case *ast.ParamVarDecl:
+		return d.Attrs
+	case *ast.EnumFieldDecl:
+		return d.Attrs
+	}
+	return nil
+}
+
+// struct、union 或 enum 标签上的属性
+func tagAttrs(typ ast.Typename) []*ast.Attribute {
+	switch t := typ.(type) {
+	case *ast.RecordType:
+		return t.Attrs
+	case *ast.EnumType:
+		return t.Attrs
+	}
+	return nil
+}
+
+// 引用已弃用的实体
+func (p *parser) checkDeprecated(ident *ast.Ident, obj *ast.Object) {
+	if attr := ast.FindAttribute(obj.Attrs, "deprecated"); attr != nil {
+		p.addWarn(ident.Position(), errors.ErrSyntaxDeprecated, ident.Literal(), attrReason(attr))
+	}
+}
+
+// 引用已弃用的 struct、union 或 enum 标签
+func (p *parser) checkDeprecatedTag(name *ast.Ident, kind string) {
+	if name == nil {
+		return
+	}
+	scope := ast.StructScope
+	switch kind {
+	case "union":
+		scope = ast.UnionScope
+	case "enum":
+		scope = ast.EnumScope
+	}
+	if obj := p.env.tryResolve(scope, name.Literal()); obj != nil {
+		if attr := ast.FindAttribute(obj.Attrs, "deprecated"); attr != nil {
+			p.addWarn(name.Position(), errors.ErrSyntaxDeprecated, kind+" "+name.Literal(), attrReason(attr))
+		}
+	}
+}
+
+// 表达式语句丢弃了 nodiscard 函数的返回值
+func (p *parser) checkNodiscard(expr ast.Expr) {
+	for {
+		paren, ok := expr.(*ast.ParenExpr)
+		if !ok {
+			break
+		}
+		expr = paren.X
+	}
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	ident, ok := call.Func.(*ast.Ident)
+	if !ok {
+		return
+	}
+	obj := p.env.tryResolve(ast.IdentScope, ident.Literal())
+	if obj == nil {
+		return
+	}
+	attr := ast.FindAttribute(obj.Attrs, "nodiscard")
+	if attr == nil {
+		attr = ast.FindAttribute(obj.Attrs, "warn_unused_result")
+	}
+	if attr != nil {
+		p.addWarn(ident.Position(), errors.ErrSyntaxNodiscard, ident.Literal(), attrReason(attr))
+	}
+}
+
+// fallthrough 只能用于紧接着 case 或 default 标签的空语句
+func (p *parser) checkFallthrough(stmts []ast.Stmt) {
+	for i, stmt := range stmts {
+		s, ok := lastLabeledStmt(stmt).(*ast.AttributedStmt)
+		if !ok {
+			continue
+		}
+		attr := ast.FindAttribute(s.Attrs, "fallthrough")
+		if attr == nil {
+			continue
+		}
+		if _, ok := s.Stmt.(*ast.EmptyStmt); ok && i+1 < len(stmts) && isSwitchLabel(stmts[i+1]) {
+			continue
+		}
+		p.addErr(attr.Name.Position(), errors.ErrSyntaxFallthroughMisplaced)
+	}
+}
+
+// 标签语句中标签之后的语句
+func lastLabeledStmt(stmt ast.Stmt) ast.Stmt {
+	switch s := stmt.(type) {
+	case *ast.CaseStmt:
+		return lastLabeledStmt(s.Stmt)
+	case *ast.CaseRangeStmt:
+		return lastLabeledStmt(s.Stmt)
+	case *ast.DefaultStmt:
+		return lastLabeledStmt(s.Stmt)
+	case *ast.LabelStmt:
+		return lastLabeledStmt(s.Stmt)
+	}
+	return stmt
+}
+
+func isSwitchLabel(stmt ast.Stmt) bool {
+	switch s := stmt.(type) {
+	case *ast.CaseStmt, *ast.CaseRangeStmt, *ast.DefaultStmt:
+		return true
+	case *ast.AttributedStmt:
+		return isSwitchLabel(s.Stmt)
+	}
+	return false
+}
+
+// 未被引用的实体，maybe_unused 与 unused 属性可以抑制警告
+func isUnused(obj *ast.Object) bool {
+	return !obj.Used && ast.FindAttribute(obj.Attrs, "maybe_unused") == nil && ast.FindAttribute(obj.Attrs, "unused") == nil
+}
+
+// 报告作用域中未使用的变量，文件作用域中只报告具有内部链接的变量与函数
+func (p *parser) reportUnused(scope *ast.Scope, fileScope bool) {
+	var objs []*ast.Object
+	for _, obj := range scope.Objects[ast.IdentScope] {
+		if !isUnused(obj) {
+			continue
+		}
+		switch decl := obj.Decl.(type) {
+		case *ast.VarDecl:
+			if _, ok := unParen(decl.Type).(*ast.FuncType); ok {
+				continue
+			}
+			if _, ok := (*decl.Qua)["extern"]; ok {
+				continue
+			}
+			if _, ok := (*decl.Qua)["static"]; ok || !fileScope {
+				objs = append(objs, obj)
+			}
+		case *ast.FuncDecl:
+			if !fileScope || !obj.Completed {
+				continue
+			}
+			_, static := (*decl.Qua)["static"]
+			_, inline := (*decl.Spec)["inline"]
+			if static && !inline {
+				objs = append(objs, obj)
+			}
+		}
+	}
+	sort.Slice(objs, func(i, j int) bool {
+		if objs[i].Pos.Line != objs[j].Pos.Line {
+			return objs[i].Pos.Line < objs[j].Pos.Line
+		}
+		return objs[i].Pos.Column < objs[j].Pos.Column
+	})
+	for _, obj := range objs {
+		code := errors.ErrSyntaxUnusedVar
+		if obj.Type == ast.ObjectFunc {
+			code = errors.ErrSyntaxUnusedFunc
+		}
+		p.addWarn(obj.Pos, code, obj.Name)
+	}
+}
@@ -0,0 +1,809 @@
+package parser
+
+import (
+	"dxkite.cn/c/ast"
+	"dxkite.cn/c/errors"
+	"dxkite.cn/c/literal"
+	"dxkite.cn/c/target"
+	"dxkite.cn/c/token"
+	"strconv"
+)
+
+// 整数常量，值按类型的宽度截断后扩展为 int64
+type constValue struct {
+	val int64
+	typ ast.BasicType
+}
+
+func (v constValue) unsigned(tgt *target.Target) bool {
+	return v.typ.IsUnsigned() || v.typ == ast.Char && !tgt.CharSigned
+}
+
+// 计算整数常量表达式，不是常量表达式时报告错误并返回 false
+func (p *parser) evalConst(expr ast.Expr) (int64, bool) {
+	v, ok := p.constExpr(expr)
+	return v.val, ok
+}
+
+// 尝试计算整数常量表达式，不是常量表达式时返回 false 且不报告错误
+func (p *parser) tryConst(expr ast.Expr) (int64, bool) {
+	err := p.err
+	p.err = func(token.Position, errors.ErrorType, errors.ErrCode, ...interface{}) {}
+	defer func() { p.err = err }()
+	return p.evalConst(expr)
+}
+
+func (p *parser) notConst(expr ast.Expr) (constValue, bool) {
+	p.addErr(expr.Beg(), errors.ErrSyntaxNotConstant)
+	return constValue{}, false
+}
+
+func (p *parser) constExpr(expr ast.Expr) (constValue, bool) {
+	switch x := expr.(type) {
+	case *ast.ConstantExpr:
+		return p.constExpr(x.X)
+	case *ast.ParenExpr:
+		return p.constExpr(x.X)
+	case *ast.BasicLit:
+		return p.constLit(x)
+	case *ast.Ident:
+		obj := p.env.tryResolve(ast.IdentScope, x.Literal())
+		if obj == nil {
+			// 未定义的标识符已经报告过
+			return constValue{}, false
+		}
+		if v, ok := obj.Decl.(*ast.EnumFieldDecl); ok && obj.Type == ast.ObjectEnumTag {
+			return constValue{val: p.env.enums[v], typ: ast.Int}, true
+		}
+		return p.notConst(x)
+	case *ast.SizeOfExpr:
+		size, _, ok := p.typeLayout(x.Type, x.Beg())
+		return constValue{val: size, typ: sizeType(p.opt.Target)}, ok
+	case *ast.AlignOfExpr:
+		align, ok := p.naturalAlign(x.Type, x.Beg())
+		return constValue{val: align, typ: sizeType(p.opt.Target)}, ok
+	case *ast.UnaryExpr:
+		return p.constUnary(x)
+	case *ast.BinaryExpr:
+		return p.constBinary(x)
+	case *ast.CondExpr:
+		c, ok := p.constExpr(x.X)
+		if !ok {
+			return c, false
+		}
+		if c.val != 0 {
+			return p.constExpr(x.Then)
+		}
+		return p.constExpr(x.Else)
+	case *ast.TypeCastExpr:
+		return p.constCast(x)
+	case *ast.ExtensionExpr:
+		return p.constExpr(x.X)
+	case *ast.BinaryCondExpr:
+		c, ok := p.constExpr(x.X)
+		if !ok || c.val != 0 {
+			return c, ok
+		}
+		return p.constExpr(x.Else)
+	case *ast.OffsetOfExpr:
+		return p.constOffsetof(x)
+	case *ast.GenericSelectionExpr:
+		if x.Selected >= 0 {
+			return p.constExpr(x.Assocs[x.Selected].X)
+		}
+	case *ast.BadExpr:
+		return constValue{}, false
+	}
+	return p.notConst(expr)
+}
+
+func (p *parser) constLit(x *ast.BasicLit) (constValue, bool) {
+	switch x.Type() {
+	case token.INT:
+		n, err := literal.ParseNumber(x.Token, p.opt.Target)
+		if err != nil {
+			p.addErr(err.Pos, err.Code, err.Params...)
+			return constValue{}, false
+		}
+		return truncConst(int64(n.Int), intLitType(n.Type, p.opt.Target), p.opt.Target), true
+	case token.CHAR:
+		c, err := literal.ParseChar(x.Token, p.opt.Target)
+		if err != nil {
+			p.addErr(err.Pos, err.Code, err.Params...)
+			return constValue{}, false
+		}
+		return truncConst(c.Value, charLitType(c.Encoding, p.opt.Target), p.opt.Target), true
+	}
+	return p.notConst(x)
+}
+
+func (p *parser) constUnary(x *ast.UnaryExpr) (constValue, bool) {
+	switch x.Op.Literal() {
+	case "sizeof":
+		return p.constSizeofExpr(x.X)
+	case "_Alignof":
+		return p.constAlignofExpr(x.X)
+	}
+	switch x.Op.Literal() {
+	case "+", "-", "~", "!":
+	default:
+		return p.notConst(x)
+	}
+	v, ok := p.constExpr(x.X)
+	if !ok {
+		return v, false
+	}
+	typ := promoteInt(v.typ, p.opt.Target)
+	switch x.Op.Literal() {
+	case "-":
+		v.val = -v.val
+	case "~":
+		v.val = ^v.val
+	case "!":
+		return constValue{val: bool2int(v.val == 0), typ: ast.Int}, true
+	}
+	return truncConst(v.val, typ, p.opt.Target), true
+}
+
+// sizeof 表达式，操作数按表达式的类型计算大小，字符串按数组计算
+func (p *parser) constSizeofExpr(x ast.Expr) (constValue, bool) {
+	switch v := x.(type) {
+	case *ast.ParenExpr:
+		return p.constSizeofExpr(v.X)
+	case *ast.BasicLit:
+		switch v.Type() {
+		case token.STRING:
+			parts := v.Parts
+			if len(parts) == 0 {
+				parts = []token.Token{v.Token}
+			}
+			if s, err := literal.ParseStrings(parts, p.opt.Target); err == nil {
+				return constValue{val: int64(s.Size(p.opt.Target)), typ: sizeType(p.opt.Target)}, true
+			}
+			return constValue{}, false
+		case token.CHAR:
+			return constValue{val: int64(p.opt.Target.Int), typ: sizeType(p.opt.Target)}, true
+		}
+	}
+	if typ := p.exprType(x); typ != nil {
+		size, _, ok := p.typeLayout(typ, x.Beg())
+		return constValue{val: size, typ: sizeType(p.opt.Target)}, ok
+	}
+	return p.notConst(x)
+}
+
+// __alignof__ 表达式，变量按声明的对齐计算
+func (p *parser) constAlignofExpr(x ast.Expr) (constValue, bool) {
+	switch v := x.(type) {
+	case *ast.ParenExpr:
+		return p.constAlignofExpr(v.X)
+	case *ast.Ident:
+		if typ := p.identType(v); typ != nil {
+			align, ok := p.naturalAlign(typ, v.Beg())
+			if obj := p.env.tryResolve(ast.IdentScope, v.Literal()); obj != nil {
+				if decl, isVar := obj.Decl.(*ast.VarDecl); isVar {
+					align = max64(align, p.declAlign(decl.Align, decl.Attrs))
+				}
+			}
+			return constValue{val: align, typ: sizeType(p.opt.Target)}, ok
+		}
+	}
+	return p.notConst(x)
+}
+
+// 变量的类型
+func (p *parser) identType(x *ast.Ident) ast.Typename {
+	if x.Type != nil {
+		return x.Type
+	}
+	obj := p.env.tryResolve(ast.IdentScope, x.Literal())
+	if obj == nil {
+		return nil
+	}
+	switch v := obj.Decl.(type) {
+	case *ast.VarDecl:
+		return v.Type
+	case *ast.ParamVarDecl:
+		return v.Type
+	}
+	return nil
+}
+
+func (p *parser) constBinary(x *ast.BinaryExpr) (constValue, bool) {
+	l, ok := p.constExpr(x.X)
+	if !ok {
+		return l, false
+	}
+	// 短路求值
+	switch x.Op.Literal() {
+	case "&&":
+		if l.val == 0 {
+			return constValue{typ: ast.Int}, true
+		}
+	case "||":
+		if l.val != 0 {
+			return constValue{val: 1, typ: ast.Int}, true
+		}
+	}
+	r, ok := p.constExpr(x.Y)
+	if !ok {
+		return r, false
+	}
+	switch x.Op.Literal() {
+	case "<<", ">>":
+		// 移位的结果为左操作数提升后的类型
+		typ := promoteInt(l.typ, p.opt.Target)
+		a, n := truncConst(l.val, typ, p.opt.Target), uint64(r.val)&63
+		if x.Op.Literal() == "<<" {
+			return truncConst(a.val<<n, typ, p.opt.Target), true
+		}
+		if a.unsigned(p.opt.Target) {
+			return truncConst(int64(uint64(a.val)>>n), typ, p.opt.Target), true
+		}
+		return truncConst(a.val>>n, typ, p.opt.Target), true
+	}
+	// 一般算术转换后按公共类型计算
+	typ := arithConv(l.typ, r.typ, p.opt.Target)
+	l, r = truncConst(l.val, typ, p.opt.Target), truncConst(r.val, typ, p.opt.Target)
+	unsigned := l.unsigned(p.opt.Target)
+	a, b := l.val, r.val
+	ua, ub := uint64(a), uint64(b)
+	v := constValue{typ: typ}
+	switch x.Op.Literal() {
+	case "+":
+		v.val = a + b
+	case "-":
+		v.val = a - b
+	case "*":
+		v.val = a * b
+	case "/", "%":
+		if b == 0 {
+			p.addErr(x.Op.Position(), errors.ErrSyntaxDivideByZero)
+			return constValue{}, false
+		}
+		switch {
+		case x.Op.Literal() == "/" && unsigned:
+			v.val = int64(ua / ub)
+		case x.Op.Literal() == "/":
+			v.val = a / b
+		case unsigned:
+			v.val = int64(ua % ub)
+		default:
+			v.val = a % b
+		}
+	case "&":
+		v.val = a & b
+	case "|":
+		v.val = a | b
+	case "^":
+		v.val = a ^ b
+	case "&&", "||":
+		v = constValue{val: bool2int(b != 0), typ: ast.Int}
+	case "==":
+		v = constValue{val: bool2int(a == b), typ: ast.Int}
+	case "!=":
+		v = constValue{val: bool2int(a != b), typ: ast.Int}
+	case "<", ">", "<=", ">=":
+		less, greater := a < b, a > b
+		if unsigned {
+			less, greater = ua < ub, ua > ub
+		}
+		switch x.Op.Literal() {
+		case "<":
+			v = constValue{val: bool2int(less)}
+		case ">":
+			v = constValue{val: bool2int(greater)}
+		case "<=":
+			v = constValue{val: bool2int(!greater)}
+		default:
+			v = constValue{val: bool2int(!less)}
+		}
+		return constValue{val: v.val, typ: ast.Int}, true
+	default:
+		return p.notConst(x)
+	}
+	return truncConst(v.val, typ, p.opt.Target), true
+}
+
+// 整数类型转换，按目标类型截断
+func (p *parser) constCast(x *ast.TypeCastExpr) (constValue, bool) {
+	t, ok := unParen(x.Type).(*ast.BuildInType)
+	if !ok || !t.Type.IsInteger() {
+		return p.notConst(x)
+	}
+	// 浮点常量可以直接转换为整数
+	if lit, ok := x.X.(*ast.BasicLit); ok && lit.Type() == token.FLOAT {
+		n, err := literal.ParseNumber(lit.Token, p.opt.Target)
+		if err != nil {
+			p.addErr(err.Pos, err.Code, err.Params...)
+			return constValue{}, false
+		}
+		return truncConst(int64(n.Float), t.Type, p.opt.Target), true
+	}
+	v, ok := p.constExpr(x.X)
+	if !ok {
+		return v, false
+	}
+	return truncConst(v.val, t.Type, p.opt.Target), true
+}
+
+func truncConst(v int64, typ ast.BasicType, tgt *target.Target) constValue {
+	size := basicSize(typ, tgt)
+	c := constValue{val: v, typ: typ}
+	if typ == ast.Bool {
+		c.val = bool2int(v != 0)
+		return c
+	}
+	if size >= 8 {
+		return c
+	}
+	bits := uint(size * 8)
+	if c.unsigned(tgt) {
+		c.val = int64(uint64(v) & (1<<bits - 1))
+	} else {
+		c.val = v << (64 - bits) >> (64 - bits)
+	}
+	return c
+}
+
+// 整数类型的等级，指针大小的无符号整数按同样大小的类型计算
+func intRank(t ast.BasicType, tgt *target.Target) int {
+	switch t {
+	case ast.Bool:
+		return 0
+	case ast.Char, ast.SignedChar, ast.UnsignedChar:
+		return 1
+	case ast.Short, ast.UnsignedShort:
+		return 2
+	case ast.Int, ast.UnsignedInt:
+		return 3
+	case ast.Long, ast.UnsignedLong:
+		return 4
+	case ast.LongLong, ast.UnsignedLongLong:
+		return 5
+	case ast.UnsignedPointer:
+		return intRank(intType(int(basicSize(t, tgt)), true, tgt), tgt)
+	}
+	return 3
+}
+
+// 大小为 size 的整数类型，优先选择等级低的类型
+func intType(size int, unsigned bool, tgt *target.Target) ast.BasicType {
+	t := ast.LongLong
+	for _, v := range []ast.BasicType{ast.Short, ast.Int, ast.Long} {
+		if basicSize(v, tgt) == int64(size) {
+			t = v
+			break
+		}
+	}
+	if unsigned {
+		return unsignedType(t)
+	}
+	return t
+}
+
+// 有符号整数类型对应的无符号类型
+func unsignedType(t ast.BasicType) ast.BasicType {
+	switch t {
+	case ast.Char, ast.SignedChar:
+		return ast.UnsignedChar
+	case ast.Short:
+		return ast.UnsignedShort
+	case ast.Int:
+		return ast.UnsignedInt
+	case ast.Long:
+		return ast.UnsignedLong
+	case ast.LongLong:
+		return ast.UnsignedLongLong
+	}
+	return t
+}
+
+// size_t 的类型
+func sizeType(tgt *target.Target) ast.BasicType {
+	return intType(tgt.SizeT, true, tgt)
+}
+
+// 整数提升，等级低于 int 的类型在 int 可以表示所有值时提升为 int，否则提升为 unsigned int
+func promoteInt(t ast.BasicType, tgt *target.Target) ast.BasicType {
+	if intRank(t, tgt) >= intRank(ast.Int, tgt) {
+		return t
+	}
+	if t.IsUnsigned() && t != ast.Bool && basicSize(t, tgt) >= basicSize(ast.Int, tgt) {
+		return ast.UnsignedInt
+	}
+	return ast.Int
+}
+
+// 一般算术转换得到的公共类型，有浮点类型时按浮点类型转换
+func arithConv(a, b ast.BasicType, tgt *target.Target) ast.BasicType {
+	if a.IsFloating() || b.IsFloating() {
+		return floatConv(a, b)
+	}
+	a, b = promoteInt(a, tgt), promoteInt(b, tgt)
+	if a == b {
+		return a
+	}
+	if a.IsUnsigned() == b.IsUnsigned() {
+		if intRank(a, tgt) >= intRank(b, tgt) {
+			return a
+		}
+		return b
+	}
+	u, s := a, b
+	if !u.IsUnsigned() {
+		u, s = b, a
+	}
+	switch {
+	case intRank(u, tgt) >= intRank(s, tgt):
+		return u
+	case basicSize(s, tgt) > basicSize(u, tgt):
+		// 有符号类型可以表示无符号类型的所有值
+		return s
+	}
+	return unsignedType(s)
+}
+
+// 浮点类型的一般算术转换，实数部分取等级高的类型，有复数或实数与虚数混合时为复数
+func floatConv(a, b ast.BasicType) ast.BasicType {
+	r := ast.Float
+	for _, t := range []ast.BasicType{a, b} {
+		if t.IsFloating() && t.Real() > r {
+			r = t.Real()
+		}
+	}
+	switch {
+	case a.IsComplex() || b.IsComplex() || a.IsImaginary() != b.IsImaginary():
+		return r + ast.FloatComplex - ast.Float
+	case a.IsImaginary():
+		return r + ast.FloatImaginary - ast.Float
+	}
+	return r
+}
+
+// 整数字面量的类型，size_t 后缀按目标平台选择同样大小的类型
+func intLitType(t literal.Type, tgt *target.Target) ast.BasicType {
+	switch t {
+	case literal.Size:
+		return sizeType(tgt)
+	case literal.SignedSize:
+		return intType(tgt.SizeT, false, tgt)
+	}
+	if v, ok := litTypes[t]; ok {
+		return v
+	}
+	return ast.Int
+}
+
+// 字符常量的类型，没有前缀时为 int
+func charLitType(enc literal.Encoding, tgt *target.Target) ast.BasicType {
+	switch enc {
+	case literal.EncodingUTF8:
+		return ast.UnsignedChar
+	case literal.EncodingUTF16:
+		return ast.UnsignedShort
+	case literal.EncodingUTF32:
+		return ast.UnsignedInt
+	case literal.EncodingWide:
+		return intType(tgt.WChar, tgt.WChar == 2, tgt)
+	}
+	return ast.Int
+}
+
+func bool2int(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func unParen(t ast.Typename) ast.Typename {
+	switch v := t.(type) {
+	case *ast.ParenType:
+		return unParen(v.Type)
+	case *ast.TypeofType:
+		if v.Type != nil {
+			return unParen(v.Type)
+		}
+	}
+	return t
+}
+
+// 内置类型在目标平台上的大小
+func basicSize(t ast.BasicType, tgt *target.Target) int64 {
+	switch t {
+	case ast.Void, ast.Bool, ast.Char, ast.SignedChar, ast.UnsignedChar:
+		return 1
+	case ast.Short, ast.UnsignedShort:
+		return int64(tgt.Short)
+	case ast.Int, ast.UnsignedInt:
+		return int64(tgt.Int)
+	case ast.Long, ast.UnsignedLong:
+		return int64(tgt.Long)
+	case ast.LongLong, ast.UnsignedLongLong:
+		return int64(tgt.LongLong)
+	case ast.Float, ast.FloatImaginary:
+		return int64(tgt.Float)
+	case ast.Double, ast.DoubleImaginary:
+		return int64(tgt.Double)
+	case ast.LongDouble, ast.LongDoubleImaginary:
+		return int64(tgt.LongDouble)
+	case ast.FloatComplex, ast.DoubleComplex, ast.LongDoubleComplex:
+		// 实部与虚部
+		return 2 * basicSize(t.Real(), tgt)
+	case ast.UnsignedPointer:
+		return int64(tgt.Pointer)
+	}
+	return int64(t.Size())
+}
+
+// 内置类型在目标平台上的对齐
+func basicAlign(t ast.BasicType, tgt *target.Target) int64 {
+	return int64(tgt.Align(int(basicSize(t.Real(), tgt))))
+}
+
+// 类型的大小与对齐，不完全类型报告错误并返回 false
+func (p *parser) typeLayout(typ ast.Typename, pos token.Position) (size, align int64, ok bool) {
+	size, align, ok = p.baseLayout(typ, pos)
+	return size, max64(align, p.typeAlign(typ)), ok
+}
+
+// aligned 属性指定的类型对齐，来自 typedef 或指针声明符中的属性，没有指定时为 0
+func (p *parser) typeAlign(typ ast.Typename) int64 {
+	n := p.env.typeAligns[typ]
+	switch t := semanticType(typ).(type) {
+	case *ast.PointerType:
+		n = max64(n, p.attrAlign(t.Attrs))
+	default:
+		n = max64(n, p.env.typeAligns[t])
+	}
+	return n
+}
+
+// typedef 声明中的 aligned 属性作用于类型名称对应的类型
+func (p *parser) typedefAlign(decl *ast.TypedefDecl) {
+	if n := p.attrAlign(decl.Attrs); n > 0 && decl.Type != nil {
+		p.env.typeAligns[decl.Type] = max64(n, p.env.typeAligns[decl.Type])
+	}
+}
+
+// 不考虑 aligned 属性的类型大小与对齐
+func (p *parser) baseLayout(typ ast.Typename, pos token.Position) (size, align int64, ok bool) {
+	switch t := semanticType(typ).(type) {
+	case *ast.TypeofType:
+		if t.Type != nil {
+			return p.typeLayout(t.Type, pos)
+		}
+	case *ast.BuildInType:
+		return basicSize(t.Type, p.opt.Target), basicAlign(t.Type, p.opt.Target), true
+	case *ast.PointerType:
+		size = int64(p.opt.Target.Pointer)
+		return size, int64(p.opt.Target.Align(p.opt.Target.Pointer)), true
+	case *ast.EnumType:
+		return basicSize(ast.Int, p.opt.Target), basicAlign(ast.Int, p.opt.Target), true
+	case *ast.ArrayType:
+		if t.Incomplete || t.Size == nil {
+			break
+		}
+		n, ok := p.evalConst(t.Size)
+		if !ok {
+			return 0, 0, false
+		}
+		size, align, ok = p.typeLayout(t.Type, pos)
+		return size * n, align, ok
+	case *ast.RecordType:
+		if r := p.completeRecord(t); r != nil {
+			return p.recordLayout(r, pos)
+		}
+	}
+	name := "<nil>"
+	if typ != nil {
+		name = typ.String()
+	}
+	p.addErr(pos, errors.ErrSyntaxIncompleteType, name)
+	return 0, 0, false
+}
+
+// 查找结构体/联合体的完整定义
+func (p *parser) completeRecord(r *ast.RecordType) *ast.RecordType {
+	if r.Completed || r.Name == nil {
+		return r
+	}
+	scope := ast.StructScope
+	if r.Type.Literal() == "union" {
+		scope = ast.UnionScope
+	}
+	if obj := p.env.tryResolve(scope, r.Name.Literal()); obj != nil && obj.Completed {
+		if v, ok := obj.Typename.(*ast.RecordType); ok {
+			return v
+		}
+	}
+	return nil
+}
+
+// 按自然对齐计算结构体/联合体的布局，位域按声明类型的存储单元分配
+func (p *parser) recordLayout(r *ast.RecordType, pos token.Position) (size, align int64, ok bool) {
+	_, size, align, ok = p.recordFieldLayout(r, pos)
+	return
+}
+
+// 结构体/联合体的布局与各成员的字节偏移
+func (p *parser) recordFieldLayout(r *ast.RecordType, pos token.Position) (offsets []int64, size, align int64, ok bool) {
+	union := r.Type.Literal() == "union"
+	packed := isPacked(r.Attrs)
+	align = 1
+	offsets = make([]int64, len(r.Fields))
+	var bits int64 // 当前偏移，单位为位
+	for i, f := range r.Fields {
+		// 柔性数组成员
+		if arr, ok := f.Type.(*ast.ArrayType); ok && arr.Incomplete && i == len(r.Fields)-1 && !union {
+			_, fa, ok := p.typeLayout(arr.Type, pos)
+			if !ok {
+				return nil, 0, 0, false
+			}
+			if packed || isPacked(f.Attrs) {
+				fa = 1
+			}
+			fa = max64(fa, p.declAlign(f.Align, f.Attrs))
+			bits = alignUp(bits, fa*8)
+			offsets[i] = bits / 8
+			align = max64(align, fa)
+			continue
+		}
+		fs, fa, ok := p.typeLayout(f.Type, pos)
+		if !ok {
+			return nil, 0, 0, false
+		}
+		// packed 的成员按 1 字节对齐，位域不再按存储单元分配
+		fieldPacked := packed || isPacked(f.Attrs)
+		if fieldPacked {
+			fa = 1
+		}
+		if f.Bit != nil {
+			width, ok := p.evalConst(f.Bit)
+			if !ok {
+				return nil, 0, 0, false
+			}
+			if union {
+				bits = max64(bits, alignUp(width, fs*8))
+			} else if width == 0 {
+				bits = alignUp(bits, fa*8)
+			} else {
+				if !fieldPacked && bits%(fs*8)+width > fs*8 {
+					bits = alignUp(bits, fs*8)
+				}
+				offsets[i] = bits / 8
+				bits += width
+			}
+			if f.Name != nil {
+				align = max64(align, fa)
+			}
+			continue
+		}
+		fa = max64(fa, p.declAlign(f.Align, f.Attrs))
+		align = max64(align, fa)
+		if union {
+			bits = max64(bits, fs*8)
+			continue
+		}
+		bits = alignUp(bits, fa*8)
+		offsets[i] = bits / 8
+		bits += fs * 8
+	}
+	align = max64(align, p.attrAlign(r.Attrs))
+	size = alignUp(alignUp(bits, 8)/8, align)
+	return offsets, size, align, true
+}
+
+// 类型的对齐，数组按元素类型计算
+func (p *parser) naturalAlign(typ ast.Typename, pos token.Position) (int64, bool) {
+	for {
+		arr, ok := unParen(typ).(*ast.ArrayType)
+		if !ok {
+			break
+		}
+		typ = arr.Type
+	}
+	_, align, ok := p.typeLayout(typ, pos)
+	return align, ok
+}
+
+// 对齐说明符中最严格的对齐，没有指定时为 0
+func declAlign(align []*ast.AlignSpec) int64 {
+	var n int64
+	for _, a := range align {
+		n = max64(n, a.Align)
+	}
+	return n
+}
+
+// 对齐说明符与 GNU aligned 属性中最严格的对齐，没有指定时为 0
+func (p *parser) declAlign(align []*ast.AlignSpec, attrs []*ast.Attribute) int64 {
+	return max64(declAlign(align), p.attrAlign(attrs))
+}
+
+// GNU aligned 属性中最大的对齐，没有指定时为 0
+func (p *parser) attrAlign(attrs []*ast.Attribute) int64 {
+	var n int64
+	for _, attr := range attrs {
+		n = max64(n, p.env.aligns[attr])
+	}
+	return n
+}
+
+// 记录 aligned 属性的对齐值，没有参数时为目标平台的最大对齐，值必须是 2 的幂
+func (p *parser) checkAlignedAttr(attr *ast.Attribute) {
+	if attr.Canonical() != "aligned" {
+		return
+	}
+	if len(attr.Args) == 0 {
+		p.env.aligns[attr] = int64(p.opt.Target.MaxAlign)
+		return
+	}
+	v, ok := p.evalConst(attr.Args[0])
+	if !ok {
+		return
+	}
+	if v <= 0 || v&(v-1) != 0 {
+		p.addErr(attr.Args[0].Beg(), errors.ErrSyntaxAlignNotPowerOfTwo, strconv.FormatInt(v, 10))
+		return
+	}
+	p.env.aligns[attr] = v
+}
+
+// 是否有 GNU packed 属性
+func isPacked(attrs []*ast.Attribute) bool {
+	return ast.FindAttribute(attrs, "packed") != nil
+}
+
+// 检查对齐说明符不弱于类型的自然对齐，不完全类型不检查
+func (p *parser) checkAlign(align []*ast.AlignSpec, typ ast.Typename) {
+	n := declAlign(align)
+	if n == 0 || !p.isCompleteType(typ) {
+		return
+	}
+	if natural, ok := p.naturalAlign(typ, align[0].Alignas); ok && n < natural {
+		p.addErr(align[0].Alignas, errors.ErrSyntaxAlignTooWeak,
+			strconv.FormatInt(n, 10), typeString(typ), strconv.FormatInt(natural, 10))
+	}
+}
+
+// 元素类型是否完整
+func (p *parser) isCompleteType(typ ast.Typename) bool {
+	switch t := unParen(typ).(type) {
+	case *ast.ArrayType:
+		return p.isCompleteType(t.Type)
+	case *ast.RecordType:
+		return p.completeRecord(t) != nil
+	case *ast.BuildInType:
+		return t.Type != ast.Void
+	}
+	return true
+}
+
+func alignUp(n, align int64) int64 {
+	if align <= 1 {
+		return n
+	}
+	return (n + align - 1) / align * align
+}
+
+func max64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// 检查静态断言
+func (p *parser) checkStaticAssert(decl *ast.StaticAssertDecl) {
+	v, ok := p.evalConst(decl.Cond)
+	if !ok || v != 0 {
+		return
+	}
+	if decl.Msg != nil {
+		p.addErr(decl.StaticAssert, errors.ErrSyntaxStaticAssertFailedMsg, decl.Msg.String())
+		return
+	}
+	p.addErr(decl.StaticAssert, errors.ErrSyntaxStaticAssertFailed)
+}
@@ -8,10 +8,13 @@ import (
 type environment struct {
 	global     *ast.Scope // 全局作用域
 	nested     *ast.Scope // 文件作用域
-	label      *ast.Scope
-	labels     []*ast.Ident
+	label      *ast.Scope // 标签作用域，__label__ 声明的局部标签嵌套在函数标签作用域内
+	labels     []labelRef
 	parser     *parser
-	unresolved []*ast.Ident // 未解析的标识符
+	unresolved []*ast.Ident                 // 未解析的标识符
+	enums      map[*ast.EnumFieldDecl]int64 // 枚举常量的值
+	aligns     map[*ast.Attribute]int64     // aligned 属性的对齐值
+	typeAligns map[ast.Typename]int64       // typedef 的 aligned 属性指定的类型对齐
 }
 
 func newEnv(glb *ast.Scope, p *parser) *environment {
@@ -19,21 +22,29 @@ func newEnv(glb *ast.Scope, p *parser) *environment {
 	env.global = glb
 	env.nested = ast.NewScope(ast.GlobalScope, env.global, int(ast.MaxNestedNamespace))
 	env.parser = p
+	env.enums = map[*ast.EnumFieldDecl]int64{}
+	env.aligns = map[*ast.Attribute]int64{}
+	env.typeAligns = map[ast.Typename]int64{}
 	return env
 }
 
 // 定义对象
 func (e *environment) declare(obj *ast.Object) {
+	obj.Attrs = declAttrs(obj.Decl)
 	var namespace ast.ScopeNamespace
 	switch obj.Type {
 	case ast.ObjectEnumName:
 		namespace = ast.EnumScope
+		obj.Attrs = tagAttrs(obj.Typename)
 	case ast.ObjectStructName:
 		namespace = ast.StructScope
+		obj.Attrs = tagAttrs(obj.Typename)
 	case ast.ObjectUnionName:
 		namespace = ast.UnionScope
+		obj.Attrs = tagAttrs(obj.Typename)
 	case ast.ObjectLabelName:
-		e.alterDeclare(e.label.Insert(ast.IdentScope, obj), obj, errors.ErrSyntaxRedefinedLabel)
+		e.declareLabel(obj)
+		return
 	case ast.ObjectFunc:
 		namespace = ast.IdentScope
 		e.alterDeclare(e.global.Insert(ast.IdentScope, obj), obj, errors.ErrSyntaxRedefineIdent) // 函数注册到全局域
@@ -127,13 +138,24 @@ func (e *environment) declareEnumTag(r *ast.EnumFieldDecl) {
 }
 
 func (e *environment) alterDeclare(alt, obj *ast.Object, err errors.ErrCode) {
-	if alt == nil {
+	// 已经在外层作用域补全过的同一个定义
+	if alt == nil || alt.Decl != nil && alt.Decl == obj.Decl {
 		return
 	}
 	if alt.Type == obj.Type {
+		// 重复声明的属性累积到最初的对象上
+		alt.Attrs = append(alt.Attrs, obj.Attrs...)
 		switch alt.Type {
 		case ast.ObjectStructName, ast.ObjectEnumName, ast.ObjectUnionName, ast.ObjectFunc:
 			if !alt.Completed && obj.Completed {
+				// 补全之前的声明
+				alt.Completed = true
+				alt.Typename = obj.Typename
+				alt.Decl = obj.Decl
+				return
+			}
+			// 函数可以重复声明
+			if alt.Type == ast.ObjectFunc && !obj.Completed {
 				return
 			}
 		}
@@ -167,11 +189,22 @@ func (e *environment) tryResolve(space ast.ScopeNamespace, name string) *ast.Obj
 	return nil
 }
 
+// 是否为文件作用域中声明的对象
+func (e *environment) isFileScope(obj *ast.Object) bool {
+	scope := e.nested
+	for scope.Outer != nil && scope.Outer != e.global {
+		scope = scope.Outer
+	}
+	return scope.Lookup(ast.IdentScope, obj.Name) == obj
+}
+
 func (e *environment) resolveIdent(name *ast.Ident) *ast.Object {
 	obj := e.tryResolve(ast.IdentScope, name.Literal())
 	if obj == nil {
 		e.parser.addErr(name.Position(), errors.ErrSyntaxUndefinedIdent, name.Literal())
+		return nil
 	}
+	obj.Used = true
 	return obj
 }
 
@@ -202,23 +235,82 @@ func (e *environment) copyParameterScope(typ *ast.FuncDecl) {
 	}
 }
 
+// 标签引用及引用处的标签作用域
+type labelRef struct {
+	name  *ast.Ident
+	scope *ast.Scope
+}
+
 // 进入label作用域
 func (e *environment) enterLabelScope() {
 	e.label = ast.NewScope(ast.FuncScope, nil, 1)
+	e.labels = nil
+}
+
+// 进入块内的局部标签作用域
+func (e *environment) enterLocalLabelScope() {
+	e.label = ast.NewScope(ast.BlockScope, e.label, 1)
+}
+
+// 退出块内的局部标签作用域
+func (e *environment) leaveLocalLabelScope() {
+	e.label = e.label.Outer
+}
+
+// 声明局部标签，标签在块内定义
+func (e *environment) declareLocalLabel(name *ast.Ident) {
+	obj := ast.NewObject(ast.ObjectLabelName, name)
+	if alt := e.label.Insert(ast.IdentScope, obj); alt != nil {
+		e.parser.addErr(obj.Pos, errors.ErrSyntaxRedefinedLabel, obj.Name, alt.Pos.String())
+	}
+}
+
+// 定义标签，优先绑定到最近的同名局部标签声明，否则属于整个函数
+func (e *environment) declareLabel(obj *ast.Object) {
+	obj.Completed = true
+	scope := e.label
+	for scope != nil {
+		if alt := scope.Lookup(ast.IdentScope, obj.Name); alt != nil {
+			if alt.Completed {
+				e.alterDeclare(alt, obj, errors.ErrSyntaxRedefinedLabel)
+				return
+			}
+			alt.Completed = true
+			alt.Pos = obj.Pos
+			return
+		}
+		if scope.Outer == nil {
+			break
+		}
+		scope = scope.Outer
+	}
+	if scope != nil {
+		scope.Insert(ast.IdentScope, obj)
+	}
 }
 
 // 解析标签
 func (e *environment) tryResolveLabel(ident *ast.Ident) {
-	e.labels = append(e.labels, ident)
+	e.labels = append(e.labels, labelRef{name: ident, scope: e.label})
 	return
 }
 
 // 退出label作用域
 func (e *environment) leaveLabelScope() (labels []*ast.Ident) {
-	for _, name := range e.labels {
-		if e.label.Lookup(0, name.Literal()) == nil {
-			labels = append(labels, name)
+	for _, ref := range e.labels {
+		if !labelDefined(ref.scope, ref.name.Literal()) {
+			labels = append(labels, ref.name)
 		}
 	}
 	return
 }
+
+// 从引用处的作用域向外查找已定义的标签
+func labelDefined(scope *ast.Scope, name string) bool {
+	for ; scope != nil; scope = scope.Outer {
+		if obj := scope.Lookup(ast.IdentScope, name); obj != nil {
+			return obj.Completed
+		}
+	}
+	return false
+}
@@ -0,0 +1,224 @@
+package parser
+
+import (
+	"dxkite.cn/c/ast"
+	"dxkite.cn/c/errors"
+)
+
+func isNoreturn(fn *ast.FuncDecl) bool {
+	if fn == nil || fn.Spec == nil {
+		return false
+	}
+	_, ok := (*fn.Spec)["_Noreturn"]
+	return ok
+}
+
+// 具有外部链接的内联函数
+func isInlineExternal(fn *ast.FuncDecl) bool {
+	if fn == nil || fn.Spec == nil || fn.Qua == nil {
+		return false
+	}
+	_, inline := (*fn.Spec)["inline"]
+	_, static := (*fn.Qua)["static"]
+	return inline && !static
+}
+
+// 语句执行后是否可能继续执行后续语句
+func (p *parser) mayFallOff(stmt ast.Stmt) bool {
+	switch s := stmt.(type) {
+	case nil:
+		return true
+	case *ast.CompoundStmt:
+		if len(s.Stmts) == 0 {
+			return true
+		}
+		return p.mayFallOff(s.Stmts[len(s.Stmts)-1])
+	case *ast.ReturnStmt, *ast.GotoStmt, *ast.IndirectGotoStmt:
+		return false
+	case *ast.LabelStmt:
+		return p.mayFallOff(s.Stmt)
+	case *ast.CaseStmt:
+		return p.mayFallOff(s.Stmt)
+	case *ast.CaseRangeStmt:
+		return p.mayFallOff(s.Stmt)
+	case *ast.DefaultStmt:
+		return p.mayFallOff(s.Stmt)
+	case *ast.SwitchStmt:
+		// 没有 default 时可能不进入任何分支，否则只能通过 break 或最后的分支结束
+		return !hasDefault(s.Stmt) || hasBreak(s.Stmt) || p.mayFallOff(s.Stmt)
+	case *ast.ExprStmt:
+		return !p.isNoreturnCall(s.Expr)
+	case *ast.IfStmt:
+		return s.Else == nil || p.mayFallOff(s.Then) || p.mayFallOff(s.Else)
+	case *ast.WhileStmt:
+		return !p.isConstTrue(s.X) || hasBreak(s.Stmt)
+	case *ast.DoWhileStmt:
+		return !p.isConstTrue(s.X) || hasBreak(s.Stmt)
+	case *ast.ForStmt:
+		return s.Cond != nil && !p.isConstTrue(s.Cond) || hasBreak(s.Stmt)
+	}
+	return true
+}
+
+// 调用 _Noreturn 函数
+func (p *parser) isNoreturnCall(expr ast.Expr) bool {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	id, ok := call.Func.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	if obj := p.env.tryResolve(ast.IdentScope, id.Literal()); obj != nil {
+		fn, ok := obj.Decl.(*ast.FuncDecl)
+		return ok && isNoreturn(fn)
+	}
+	return false
+}
+
+// 值非零的整数常量表达式
+func (p *parser) isConstTrue(expr ast.Expr) bool {
+	v, ok := p.tryConst(expr)
+	return ok && v != 0
+}
+
+// 循环中是否有跳出该循环的 break，嵌套的循环与 switch 中的 break 不计算
+func hasBreak(stmt ast.Stmt) bool {
+	switch s := stmt.(type) {
+	case *ast.BreakStmt:
+		return true
+	case *ast.CompoundStmt:
+		for _, item := range s.Stmts {
+			if hasBreak(item) {
+				return true
+			}
+		}
+	case *ast.LabelStmt:
+		return hasBreak(s.Stmt)
+	case *ast.CaseStmt:
+		return hasBreak(s.Stmt)
+	case *ast.CaseRangeStmt:
+		return hasBreak(s.Stmt)
+	case *ast.DefaultStmt:
+		return hasBreak(s.Stmt)
+	case *ast.IfStmt:
+		return hasBreak(s.Then) || hasBreak(s.Else)
+	}
+	return false
+}
+
+// switch 中是否有 default 标签，嵌套的 switch 不计算
+func hasDefault(stmt ast.Stmt) bool {
+	switch s := stmt.(type) {
+	case *ast.DefaultStmt:
+		return true
+	case *ast.CompoundStmt:
+		for _, item := range s.Stmts {
+			if hasDefault(item) {
+				return true
+			}
+		}
+	case *ast.LabelStmt:
+		return hasDefault(s.Stmt)
+	case *ast.CaseStmt:
+		return hasDefault(s.Stmt)
+	case *ast.CaseRangeStmt:
+		return hasDefault(s.Stmt)
+	case *ast.IfStmt:
+		return hasDefault(s.Then) || hasDefault(s.Else)
+	case *ast.WhileStmt:
+		return hasDefault(s.Stmt)
+	case *ast.DoWhileStmt:
+		return hasDefault(s.Stmt)
+	case *ast.ForStmt:
+		return hasDefault(s.Stmt)
+	}
+	return false
+}
+
+// 具有外部链接的内联函数中不能定义可修改的静态变量
+func (p *parser) checkInlineStaticVar(decl *ast.VarDecl, external bool) {
+	if external || !isInlineExternal(p.fn) || decl.Name == nil {
+		return
+	}
+	if decl.Qua.Duration(false) == ast.AutomaticStorage {
+		return
+	}
+	if _, ok := (*decl.Qua)["extern"]; ok {
+		return
+	}
+	if q := decl.Type.Qualifier(); q != nil {
+		if _, ok := (*q)["const"]; ok {
+			return
+		}
+	}
+	p.addErr(decl.Name.Position(), errors.ErrSyntaxInlineStaticVar, p.fn.Name.Literal(), decl.Name.Literal())
+}
+
+// 具有外部链接的内联函数中不能引用具有内部链接的标识符
+func (p *parser) checkInlineRef(ident *ast.Ident, obj *ast.Object) {
+	if !isInlineExternal(p.fn) || !p.env.isFileScope(obj) {
+		return
+	}
+	var qua *ast.StorageSpecifier
+	switch v := obj.Decl.(type) {
+	case *ast.VarDecl:
+		qua = v.Qua
+	case *ast.FuncDecl:
+		qua = v.Qua
+	}
+	if qua == nil {
+		return
+	}
+	if _, ok := (*qua)["static"]; ok {
+		p.addErr(ident.Position(), errors.ErrSyntaxInlineInternalRef, p.fn.Name.Literal(), ident.Literal())
+	}
+}
+
+// 检查翻译单元中的内联函数
+// 文件作用域中的声明都有 inline 且没有 extern 时，定义为内联定义，不提供外部定义
+func (p *multiparser) checkInline(unit *ast.TranslationUnit) {
+	var names []string
+	decls := map[string][]*ast.FuncDecl{}
+	for _, file := range unit.Files {
+		for _, decl := range file.Decl {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Name == nil {
+				continue
+			}
+			name := fn.Name.Literal()
+			if _, ok := decls[name]; !ok {
+				names = append(names, name)
+			}
+			decls[name] = append(decls[name], fn)
+		}
+	}
+	for _, name := range names {
+		var def *ast.FuncDecl
+		inline, static := false, false
+		inlineDef := true
+		for _, fn := range decls[name] {
+			if fn.Body != nil {
+				def = fn
+			}
+			_, isInline := (*fn.Spec)["inline"]
+			_, isExtern := (*fn.Qua)["extern"]
+			_, isStatic := (*fn.Qua)["static"]
+			inline = inline || isInline
+			static = static || isStatic
+			if !isInline || isExtern {
+				inlineDef = false
+			}
+		}
+		if !inline || static {
+			continue
+		}
+		if def == nil {
+			first := decls[name][0]
+			p.err(first.Name.Position(), errors.ErrTypeWarning, errors.ErrSyntaxInlineNotDefined, name)
+			continue
+		}
+		def.InlineDef = inlineDef
+	}
+}
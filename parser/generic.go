@@ -0,0 +1,399 @@
+package parser
+
+import (
+	"dxkite.cn/c/ast"
+	"dxkite.cn/c/errors"
+	"dxkite.cn/c/literal"
+	"dxkite.cn/c/target"
+	"dxkite.cn/c/token"
+	"strings"
+)
+
+// 检查泛型关联并确定选中的关联，pos 为各关联的起始位置
+func (p *parser) resolveGeneric(expr *ast.GenericSelectionExpr, pos []token.Position) {
+	def := -1
+	for i, assoc := range expr.Assocs {
+		if assoc.Type == nil {
+			if def >= 0 {
+				p.addErr(pos[i], errors.ErrSyntaxGenericDuplicateDefault, pos[def].String())
+			} else {
+				def = i
+			}
+			continue
+		}
+		for j := 0; j < i; j++ {
+			if prev := expr.Assocs[j].Type; prev != nil && p.compatibleType(assoc.Type, prev, true) {
+				p.addErr(pos[i], errors.ErrSyntaxGenericCompatible, typeString(assoc.Type), typeString(prev), pos[j].String())
+				break
+			}
+		}
+	}
+	typ := p.exprType(expr.X)
+	if typ == nil {
+		return
+	}
+	// 控制表达式经过左值转换，去掉顶层限定符
+	typ = decayType(typ)
+	for i, assoc := range expr.Assocs {
+		if assoc.Type != nil && isUnqualified(assoc.Type) && p.compatibleType(typ, assoc.Type, false) {
+			expr.Selected = i
+			return
+		}
+	}
+	if def < 0 {
+		p.addErr(expr.X.Beg(), errors.ErrSyntaxGenericNoMatch, typeString(typ))
+		return
+	}
+	expr.Selected = def
+}
+
+// 表达式的类型，无法确定时返回 nil
+func (p *parser) exprType(expr ast.Expr) ast.Typename {
+	switch x := expr.(type) {
+	case *ast.ParenExpr:
+		return p.exprType(x.X)
+	case *ast.Ident:
+		if obj := p.env.tryResolve(ast.IdentScope, x.Literal()); obj != nil {
+			switch v := obj.Decl.(type) {
+			case *ast.EnumFieldDecl:
+				return &ast.BuildInType{Type: ast.Int}
+			case *ast.FuncDecl:
+				return v.Type
+			}
+		}
+		return p.identType(x)
+	case *ast.BasicLit:
+		return litType(x, p.opt.Target)
+	case *ast.TypeCastExpr:
+		return x.Type
+	case *ast.UnaryExpr:
+		switch x.Op.Literal() {
+		case "&":
+			if t := p.exprType(x.X); t != nil {
+				return &ast.PointerType{Type: t}
+			}
+		case "*":
+			return p.elemType(x.X)
+		case "+", "-", "~":
+			if t, ok := arithType(p.exprType(x.X)); ok {
+				return &ast.BuildInType{Type: promoteInt(t, p.opt.Target)}
+			}
+		case "!":
+			return &ast.BuildInType{Type: ast.Int}
+		case "sizeof", "_Alignof":
+			return &ast.BuildInType{Type: sizeType(p.opt.Target)}
+		case "++", "--":
+			return p.exprType(x.X)
+		}
+	case *ast.IndexExpr:
+		// a[i] 与 i[a] 等价
+		if t := p.elemType(x.Arr); t != nil {
+			return t
+		}
+		return p.elemType(x.Index)
+	case *ast.SelectorExpr:
+		t := p.exprType(x.X)
+		if x.Op.Literal() == "->" {
+			t = p.elemType(x.X)
+		}
+		if r, ok := semanticType(t).(*ast.RecordType); ok {
+			if r = p.completeRecord(r); r != nil {
+				return p.lookupField(r, x.Name)
+			}
+		}
+	case *ast.CallExpr:
+		// 函数或函数指针的返回类型
+		if fp, ok := decayType(p.exprType(x.Func)).(*ast.PointerType); ok {
+			if fn, ok := semanticType(fp.Type).(*ast.FuncType); ok {
+				return fn.Return
+			}
+		}
+	case *ast.SizeOfExpr, *ast.AlignOfExpr:
+		return &ast.BuildInType{Type: sizeType(p.opt.Target)}
+	case *ast.BinaryExpr:
+		return p.binaryType(x)
+	case *ast.CondExpr:
+		return p.condType(p.exprType(x.Then), p.exprType(x.Else))
+	case *ast.BinaryCondExpr:
+		return p.condType(p.exprType(x.X), p.exprType(x.Else))
+	case *ast.AssignExpr:
+		return p.exprType(x.X)
+	case *ast.CommaExpr:
+		return p.exprType((*x)[len(*x)-1])
+	case *ast.VaArgExpr:
+		return x.Type
+	case *ast.ExtensionExpr:
+		return p.exprType(x.X)
+	case *ast.StmtExpr:
+		// 最后一个表达式语句的值
+		if n := len(x.Body.Stmts); n > 0 {
+			if stmt, ok := x.Body.Stmts[n-1].(*ast.ExprStmt); ok {
+				return p.exprType(stmt.Expr)
+			}
+		}
+	case *ast.GenericSelectionExpr:
+		if x.Selected >= 0 {
+			return p.exprType(x.Assocs[x.Selected].X)
+		}
+	}
+	return nil
+}
+
+// 指针或数组表达式指向的元素类型
+func (p *parser) elemType(x ast.Expr) ast.Typename {
+	if t, ok := semanticType(decayType(p.exprType(x))).(*ast.PointerType); ok {
+		return t.Type
+	}
+	return nil
+}
+
+// 算术类型对应的基础类型，枚举按 int 处理
+func arithType(t ast.Typename) (ast.BasicType, bool) {
+	switch v := semanticType(t).(type) {
+	case *ast.BuildInType:
+		return v.Type, v.Type.IsInteger() || v.Type.IsFloating()
+	case *ast.EnumType:
+		return ast.Int, true
+	}
+	return 0, false
+}
+
+// 二元表达式的类型，算术运算经过一般算术转换
+func (p *parser) binaryType(x *ast.BinaryExpr) ast.Typename {
+	switch x.Op.Literal() {
+	case "==", "!=", "<", ">", "<=", ">=", "&&", "||":
+		return &ast.BuildInType{Type: ast.Int}
+	}
+	lt, rt := p.exprType(x.X), p.exprType(x.Y)
+	l, lok := arithType(lt)
+	r, rok := arithType(rt)
+	switch op := x.Op.Literal(); {
+	case lok && rok && (op == "<<" || op == ">>"):
+		return &ast.BuildInType{Type: promoteInt(l, p.opt.Target)}
+	case lok && rok:
+		return &ast.BuildInType{Type: arithConv(l, r, p.opt.Target)}
+	case op == "-" && !lok && !rok && lt != nil && rt != nil:
+		// 指针相减的结果为 ptrdiff_t
+		return &ast.BuildInType{Type: intType(p.opt.Target.Pointer, false, p.opt.Target)}
+	case op == "+" || op == "-":
+		if lt != nil && !lok {
+			return decayType(lt)
+		}
+		if rt != nil && !rok && op == "+" {
+			return decayType(rt)
+		}
+	}
+	return nil
+}
+
+// 条件表达式的类型，两个分支都是算术类型时经过一般算术转换
+func (p *parser) condType(a, b ast.Typename) ast.Typename {
+	x, xok := arithType(a)
+	y, yok := arithType(b)
+	if xok && yok {
+		return &ast.BuildInType{Type: arithConv(x, y, p.opt.Target)}
+	}
+	if a == nil || b == nil {
+		return nil
+	}
+	return decayType(a)
+}
+
+// 字面量的类型，没有对应内置类型时返回 nil
+func litType(x *ast.BasicLit, tgt *target.Target) ast.Typename {
+	switch x.Type() {
+	case token.INT, token.FLOAT:
+		n, err := literal.ParseNumber(x.Token, tgt)
+		if err != nil {
+			return nil
+		}
+		if t, ok := litTypes[n.Type]; ok {
+			return &ast.BuildInType{Type: t}
+		}
+	case token.CHAR:
+		// 字符常量的类型为 int
+		if c, err := literal.ParseChar(x.Token, tgt); err == nil && c.Encoding == literal.EncodingNone {
+			return &ast.BuildInType{Type: ast.Int}
+		}
+	case token.STRING:
+		if x.Encoding == literal.EncodingNone || x.Encoding == literal.EncodingUTF8 {
+			return &ast.PointerType{Type: &ast.BuildInType{Type: ast.Char}}
+		}
+	}
+	return nil
+}
+
+var litTypes = map[literal.Type]ast.BasicType{
+	literal.Int:              ast.Int,
+	literal.UnsignedInt:      ast.UnsignedInt,
+	literal.Long:             ast.Long,
+	literal.UnsignedLong:     ast.UnsignedLong,
+	literal.LongLong:         ast.LongLong,
+	literal.UnsignedLongLong: ast.UnsignedLongLong,
+	literal.Float:            ast.Float,
+	literal.Double:           ast.Double,
+	literal.LongDouble:       ast.LongDouble,
+}
+
+// 左值转换，数组与函数转换为指针并去掉顶层限定符
+func decayType(t ast.Typename) ast.Typename {
+	switch v := semanticType(t).(type) {
+	case *ast.ArrayType:
+		return &ast.PointerType{Type: v.Type}
+	case *ast.FuncType:
+		return &ast.PointerType{Type: v}
+	}
+	return t
+}
+
+// 声明符中的括号改变了结合顺序，语法树中 int (*)(int) 为返回 (* int) 的函数，
+// 转换为按语义嵌套的类型，即指向 int (int) 函数的指针
+func semanticType(t ast.Typename) ast.Typename {
+	var outer []ast.Typename // 括号外的派生类型，由外到内
+	for v := t; v != nil; v = derivedType(v) {
+		if paren, ok := v.(*ast.ParenType); ok {
+			// 括号内的派生类型在外层，括号外的派生类型作用于基础类型
+			return semanticType(rebaseType(paren.Type, outer))
+		}
+		outer = append(outer, v)
+	}
+	return unParen(t)
+}
+
+// 派生类型的下一层类型，不是派生类型时返回 nil
+func derivedType(t ast.Typename) ast.Typename {
+	switch v := t.(type) {
+	case *ast.PointerType:
+		return v.Type
+	case *ast.ArrayType:
+		return v.Type
+	case *ast.FuncType:
+		return v.Return
+	case *ast.ParenType:
+		return v.Type
+	}
+	return nil
+}
+
+// 复制派生类型并替换下一层类型
+func withDerivedType(t, inner ast.Typename) ast.Typename {
+	switch v := t.(type) {
+	case *ast.PointerType:
+		n := *v
+		n.Type = inner
+		return &n
+	case *ast.ArrayType:
+		n := *v
+		n.Type = inner
+		return &n
+	case *ast.FuncType:
+		n := *v
+		n.Return = inner
+		return &n
+	case *ast.ParenType:
+		n := *v
+		n.Type = inner
+		return &n
+	}
+	return inner
+}
+
+// 将派生类型链 outer 作用于 t 的基础类型
+func rebaseType(t ast.Typename, outer []ast.Typename) ast.Typename {
+	if inner := derivedType(t); inner != nil {
+		return withDerivedType(t, rebaseType(inner, outer))
+	}
+	for i := len(outer) - 1; i >= 0; i-- {
+		t = withDerivedType(outer[i], t)
+	}
+	return t
+}
+
+func isUnqualified(t ast.Typename) bool {
+	q := unParen(t).Qualifier()
+	return q == nil || len(*q) == 0
+}
+
+// 两个类型是否兼容，qua 为 false 时不比较顶层限定符
+func (p *parser) compatibleType(a, b ast.Typename, qua bool) bool {
+	a, b = semanticType(a), semanticType(b)
+	if a == b {
+		return true
+	}
+	if qua && !sameQualifier(a.Qualifier(), b.Qualifier()) {
+		return false
+	}
+	switch x := a.(type) {
+	case *ast.BuildInType:
+		if y, ok := b.(*ast.BuildInType); ok {
+			return x.Type == y.Type
+		}
+	case *ast.PointerType:
+		if y, ok := b.(*ast.PointerType); ok {
+			return p.compatibleType(x.Type, y.Type, true)
+		}
+	case *ast.RecordType:
+		if y, ok := b.(*ast.RecordType); ok {
+			return x.Type.Literal() == y.Type.Literal() && x.Name != nil && y.Name != nil &&
+				x.Name.Literal() == y.Name.Literal()
+		}
+	case *ast.EnumType:
+		if y, ok := b.(*ast.EnumType); ok {
+			return x.Name != nil && y.Name != nil && x.Name.Literal() == y.Name.Literal()
+		}
+	case *ast.ArrayType:
+		if y, ok := b.(*ast.ArrayType); ok {
+			// 两边都是常量大小时大小必须相同
+			if m, ok := p.constArraySize(x); ok {
+				if n, ok := p.constArraySize(y); ok && m != n {
+					return false
+				}
+			}
+			return p.compatibleType(x.Type, y.Type, true)
+		}
+	case *ast.FuncType:
+		if y, ok := b.(*ast.FuncType); ok {
+			if !p.compatibleType(x.Return, y.Return, true) || x.Ellipsis != y.Ellipsis || len(x.Params) != len(y.Params) {
+				return false
+			}
+			for i := range x.Params {
+				if !p.compatibleType(x.Params[i].Type, y.Params[i].Type, false) {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// 数组的常量大小，不是常量时返回 false 且不报告错误
+func (p *parser) constArraySize(t *ast.ArrayType) (int64, bool) {
+	if t.Incomplete || t.Size == nil {
+		return 0, false
+	}
+	return p.tryConst(t.Size)
+}
+
+func sameQualifier(a, b *ast.Qualifier) bool {
+	var x, y ast.Qualifier
+	if a != nil {
+		x = *a
+	}
+	if b != nil {
+		y = *b
+	}
+	if len(x) != len(y) {
+		return false
+	}
+	for name := range x {
+		if _, ok := y[name]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func typeString(t ast.Typename) string {
+	return strings.TrimSpace(t.String())
+}
@@ -0,0 +1,388 @@
+package parser
+
+import (
+	"dxkite.cn/c/ast"
+	"dxkite.cn/c/errors"
+	"dxkite.cn/c/scanner"
+	"dxkite.cn/c/token"
+)
+
+// 非 GNU 模式下使用 GNU 扩展时报告错误
+func (p *parser) requireGNU(pos token.Position, name string) {
+	if !p.opt.GNU {
+		p.addErr(pos, errors.ErrSyntaxGNUExtension, name)
+	}
+}
+
+// 只在 GNU 方言中是关键字的写法
+var gnuOnlyKeywords = func() map[string]bool {
+	std := scanner.Keywords(scanner.StdC23, scanner.DialectStd)
+	words := map[string]bool{}
+	for w := range scanner.Keywords(scanner.StdC23, scanner.DialectGNU) {
+		if _, ok := std[w]; !ok {
+			words[w] = true
+		}
+	}
+	return words
+}()
+
+// 扫描器方言与 GNU 选项不一致时，GNU 关键字会被扫描为标识符或在标准模式下成为关键字
+func (p *parser) checkDialect(tok token.Token) {
+	if p.dialectWarned || !gnuOnlyKeywords[tok.Literal()] {
+		return
+	}
+	got := ""
+	switch {
+	case tok.Type() == token.KEYWORD && !p.opt.GNU:
+		got = "关键字"
+	case tok.Type() == token.IDENT && p.opt.GNU:
+		got = "标识符"
+	default:
+		return
+	}
+	p.dialectWarned = true
+	p.addWarn(tok.Position(), errors.ErrSyntaxDialectMismatch, tok.Literal(), got, p.opt.GNU)
+}
+
+// __extension__ 只在 GNU 方言中是关键字
+func (p *parser) isExtension() bool {
+	return p.cur.Type() == token.KEYWORD && p.cur.Literal() == "__extension__"
+}
+
+// typeof 与 typeof_unqual，GNU 方言或 C23 中是关键字
+func isTypeof(tok token.Token) bool {
+	return tok.Type() == token.KEYWORD && (tok.Literal() == "typeof" || tok.Literal() == "typeof_unqual")
+}
+
+// typeof ( expression ) | typeof ( type-name )
+func (p *parser) parseTypeofType() ast.Typename {
+	t := &ast.TypeofType{Typeof: p.cur}
+	p.next() // typeof
+	p.exceptPunctuator("(")
+	if p.isTypeNameTok(p.cur) {
+		t.Type = p.parseTypeName()
+	} else {
+		t.X = p.parseExpr()
+		t.Type = p.exprType(t.X)
+	}
+	t.Rparen = p.exceptPunctuator(")").Position()
+	return t
+}
+
+// ( compound-statement )
+func (p *parser) parseStmtExpr() ast.Expr {
+	expr := &ast.StmtExpr{Lparen: p.exceptPunctuator("(").Position()}
+	p.requireGNU(expr.Lparen, "语句表达式")
+	if p.fn == nil {
+		p.addErr(expr.Lparen, errors.ErrSyntaxStmtExprOutsideFunc)
+	}
+	expr.Body = p.parseCompoundStmt()
+	expr.Rparen = p.exceptPunctuator(")").Position()
+	return expr
+}
+
+// && identifier
+func (p *parser) parseLabelAddr() ast.Expr {
+	expr := &ast.LabelAddrExpr{AndAnd: p.cur.Position()}
+	p.requireGNU(expr.AndAnd, "标签地址")
+	p.next() // &&
+	tok := p.expectIdent()
+	expr.Label = &ast.Ident{Token: tok}
+	// 缺少标签名称时已经报告错误，不再作为未定义的标签
+	if tok.Type() == token.IDENT {
+		p.env.tryResolveLabel(expr.Label)
+	}
+	return expr
+}
+
+// __label__ 只在 GNU 方言中是关键字
+func (p *parser) isLabelDecl() bool {
+	return p.cur.Type() == token.KEYWORD && p.cur.Literal() == "__label__"
+}
+
+// __label__ identifier-list ;
+// 不在块开头的声明只解析不生效
+func (p *parser) parseLabelDecl(declare bool) ast.Stmt {
+	stmt := &ast.LabelDeclStmt{Label: p.cur.Position()}
+	p.requireGNU(stmt.Label, "局部标签")
+	p.next() // __label__
+	for {
+		name := &ast.Ident{Token: p.expectIdent()}
+		stmt.Names = append(stmt.Names, name)
+		if declare {
+			p.env.declareLocalLabel(name)
+		}
+		if p.cur.Literal() != "," {
+			break
+		}
+		p.next() // ,
+	}
+	stmt.Semicolon = p.exceptPunctuator(";").Position()
+	return stmt
+}
+
+// __extension__ cast-expression
+func (p *parser) parseExtensionExpr() ast.Expr {
+	expr := &ast.ExtensionExpr{Extension: p.cur.Position()}
+	p.next() // __extension__
+	expr.X = p.parseCastExpr()
+	return expr
+}
+
+// GNU 内置函数，不是函数调用时作为普通标识符
+func (p *parser) isBuiltin(name string) bool {
+	return p.opt.GNU && p.cur.Type() == token.IDENT && p.cur.Literal() == name && p.peekOne().Literal() == "("
+}
+
+// __builtin_va_arg ( assignment-expression , type-name )
+func (p *parser) parseVaArgExpr() ast.Expr {
+	expr := &ast.VaArgExpr{Builtin: p.cur.Position()}
+	p.next() // __builtin_va_arg
+	p.exceptPunctuator("(")
+	expr.X = p.parseAssignExpr()
+	p.exceptPunctuator(",")
+	expr.Type = p.parseTypeName()
+	expr.Rparen = p.exceptPunctuator(")").Position()
+	return expr
+}
+
+// __builtin_offsetof ( type-name , member-designator )
+// member-designator: identifier | member-designator . identifier | member-designator [ expression ]
+func (p *parser) parseOffsetOfExpr() ast.Expr {
+	expr := &ast.OffsetOfExpr{Builtin: p.cur.Position()}
+	p.next() // __builtin_offsetof
+	p.exceptPunctuator("(")
+	expr.Type = p.parseTypeName()
+	p.exceptPunctuator(",")
+	var member ast.Expr = &ast.Ident{Token: p.expectIdent()}
+	for {
+		switch p.cur.Literal() {
+		case ".":
+			op := p.cur
+			p.next() // .
+			member = &ast.SelectorExpr{X: member, Op: op, Name: &ast.Ident{Token: p.expectIdent()}}
+			continue
+		case "[":
+			lb := p.cur.Position()
+			p.next() // [
+			idx := p.parseExpr()
+			rb := p.exceptPunctuator("]")
+			member = &ast.IndexExpr{Arr: member, Lbrack: lb, Index: idx, Rbrack: rb.Position()}
+			continue
+		}
+		break
+	}
+	expr.Member = member
+	expr.Rparen = p.exceptPunctuator(")").Position()
+	// 成员名称在解析时检查，不依赖是否在常量表达式中求值
+	p.memberType(expr.Type, expr.Member)
+	return expr
+}
+
+// __builtin_offsetof 的值
+func (p *parser) constOffsetof(x *ast.OffsetOfExpr) (constValue, bool) {
+	off, _, ok := p.memberOffset(x.Type, x.Member)
+	return constValue{val: off, typ: sizeType(p.opt.Target)}, ok
+}
+
+// 成员指示符对应的成员类型，成员不存在时报告错误
+func (p *parser) memberType(typ ast.Typename, member ast.Expr) (ast.Typename, bool) {
+	switch m := member.(type) {
+	case *ast.Ident:
+		return p.fieldType(typ, m)
+	case *ast.SelectorExpr:
+		t, ok := p.memberType(typ, m.X)
+		if !ok {
+			return nil, false
+		}
+		return p.fieldType(t, m.Name)
+	case *ast.IndexExpr:
+		t, ok := p.memberType(typ, m.Arr)
+		if !ok {
+			return nil, false
+		}
+		arr, isArr := unParen(t).(*ast.ArrayType)
+		if !isArr {
+			p.addErr(m.Lbrack, errors.ErrSyntaxExpectedGot, "数组", typeString(t))
+			return nil, false
+		}
+		return arr.Type, true
+	}
+	return nil, false
+}
+
+// 结构体/联合体中指定名称的成员类型
+func (p *parser) fieldType(typ ast.Typename, name *ast.Ident) (ast.Typename, bool) {
+	var t ast.Typename
+	if v, ok := unParen(typ).(*ast.RecordType); ok {
+		if r := p.completeRecord(v); r != nil {
+			t = p.lookupField(r, name)
+		}
+	}
+	if t == nil {
+		p.addErr(name.Position(), errors.ErrSyntaxNoMember, typeString(typ), name.Literal())
+		return nil, false
+	}
+	return t, true
+}
+
+// 按名称查找成员类型，匿名成员中的字段也可以直接访问
+func (p *parser) lookupField(r *ast.RecordType, name *ast.Ident) ast.Typename {
+	for _, f := range r.Fields {
+		if f.Name != nil {
+			if f.Name.Literal() == name.Literal() {
+				return f.Type
+			}
+			continue
+		}
+		if inner, ok := unParen(f.Type).(*ast.RecordType); ok {
+			if inner = p.completeRecord(inner); inner != nil {
+				if t := p.lookupField(inner, name); t != nil {
+					return t
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// 成员相对于类型起始位置的偏移与成员的类型，成员名称的错误已在解析时报告
+func (p *parser) memberOffset(typ ast.Typename, member ast.Expr) (int64, ast.Typename, bool) {
+	switch m := member.(type) {
+	case *ast.Ident:
+		return p.fieldOffset(typ, m)
+	case *ast.SelectorExpr:
+		base, t, ok := p.memberOffset(typ, m.X)
+		if !ok {
+			return 0, nil, false
+		}
+		off, t, ok := p.fieldOffset(t, m.Name)
+		return base + off, t, ok
+	case *ast.IndexExpr:
+		base, t, ok := p.memberOffset(typ, m.Arr)
+		if !ok {
+			return 0, nil, false
+		}
+		arr, isArr := unParen(t).(*ast.ArrayType)
+		if !isArr {
+			return 0, nil, false
+		}
+		n, ok := p.evalConst(m.Index)
+		if !ok {
+			return 0, nil, false
+		}
+		size, _, ok := p.typeLayout(arr.Type, m.Lbrack)
+		return base + n*size, arr.Type, ok
+	}
+	return 0, nil, false
+}
+
+// 结构体/联合体中指定名称的成员的偏移
+func (p *parser) fieldOffset(typ ast.Typename, name *ast.Ident) (int64, ast.Typename, bool) {
+	v, ok := unParen(typ).(*ast.RecordType)
+	if !ok {
+		return 0, nil, false
+	}
+	r := p.completeRecord(v)
+	if r == nil {
+		return 0, nil, false
+	}
+	off, t, found, ok := p.findField(r, name)
+	return off, t, found && ok
+}
+
+func (p *parser) findField(r *ast.RecordType, name *ast.Ident) (off int64, typ ast.Typename, found, ok bool) {
+	offsets, _, _, ok := p.recordFieldLayout(r, name.Position())
+	if !ok {
+		return 0, nil, false, false
+	}
+	for i, f := range r.Fields {
+		if f.Name != nil {
+			if f.Name.Literal() == name.Literal() {
+				return offsets[i], f.Type, true, true
+			}
+			continue
+		}
+		inner, isRecord := unParen(f.Type).(*ast.RecordType)
+		if !isRecord {
+			continue
+		}
+		if inner = p.completeRecord(inner); inner == nil {
+			continue
+		}
+		if off, typ, found, ok := p.findField(inner, name); !ok || found {
+			return offsets[i] + off, typ, found, ok
+		}
+	}
+	return 0, nil, false, true
+}
+
+// case 范围的上下界为空时给出警告
+func (p *parser) checkCaseRange(stmt *ast.CaseRangeStmt) {
+	low, ok := p.constExpr(stmt.Low)
+	if !ok {
+		return
+	}
+	high, ok := p.constExpr(stmt.High)
+	if !ok {
+		return
+	}
+	if low.val > high.val {
+		p.addWarn(stmt.Ellipsis, errors.ErrSyntaxCaseRangeEmpty, low.val, high.val)
+	}
+}
+
+// goto * expression ;
+func (p *parser) parseIndirectGoto(pk token.Token) ast.Stmt {
+	stmt := &ast.IndirectGotoStmt{Goto: pk.Position(), Star: p.exceptPunctuator("*").Position()}
+	p.requireGNU(stmt.Star, "计算跳转")
+	stmt.X = p.parseExpr()
+	stmt.Semicolon = p.exceptPunctuator(";").Position()
+	return stmt
+}
+
+// case constant-expression ... constant-expression :
+func (p *parser) parseCaseRange(pk token.Token, low ast.Expr) ast.Stmt {
+	stmt := &ast.CaseRangeStmt{Case: pk.Position(), Low: low, Ellipsis: p.exceptPunctuator("...").Position()}
+	p.requireGNU(stmt.Ellipsis, "case 范围")
+	stmt.High = p.parseConstantExpr()
+	p.exceptPunctuator(":")
+	p.checkCaseRange(stmt)
+	stmt.Stmt = p.parseStmt()
+	return stmt
+}
+
+// GNU 模式下预定义的类型与函数，__builtin_va_list 不区分目标平台按 void * 处理
+func declareGNUBuiltins(scope *ast.Scope) {
+	vaList := &ast.PointerType{Type: &ast.BuildInType{Range: &ast.Range{}, Type: ast.Void}}
+	scope.Insert(ast.IdentScope, &ast.Object{
+		Type:     ast.ObjectTypename,
+		Name:     "__builtin_va_list",
+		Typename: vaList,
+	})
+	// __builtin_va_arg 单独解析，其余可变参数内建函数按普通函数调用
+	builtins := []struct {
+		name     string
+		params   int
+		ellipsis bool
+	}{
+		{"__builtin_va_start", 1, true},
+		{"__builtin_va_end", 1, false},
+		{"__builtin_va_copy", 2, false},
+	}
+	for _, b := range builtins {
+		fn := &ast.FuncType{
+			Return:   &ast.BuildInType{Range: &ast.Range{}, Type: ast.Void},
+			Ellipsis: b.ellipsis,
+		}
+		for i := 0; i < b.params; i++ {
+			fn.Params = append(fn.Params, &ast.ParamVarDecl{Qua: &ast.StorageSpecifier{}, Type: vaList})
+		}
+		scope.Insert(ast.IdentScope, &ast.Object{
+			Type:      ast.ObjectFunc,
+			Name:      b.name,
+			Typename:  fn,
+			Completed: true,
+		})
+	}
+}
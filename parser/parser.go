@@ -3,8 +3,11 @@ package parser
 import (
 	"dxkite.cn/c/ast"
 	"dxkite.cn/c/errors"
+	"dxkite.cn/c/literal"
 	"dxkite.cn/c/scanner"
+	"dxkite.cn/c/target"
 	"dxkite.cn/c/token"
+	"strconv"
 )
 
 // 表达式解析
@@ -19,20 +22,47 @@ type parser struct {
 	env *environment
 	// 当前文件
 	file string
+	// 正在解析的函数定义
+	fn *ast.FuncDecl
+	// 解析选项
+	opt Option
+	// 已经报告过方言不一致
+	dialectWarned bool
+}
+
+// 解析选项
+type Option struct {
+	// 支持 _Imaginary 虚数类型 (C11 附录 G)
+	Imaginary bool
+	// 支持 GNU C 的表达式与语句扩展，需要与扫描器的 scanner.DialectGNU 同时使用，不一致时给出警告
+	GNU bool
+	// 目标平台，决定类型的大小与对齐，默认为 target.Default
+	Target *target.Target
 }
 
 type multiparser struct {
 	global *ast.Scope // 全局作用域 (extern)
 	r      scanner.PeekScanner
 	err    errors.ErrorHandler
+	opt    Option
 }
 
-func newMultiparser(r scanner.Scanner, err errors.ErrorHandler) *multiparser {
-	return &multiparser{
+func newMultiparser(r scanner.Scanner, err errors.ErrorHandler, opt *Option) *multiparser {
+	p := &multiparser{
 		r:      scanner.NewPeekScan(r),
 		err:    err,
 		global: ast.NewScope(ast.GlobalScope, nil, 1),
 	}
+	if opt != nil {
+		p.opt = *opt
+	}
+	if p.opt.Target == nil {
+		p.opt.Target = target.Default
+	}
+	if p.opt.GNU {
+		declareGNUBuiltins(p.global)
+	}
+	return p
 }
 
 func (p *multiparser) parseUnit() *ast.TranslationUnit {
@@ -43,11 +73,12 @@ func (p *multiparser) parseUnit() *ast.TranslationUnit {
 			break
 		}
 		file := t.Position().Filename
-		pp := newParser(file, p.r, p.global, p.err)
+		pp := newParser(file, p.r, p.global, p.err, p.opt)
 		ret := pp.parseFile()
 		p.push(pp.cur)
 		unit.Files = append(unit.Files, ret)
 	}
+	p.checkInline(unit)
 	return unit
 }
 
@@ -55,11 +86,12 @@ func (p *multiparser) push(tok token.Token) {
 	p.r = scanner.NewPeekScan(scanner.NewMultiScan(p.r, scanner.NewArrayScan([]token.Token{tok})))
 }
 
-func newParser(file string, r scanner.Scanner, glb *ast.Scope, err errors.ErrorHandler) *parser {
+func newParser(file string, r scanner.Scanner, glb *ast.Scope, err errors.ErrorHandler, opt Option) *parser {
 	p := &parser{
 		err:  err,
 		file: file,
 		r:    scanner.NewTokenScan(r),
+		opt:  opt,
 	}
 	p.next()
 	p.env = newEnv(glb, p)
@@ -102,6 +134,9 @@ func precedence(lit string) int {
 
 // primary-expression: identifier | constant | string-literal | ( expression )
 func (p *parser) parsePrimaryExpr() ast.Expr {
+	if p.cur.Type() == token.PUNCTUATOR && p.cur.Literal() == "(" && p.peekOne().Literal() == "{" {
+		return p.parseStmtExpr()
+	}
 	if p.cur.Type() == token.PUNCTUATOR && p.cur.Literal() == "(" {
 		l := p.cur
 		p.next()
@@ -115,23 +150,80 @@ func (p *parser) parsePrimaryExpr() ast.Expr {
 	}
 	switch p.cur.Type() {
 	case token.IDENT:
+		switch {
+		case p.isBuiltin("__builtin_va_arg"):
+			return p.parseVaArgExpr()
+		case p.isBuiltin("__builtin_offsetof"):
+			return p.parseOffsetOfExpr()
+		}
 		cur := p.cur
 		p.next()
 		ident := &ast.Ident{Token: cur}
 		if obj := p.env.resolveIdent(ident); obj != nil {
 			ident.Type = obj.Typename
+			p.checkInlineRef(ident, obj)
+			p.checkDeprecated(ident, obj)
 		}
 		return ident
-	case token.INT, token.CHAR, token.FLOAT, token.STRING:
+	case token.INT, token.CHAR, token.FLOAT:
 		cur := p.cur
 		p.next()
 		return &ast.BasicLit{Token: cur}
+	case token.STRING:
+		return p.parseStringLit()
+	case token.KEYWORD:
+		if p.cur.Literal() == "_Generic" {
+			return p.parseGenericSelection()
+		}
 	}
 	exp := ast.BadExpr{Token: p.cur}
 	p.next()
 	return &exp
 }
 
+// 连接相邻的字符串
+func (p *parser) parseStringLit() *ast.BasicLit {
+	lit := &ast.BasicLit{Token: p.cur}
+	for p.cur.Type() == token.STRING {
+		lit.Parts = append(lit.Parts, p.cur)
+		p.next()
+	}
+	s, err := literal.ParseStrings(lit.Parts, p.opt.Target)
+	if err != nil {
+		p.addErr(err.Pos, err.Code, err.Params...)
+	}
+	lit.Encoding = s.Encoding
+	return lit
+}
+
+// _Generic ( assignment-expression , generic-assoc-list )
+func (p *parser) parseGenericSelection() ast.Expr {
+	expr := &ast.GenericSelectionExpr{Generic: p.cur.Position(), Selected: -1}
+	p.next() // _Generic
+	expr.Lparen = p.exceptPunctuator("(").Position()
+	expr.X = p.parseAssignExpr()
+	var pos []token.Position
+	for p.cur.Literal() == "," {
+		p.next() // ,
+		assoc := &ast.GenericAssoc{}
+		pos = append(pos, p.cur.Position())
+		if p.cur.Type() == token.KEYWORD && p.cur.Literal() == "default" {
+			assoc.Default = p.cur.Position()
+			p.next()
+		} else if p.isTypeNameTok(p.cur) {
+			assoc.Type = p.parseTypeName()
+		} else {
+			p.addErr(p.cur.Position(), errors.ErrSyntaxExpectedGot, "类型名", p.cur.Literal())
+		}
+		assoc.Colon = p.exceptPunctuator(":").Position()
+		assoc.X = p.parseAssignExpr()
+		expr.Assocs = append(expr.Assocs, assoc)
+	}
+	expr.Rparen = p.exceptPunctuator(")").Position()
+	p.resolveGeneric(expr, pos)
+	return expr
+}
+
 func (p *parser) parsePostfixExpr() ast.Expr {
 	// ( typename ) { init-list }
 	if p.cur.Type() == token.PUNCTUATOR && p.cur.Literal() == "(" {
@@ -190,8 +282,13 @@ func (p *parser) parsePostfixExprInner(expr ast.Expr) ast.Expr {
 }
 
 func (p *parser) parseUnaryExpr() ast.Expr {
-	if p.cur.Type() == token.PUNCTUATOR || p.cur.Literal() == "sizeof" {
+	if p.isExtension() {
+		return p.parseExtensionExpr()
+	}
+	if p.cur.Type() == token.PUNCTUATOR || p.cur.Literal() == "sizeof" || p.cur.Literal() == "_Alignof" {
 		switch p.cur.Literal() {
+		case "&&":
+			return p.parseLabelAddr()
 		case "++", "--", "&", "*", "+", "-", "~", "!":
 			op := p.cur
 			p.next() //
@@ -203,17 +300,35 @@ func (p *parser) parseUnaryExpr() ast.Expr {
 			op := p.cur
 			p.next() // sizeof
 			if t := p.peekOne(); p.cur.Literal() == "(" && p.isTypeNameTok(t) {
-				p.next()                  // (
-				name := p.parseTypeName() // type-name
-				p.exceptPunctuator(")")   // )
+				p.next()                      // (
+				name := p.parseTypeName()     // type-name
+				rp := p.exceptPunctuator(")") // )
 				return &ast.SizeOfExpr{
-					Type: name,
+					Range: &ast.Range{Begin: op.Position(), End: rp.Position()},
+					Type:  name,
 				}
 			}
 			return &ast.UnaryExpr{
 				Op: op,
 				X:  p.parseUnaryExpr(),
 			}
+		case "_Alignof":
+			op := p.cur
+			p.next() // _Alignof
+			if t := p.peekOne(); p.cur.Literal() == "(" && p.isTypeNameTok(t) {
+				p.next()                      // (
+				name := p.parseTypeName()     // type-name
+				rp := p.exceptPunctuator(")") // )
+				return &ast.AlignOfExpr{
+					Range: &ast.Range{Begin: op.Position(), End: rp.Position()},
+					Type:  name,
+				}
+			}
+			// GNU 扩展 __alignof__ unary-expression
+			return &ast.UnaryExpr{
+				Op: op,
+				X:  p.parseUnaryExpr(),
+			}
 		}
 	}
 	return p.parsePostfixExpr()
@@ -273,6 +388,17 @@ func (p *parser) parseCondExpr() ast.Expr {
 	if p.cur.Type() == token.PUNCTUATOR && p.cur.Literal() == "?" {
 		op := p.cur
 		p.next()
+		// x ?: y
+		if p.cur.Literal() == ":" {
+			p.requireGNU(op.Position(), "省略中间操作数的条件表达式")
+			colon := p.exceptPunctuator(":")
+			return &ast.BinaryCondExpr{
+				X:     x,
+				Op:    op,
+				Colon: colon.Position(),
+				Else:  p.parseCondExpr(),
+			}
+		}
 		then := p.parseExpr()
 		p.exceptPunctuator(":")
 		el := p.parseCondExpr()
@@ -433,10 +559,10 @@ func (p *parser) parseArgsExpr() []ast.Expr {
 	return list
 }
 
-var typeQualifier = []string{"const", "restrict", "volatile"}
-var typeSpecifier = []string{"void", "char", "short", "int", "long", "float", "double", "signed", "unsigned", "_Bool" /*"_Complex"*/}
-var functionSpecifier = []string{"inline"}
-var storageClassSpecifier = []string{"typedef", "extern", "static", "auto", "register"}
+var typeQualifier = []string{"const", "restrict", "volatile", "_Atomic"}
+var typeSpecifier = []string{"void", "char", "short", "int", "long", "float", "double", "signed", "unsigned", "_Bool", "_Complex", "_Imaginary"}
+var functionSpecifier = []string{"inline", "_Noreturn"}
+var storageClassSpecifier = []string{"typedef", "extern", "static", "auto", "register", "_Thread_local"}
 
 // 结构化类型
 var typeStructMap = map[string]bool{
@@ -449,12 +575,16 @@ var typeStructMap = map[string]bool{
 // "void", "char", "short", "int", "long", "float", "double", "signed", "unsigned", "_Bool", "_Complex"
 var typeSpecifierMap = map[string]bool{}
 
+// 组成内置类型的说明符
+var buildInSpecifierMap = map[string]bool{}
+
 // 声明
 var declarationSpecifierMap = map[string]bool{}
 
 // "const", "restrict", "volatile"
 var typeQualifierMap = map[string]bool{}
 var storageClassSpecifierMap = map[string]bool{}
+var functionSpecifierMap = map[string]bool{}
 var typeSpecifierQualifierMap = map[string]bool{}
 
 func init() {
@@ -467,12 +597,14 @@ func init() {
 	typeSpecifierMap = typeStructMap
 	for _, v := range typeSpecifier {
 		typeSpecifierMap[v] = true
+		buildInSpecifierMap[v] = true
 		typeSpecifierQualifierMap[v] = true
 	}
 
 	declarationSpecifierMap = typeSpecifierMap
 	for _, v := range functionSpecifier {
 		declarationSpecifierMap[v] = true
+		functionSpecifierMap[v] = true
 	}
 	for _, v := range storageClassSpecifier {
 		declarationSpecifierMap[v] = true
@@ -492,13 +624,18 @@ func (p *parser) parseAbstractDeclarator(inner ast.Typename) ast.Typename {
 		inner = p.parsePointer(inner)
 	case "(":
 		if t := p.peekOne().Literal(); t == "*" || t == "[" || t == "(" {
-			p.next() // (
-			inner = p.parseAbstractDeclarator(&ast.ParenType{Type: inner})
-			p.exceptPunctuator(")")
+			// 与声明符相同，括号内的部分作用于基础类型，括号外的部分作用于括号
+			lp := p.exceptPunctuator("(")
+			typ := p.parseAbstractDeclarator(inner)
+			rp := p.exceptPunctuator(")")
+			inner = &ast.ParenType{Lparen: lp.Position(), Type: typ, Rparen: rp.Position()}
 		} else {
 			inner = p.parseFuncType(inner)
 		}
 	case "[":
+		if p.isAttributeSpecifier() {
+			return inner
+		}
 		inner = p.parseArrayType(inner)
 	default:
 		return inner
@@ -548,6 +685,7 @@ func (p *parser) parseArrayTypeSize(inner ast.Typename) ast.Typename {
 		} else {
 			qua = append(qua, p.cur)
 		}
+		p.next()
 	}
 	expr := p.parseAssignExpr()
 	rb := p.exceptPunctuator("]") // ]
@@ -555,11 +693,11 @@ func (p *parser) parseArrayTypeSize(inner ast.Typename) ast.Typename {
 		Type:   inner,
 		Static: static,
 		Lbrack: lb.Position(),
+		Size:   expr,
 		Rbrack: rb.Position(),
 	}
 
-	if v, ok := expr.(*ast.AssignExpr); ok {
-		arr.Size = v
+	if _, ok := expr.(*ast.AssignExpr); ok {
 		return p.makeTypeQualifier(arr, qua)
 	}
 
@@ -586,35 +724,50 @@ func (p *parser) parseParameterList() (params ast.ParamList, ellipsis bool) {
 }
 
 func (p *parser) parseParameterDecl() *ast.ParamVarDecl {
-	typ, spec := p.parseDeclarationSpecifiers()
+	typ, spec, align, fnSpec, attrs := p.parseDeclarationSpecifiers()
+	p.disallowAlign(align)
+	p.disallowFuncSpec(fnSpec)
+	if pos, ok := (*spec)["_Thread_local"]; ok {
+		p.addErr(pos, errors.ErrSyntaxStorageNotAllowed, "_Thread_local")
+	}
 	param := &ast.ParamVarDecl{Qua: spec}
-	param.Type, param.Name = p.parseDeclarator(typ)
+	var declAttrs []*ast.Attribute
+	param.Type, param.Name, declAttrs = p.parseDeclarator(typ)
+	param.Attrs = append(append(attrs, declAttrs...), p.parseAttributes()...)
 	return param
 }
 
-func (p *parser) parseDeclarator(inner ast.Typename) (ast.Typename, *ast.Ident) {
+// 返回声明符中属于被声明实体的属性
+func (p *parser) parseDeclarator(inner ast.Typename) (ast.Typename, *ast.Ident, []*ast.Attribute) {
 	if p.cur.Literal() == "*" {
 		inner = p.parsePointer(inner)
 	}
 	return p.parseDirectDeclarator(inner)
 }
 
-func (p *parser) parseDirectDeclarator(inner ast.Typename) (ast.Typename, *ast.Ident) {
+func (p *parser) parseDirectDeclarator(inner ast.Typename) (ast.Typename, *ast.Ident, []*ast.Attribute) {
 	var ident *ast.Ident
 	if p.cur.Literal() == "(" {
 		lp := p.exceptPunctuator("(")
-		typ, ident := p.parseDeclarator(inner)
+		// 括号内声明符之前的属性属于被声明的实体
+		attrs := p.parseAttributes()
+		typ, ident, inAttrs := p.parseDeclarator(inner)
 		rp := p.exceptPunctuator(")")
 		typ = &ast.ParenType{Lparen: lp.Position(), Type: typ, Rparen: rp.Position()}
 		typ = p.parseDirectDeclaratorInner(typ)
-		return typ, ident
+		return typ, ident, append(attrs, inAttrs...)
 	}
+	var attrs []*ast.Attribute
 	if p.cur.Type() == token.IDENT {
 		tok := p.expectIdent()
 		ident = &ast.Ident{Token: tok}
+		// 标识符之后的属性属于被声明的实体，之后仍可以有数组或函数声明符
+		if p.isAttributeSpecifier() {
+			attrs = p.parseAttributes()
+		}
 	}
 	inner = p.parseDirectDeclaratorInner(inner)
-	return inner, ident
+	return inner, ident, attrs
 }
 
 func (p *parser) parseDirectDeclaratorInner(typ ast.Typename) ast.Typename {
@@ -622,6 +775,10 @@ func (p *parser) parseDirectDeclaratorInner(typ ast.Typename) ast.Typename {
 	case "(":
 		typ = p.parseFuncType(typ)
 	case "[":
+		// 声明符之后的 [[ 为属性说明符
+		if p.isAttributeSpecifier() {
+			return typ
+		}
 		typ = p.parseArrayType(typ)
 	default:
 		return typ
@@ -630,18 +787,41 @@ func (p *parser) parseDirectDeclaratorInner(typ ast.Typename) ast.Typename {
 }
 
 // ( type-specifier | type-qualifier ) +
+// 类型名称中的属性不记录
 func (p *parser) parseTypeQualifierSpecifierList() ast.Typename {
+	typ, align, _ := p.parseSpecifierQualifierList()
+	p.disallowAlign(align)
+	return typ
+}
+
+// ( type-specifier | type-qualifier | alignment-specifier | attribute ) +
+func (p *parser) parseSpecifierQualifierList() (ast.Typename, []*ast.AlignSpec, []*ast.Attribute) {
 	var qua []token.Token
 	var typ ast.Typename
 	var buildIn []token.Token
+	var align []*ast.AlignSpec
+	var attrs []*ast.Attribute
 
-	for typeSpecifierQualifierMap[p.cur.Literal()] {
-		if typeQualifierMap[p.cur.Literal()] {
+	for typeSpecifierQualifierMap[p.cur.Literal()] || isTypeof(p.cur) || p.isAlignmentSpecifier(p.cur) || p.isAttribute() ||
+		p.isExtension() || typ == nil && len(buildIn) == 0 && p.isTypedefName(p.cur) != nil {
+		if p.isAttribute() {
+			attrs = append(attrs, p.parseAttributes()...)
+			continue
+		}
+		if p.isExtension() {
+			p.next()
+			continue
+		}
+		if typeQualifierMap[p.cur.Literal()] && !p.isAtomicSpecifier() {
 			qua = append(qua, p.cur)
 			p.next()
 			continue
 		}
-		if len(buildIn) > 0 && typeStructMap[p.cur.Literal()] {
+		if p.isAlignmentSpecifier(p.cur) {
+			align = append(align, p.parseAlignSpec())
+			continue
+		}
+		if len(buildIn) > 0 && (typeStructMap[p.cur.Literal()] || isTypeof(p.cur)) {
 			p.addErr(p.cur.Position(), errors.ErrSyntaxUnexpectedTypeSpecifier, p.cur.Literal())
 		}
 		if t, s := p.parseTypeSpecifier(); t != nil {
@@ -655,11 +835,7 @@ func (p *parser) parseTypeQualifierSpecifierList() ast.Typename {
 		tp := &ast.BuildInType{
 			Type: ast.Int,
 		}
-		if t, err := ast.ParseBuildInType(buildIn); err != nil {
-			p.addErr(err.Pos, err.Code, err.Params...)
-		} else {
-			tp.Type = t
-		}
+		tp.Type = p.parseBuildInType(buildIn)
 		rg := &ast.Range{}
 		rg.Begin = buildIn[0].Position()
 		rg.End = buildIn[len(buildIn)-1].Position()
@@ -668,16 +844,22 @@ func (p *parser) parseTypeQualifierSpecifierList() ast.Typename {
 	}
 
 	if len(qua) > 0 && typ != nil {
+		p.checkAtomicQualifier(typ, qua)
 		p.markQualifier(typ.Qualifier(), qua)
 	}
-	return typ
+	return typ, align, attrs
 }
 
 // (('*') typeQualifierList?)+
 func (p *parser) parsePointer(inner ast.Typename) (t ast.Typename) {
 	pk := p.exceptPunctuator("*")
 	tt := &ast.PointerType{Pointer: pk.Position(), Type: inner}
+	// 限定符与属性可以交替出现
 	tks := p.scanTypeQualifierTok()
+	for p.isAttribute() {
+		tt.Attrs = append(tt.Attrs, p.parseAttributes()...)
+		tks = append(tks, p.scanTypeQualifierTok()...)
+	}
 	t = p.makeTypeQualifier(tt, tks)
 	for p.cur.Literal() == "*" {
 		t = p.parsePointer(t)
@@ -691,29 +873,60 @@ func (p *parser) makeTypeQualifier(typ ast.Typename, qua []token.Token) ast.Type
 }
 
 func (p *parser) isDeclarationSpecifier(tok token.Token) bool {
-	return declarationSpecifierMap[tok.Literal()] || p.isTypeNameTok(tok)
+	return declarationSpecifierMap[tok.Literal()] || p.isAlignmentSpecifier(tok) || p.isTypeNameTok(tok)
+}
+
+func (p *parser) isAlignmentSpecifier(tok token.Token) bool {
+	return tok.Type() == token.KEYWORD && tok.Literal() == "_Alignas"
 }
 
 // 扫描类型
-func (p *parser) parseDeclarationSpecifiers() (ast.Typename, *ast.StorageSpecifier) {
+func (p *parser) parseDeclarationSpecifiers() (ast.Typename, *ast.StorageSpecifier, []*ast.AlignSpec, *ast.FunctionSpecifier, []*ast.Attribute) {
 	var qua []token.Token
 	var typ ast.Typename
 	var buildIn []token.Token
 	var spec []token.Token
+	var align []*ast.AlignSpec
+	var attrs []*ast.Attribute
+	fnSpec := &ast.FunctionSpecifier{}
+
+	for p.isDeclarationSpecifier(p.cur) || p.isAttribute() || p.isExtension() {
+		if p.isAttribute() {
+			attrs = append(attrs, p.parseAttributes()...)
+			continue
+		}
+		// __extension__ 用于声明时只是抑制警告
+		if p.isExtension() {
+			p.next()
+			continue
+		}
 
-	for p.isDeclarationSpecifier(p.cur) {
-		if typeQualifierMap[p.cur.Literal()] {
+		// 函数说明符可以重复出现
+		if functionSpecifierMap[p.cur.Literal()] {
+			if _, ok := (*fnSpec)[p.cur.Literal()]; !ok {
+				(*fnSpec)[p.cur.Literal()] = p.cur.Position()
+			}
+			p.next()
+			continue
+		}
+
+		if typeQualifierMap[p.cur.Literal()] && !p.isAtomicSpecifier() {
 			qua = append(qua, p.cur)
 			p.next()
 			continue
 		}
 
+		if p.isAlignmentSpecifier(p.cur) {
+			align = append(align, p.parseAlignSpec())
+			continue
+		}
+
 		if storageClassSpecifierMap[p.cur.Literal()] {
 			spec = append(spec, p.cur)
 			p.next()
 			continue
 		}
-		if len(buildIn) > 0 && typeStructMap[p.cur.Literal()] {
+		if len(buildIn) > 0 && (typeStructMap[p.cur.Literal()] || isTypeof(p.cur)) {
 			p.addErr(p.cur.Position(), errors.ErrSyntaxUnexpectedTypeSpecifier, p.cur.Literal())
 		}
 		if t, s := p.parseTypeSpecifier(); t != nil {
@@ -727,15 +940,12 @@ func (p *parser) parseDeclarationSpecifiers() (ast.Typename, *ast.StorageSpecifi
 		tp := &ast.BuildInType{
 			Type: ast.Int,
 		}
-		if t, err := ast.ParseBuildInType(buildIn); err != nil {
-			p.addErr(err.Pos, err.Code, err.Params...)
-		} else {
-			tp.Type = t
-		}
+		tp.Type = p.parseBuildInType(buildIn)
 		typ = tp
 	}
 
 	if len(qua) > 0 && typ != nil {
+		p.checkAtomicQualifier(typ, qua)
 		p.markQualifier(typ.Qualifier(), qua)
 	}
 
@@ -743,7 +953,69 @@ func (p *parser) parseDeclarationSpecifiers() (ast.Typename, *ast.StorageSpecifi
 	if len(spec) > 0 {
 		p.markSpecifier(storage, spec)
 	}
-	return typ, storage
+	p.checkThreadLocal(spec)
+	// typedef 与 register 不能指定对齐
+	if _, ok := (*storage)["typedef"]; ok {
+		p.disallowAlign(align)
+	} else if _, ok := (*storage)["register"]; ok {
+		p.disallowAlign(align)
+	}
+	return typ, storage, align, fnSpec, attrs
+}
+
+// _Alignas ( type-name ) | _Alignas ( constant-expression )
+func (p *parser) parseAlignSpec() *ast.AlignSpec {
+	spec := &ast.AlignSpec{Alignas: p.exceptKeyword("_Alignas").Position()}
+	p.exceptPunctuator("(")
+	if p.isTypeNameTok(p.cur) {
+		spec.Type = p.parseTypeName()
+		spec.Align, _ = p.naturalAlign(spec.Type, spec.Alignas)
+	} else {
+		spec.X = p.parseConstantExpr()
+		if v, ok := p.evalConst(spec.X); ok {
+			if v < 0 || v&(v-1) != 0 {
+				p.addErr(spec.X.Beg(), errors.ErrSyntaxAlignNotPowerOfTwo, strconv.FormatInt(v, 10))
+			} else {
+				spec.Align = v
+			}
+		}
+	}
+	spec.Rparen = p.exceptPunctuator(")").Position()
+	return spec
+}
+
+// _Thread_local 只能与 static 或 extern 同时使用
+func (p *parser) checkThreadLocal(spec []token.Token) {
+	var tls token.Token
+	for _, t := range spec {
+		if t.Literal() == "_Thread_local" {
+			tls = t
+		}
+	}
+	if tls == nil {
+		return
+	}
+	for _, t := range spec {
+		switch t.Literal() {
+		case "_Thread_local", "static", "extern":
+		default:
+			p.addErr(t.Position(), errors.ErrSyntaxStorageConflict, t.Literal(), tls.Literal())
+		}
+	}
+}
+
+func (p *parser) disallowFuncSpec(spec *ast.FunctionSpecifier) {
+	for _, name := range functionSpecifier {
+		if pos, ok := (*spec)[name]; ok {
+			p.addErr(pos, errors.ErrSyntaxFuncSpecNotFunc, name)
+		}
+	}
+}
+
+func (p *parser) disallowAlign(align []*ast.AlignSpec) {
+	for _, a := range align {
+		p.addErr(a.Alignas, errors.ErrSyntaxAlignasNotAllowed)
+	}
 }
 
 func (p *parser) markSpecifier(q *ast.StorageSpecifier, qua []token.Token) {
@@ -761,6 +1033,12 @@ func (p *parser) parseTypeSpecifier() (ast.Typename, []token.Token) {
 		return p.parseRecordType(), nil
 	case "enum":
 		return p.parseEnumType(), nil
+	case "_Atomic":
+		return p.parseAtomicType(), nil
+	case "typeof", "typeof_unqual":
+		if isTypeof(p.cur) {
+			return p.parseTypeofType(), nil
+		}
 	default:
 		// 用户定义的类型
 		if p.cur.Type() == token.IDENT {
@@ -773,18 +1051,119 @@ func (p *parser) parseTypeSpecifier() (ast.Typename, []token.Token) {
 	return nil, p.parseBuildInSpec()
 }
 
+// _Atomic 后紧跟 ( 时为类型说明符
+func (p *parser) isAtomicSpecifier() bool {
+	return p.cur.Literal() == "_Atomic" && p.peekOne().Literal() == "("
+}
+
+// _Atomic ( type-name )
+func (p *parser) parseAtomicType() ast.Typename {
+	pk := p.exceptKeyword("_Atomic")
+	p.exceptPunctuator("(")
+	typ := p.parseTypeName()
+	p.exceptPunctuator(")")
+	if typ == nil {
+		return typ
+	}
+	// 不能是数组、函数、原子类型或有限定符的类型
+	switch unParen(typ).(type) {
+	case *ast.ArrayType, *ast.FuncType:
+		p.addErr(pk.Position(), errors.ErrSyntaxAtomicInvalidType, typeString(typ))
+		return typ
+	}
+	if !isUnqualified(typ) {
+		p.addErr(pk.Position(), errors.ErrSyntaxAtomicInvalidType, typeString(typ))
+		return typ
+	}
+	// typedef 名称的类型对象是共享的，复制后再添加限定符
+	typ = copyQualified(typ)
+	p.markQualifier(typ.Qualifier(), []token.Token{pk})
+	return typ
+}
+
+// 复制类型的最外层与限定符，修改限定符时不影响原类型
+func copyQualified(t ast.Typename) ast.Typename {
+	switch v := t.(type) {
+	case *ast.BuildInType:
+		n := *v
+		n.Qua = copyQualifier(v.Qua)
+		return &n
+	case *ast.RecordType:
+		n := *v
+		n.Qua = copyQualifier(v.Qua)
+		return &n
+	case *ast.EnumType:
+		n := *v
+		n.Qua = copyQualifier(v.Qua)
+		return &n
+	case *ast.PointerType:
+		n := *v
+		n.Qua = copyQualifier(v.Qua)
+		return &n
+	case *ast.TypeofType:
+		n := *v
+		n.Qua = copyQualifier(v.Qua)
+		return &n
+	case *ast.ParenType:
+		n := *v
+		n.Type = copyQualified(v.Type)
+		return &n
+	}
+	return t
+}
+
+func copyQualifier(q *ast.Qualifier) *ast.Qualifier {
+	n := ast.Qualifier{}
+	if q != nil {
+		for name, pos := range *q {
+			n[name] = pos
+		}
+	}
+	return &n
+}
+
+// _Atomic 限定符不能用于数组与函数类型
+func (p *parser) checkAtomicQualifier(typ ast.Typename, qua []token.Token) {
+	for _, t := range qua {
+		if t.Literal() != "_Atomic" {
+			continue
+		}
+		switch unParen(typ).(type) {
+		case *ast.ArrayType, *ast.FuncType:
+			p.addErr(t.Position(), errors.ErrSyntaxAtomicInvalidType, typeString(typ))
+		}
+	}
+}
+
 // 扫描内置类型
 func (p *parser) parseBuildInSpec() []token.Token {
 	var spec []token.Token
-	for p.cur.Type() == token.KEYWORD && typeSpecifierMap[p.cur.Literal()] {
-		if typeSpecifierMap[p.cur.Literal()] {
-			spec = append(spec, p.cur)
-		}
+	for p.cur.Type() == token.KEYWORD && buildInSpecifierMap[p.cur.Literal()] {
+		spec = append(spec, p.cur)
+		p.next()
+	}
+	// 不能作为类型说明符的符号，交给 ParseBuildInType 报告
+	if len(spec) == 0 {
+		spec = append(spec, p.cur)
 		p.next()
 	}
 	return spec
 }
 
+// 解析内置类型，出错时为 int
+func (p *parser) parseBuildInType(spec []token.Token) ast.BasicType {
+	t, err := ast.ParseBuildInType(spec)
+	if err != nil {
+		p.addErr(err.Pos, err.Code, err.Params...)
+		return ast.Int
+	}
+	if t.IsImaginary() && !p.opt.Imaginary {
+		p.addErr(spec[0].Position(), errors.ErrSyntaxImaginaryUnsupported, t.String())
+		return t.Real()
+	}
+	return t
+}
+
 func (p *parser) markQualifier(q *ast.Qualifier, qua []token.Token) {
 	if q == nil {
 		return
@@ -801,6 +1180,7 @@ func (p *parser) parseRecordType() *ast.RecordType {
 	t := p.cur
 	p.next() // struct union
 	r := &ast.RecordType{Type: t}
+	r.Attrs = p.parseAttributes()
 
 	if p.cur.Literal() != "{" {
 		tok := p.expectIdent()
@@ -808,6 +1188,7 @@ func (p *parser) parseRecordType() *ast.RecordType {
 	}
 
 	if p.cur.Literal() != "{" {
+		p.checkDeprecatedTag(r.Name, r.Type.Literal())
 		return r
 	}
 
@@ -816,17 +1197,27 @@ func (p *parser) parseRecordType() *ast.RecordType {
 	r.Completed = true
 	r.Lbrace = p.exceptPunctuator("{").Position()
 	for p.until("}") {
-		typ := p.parseTypeQualifierSpecifierList()
+		if p.isStaticAssert() {
+			r.Asserts = append(r.Asserts, p.parseStaticAssertDecl())
+			continue
+		}
+		typ, align, attrs := p.parseSpecifierQualifierList()
 		for p.cur.Type() != token.EOF {
 			f := &ast.RecordField{}
-			typ, ident := p.parseDeclarator(typ)
+			typ, ident, declAttrs := p.parseDeclarator(typ)
 			f.Type = typ
 			f.Name = ident
+			f.Attrs = append(append(attrs[:len(attrs):len(attrs)], declAttrs...), p.parseAttributes()...)
 			// bit-field
 			if p.cur.Literal() == ":" {
 				p.next() // :
 				expr := p.parseConstantExpr()
 				f.Bit = expr
+				f.Attrs = append(f.Attrs, p.parseAttributes()...)
+				p.disallowAlign(align)
+			} else {
+				f.Align = align
+				p.checkAlign(align, typ)
 			}
 			// bit field
 			if f.Bit == nil && f.Name == nil && !isRecordType(typ) {
@@ -844,6 +1235,7 @@ func (p *parser) parseRecordType() *ast.RecordType {
 	}
 
 	r.Rbrace = p.exceptPunctuator("}").Position() // }
+	r.Attrs = append(r.Attrs, p.parseAttributes()...)
 	p.env.declareRecord(r, true)
 	return r
 }
@@ -861,29 +1253,39 @@ func isRecordType(typ ast.Typename) bool {
 func (p *parser) parseEnumType() *ast.EnumType {
 	pk := p.exceptKeyword("enum")
 	t := &ast.EnumType{Enum: pk.Position()}
+	t.Attrs = p.parseAttributes()
 	if p.cur.Type() == token.IDENT {
 		t.Name = &ast.Ident{Token: p.cur}
 		p.next()
 	}
 	if p.cur.Literal() != "{" {
+		p.checkDeprecatedTag(t.Name, "enum")
 		return t
 	}
 	p.env.declareEnum(t, false)
 
 	t.Lbrace = p.exceptPunctuator("{").Position()
 	t.Completed = true
+	var val int64
 	for p.until("}") {
 		ident := p.expectIdent()
+		attrs := p.parseAttributes()
 		var expr ast.Expr
 		if p.cur.Literal() == "=" {
 			p.next()
 			expr = p.parseConstantExpr()
+			if v, ok := p.evalConst(expr); ok {
+				val = v
+			}
 		}
 		tag := &ast.EnumFieldDecl{
-			Name: &ast.Ident{Token: ident},
-			Val:  expr,
+			Name:  &ast.Ident{Token: ident},
+			Val:   expr,
+			Attrs: attrs,
 		}
 		p.env.declareEnumTag(tag)
+		p.env.enums[tag] = val
+		val++
 		t.List = append(t.List, tag)
 		if p.cur.Literal() != "," {
 			break
@@ -892,6 +1294,7 @@ func (p *parser) parseEnumType() *ast.EnumType {
 	}
 
 	t.Rbrace = p.exceptPunctuator("}").Position()
+	t.Attrs = append(t.Attrs, p.parseAttributes()...)
 	p.env.declareEnum(t, true)
 	return t
 }
@@ -905,16 +1308,18 @@ func (p *parser) scanTypeQualifierTok() (qua []token.Token) {
 	return
 }
 
-func (p *parser) parseDeclStmt() *ast.DeclStmt {
-	stmt := ast.DeclStmt(p.parseDeclaration())
+func (p *parser) parseDeclStmt(attrs []*ast.Attribute) *ast.DeclStmt {
+	stmt := ast.DeclStmt(p.parseDeclaration(attrs))
 	return &stmt
 }
 
-func (p *parser) parseDeclaration() []ast.Decl {
-	typ, spec := p.parseDeclarationSpecifiers()
+// attrs 为声明之前已经解析的属性
+func (p *parser) parseDeclaration(attrs []*ast.Attribute) []ast.Decl {
+	typ, spec, align, fnSpec, specAttrs := p.parseDeclarationSpecifiers()
+	attrs = append(attrs, specAttrs...)
 	var decls []ast.Decl
 	for p.until(";") {
-		decl := p.parserInitDeclarator(typ, spec)
+		decl := p.parserInitDeclarator(typ, spec, align, fnSpec, attrs)
 		decls = append(decls, decl)
 		if p.cur.Literal() == "," {
 			p.next() //,
@@ -930,8 +1335,8 @@ func (p *parser) until(lit string) bool {
 	return p.cur.Literal() != lit && p.cur.Type() != token.EOF
 }
 
-func (p *parser) parserInitDeclarator(inner ast.Typename, spec *ast.StorageSpecifier) ast.Decl {
-	decl, _ := p.parseExternalDeclOrInit(inner, spec, false)
+func (p *parser) parserInitDeclarator(inner ast.Typename, spec *ast.StorageSpecifier, align []*ast.AlignSpec, fnSpec *ast.FunctionSpecifier, attrs []*ast.Attribute) ast.Decl {
+	decl, _ := p.parseExternalDeclOrInit(inner, spec, align, fnSpec, attrs, false)
 	return decl
 }
 
@@ -951,6 +1356,9 @@ func (p *parser) parseStmt() ast.Stmt {
 		return p.parseForStmt()
 	case "goto":
 		pk := p.exceptKeyword("goto")
+		if p.cur.Literal() == "*" {
+			return p.parseIndirectGoto(pk)
+		}
 		id := p.expectIdent()
 		se := p.exceptPunctuator(";")
 		stmt := &ast.GotoStmt{
@@ -971,6 +1379,9 @@ func (p *parser) parseStmt() ast.Stmt {
 	case "return":
 		pk := p.exceptKeyword("return")
 		stmt := &ast.ReturnStmt{Return: pk.Position()}
+		if isNoreturn(p.fn) {
+			p.addWarn(pk.Position(), errors.ErrSyntaxNoreturnReturn, p.fn.Name.Literal())
+		}
 		if p.cur.Literal() != ";" {
 			stmt.X = p.parseExpr()
 		}
@@ -980,6 +1391,13 @@ func (p *parser) parseStmt() ast.Stmt {
 	case "{":
 		stmt := p.parseCompoundStmt()
 		return stmt
+	case ";":
+		se := p.exceptPunctuator(";")
+		return &ast.EmptyStmt{Semicolon: se.Position()}
+	}
+	if p.isAttribute() {
+		attrs := p.parseAttributes()
+		return &ast.AttributedStmt{Attrs: attrs, Stmt: p.parseStmt()}
 	}
 	if p.cur.Type() == token.IDENT && p.peekOne().Literal() == ":" {
 		return p.parseLabeledStmt()
@@ -992,6 +1410,9 @@ func (p *parser) parseLabeledStmt() ast.Stmt {
 	if p.cur.Literal() == "case" {
 		pk := p.exceptKeyword("case")
 		expr := p.parseConstantExpr()
+		if p.cur.Literal() == "..." {
+			return p.parseCaseRange(pk, expr)
+		}
 		p.exceptPunctuator(":")
 		stmt := p.parseStmt()
 		return &ast.CaseStmt{
@@ -1016,10 +1437,13 @@ func (p *parser) parseLabeledStmt() ast.Stmt {
 	if p.cur.Type() == token.IDENT && p.peekOne().Literal() == ":" {
 		ident := p.expectIdent()
 		p.exceptPunctuator(":")
+		// 标签之后的属性属于标签
+		attrs := p.parseGNUAttributes()
 		stmt := p.parseStmt()
 		st := &ast.LabelStmt{
-			Id:   &ast.Ident{Token: ident},
-			Stmt: stmt,
+			Id:    &ast.Ident{Token: ident},
+			Attrs: attrs,
+			Stmt:  stmt,
 		}
 		p.env.declare(ast.NewObject(ast.ObjectLabelName, st.Id))
 		return st
@@ -1048,6 +1472,7 @@ func (p *parser) parseIfStmt() ast.Stmt {
 	then := p.parseStmt()
 	var elseStmt ast.Stmt
 	if p.cur.Literal() == "else" {
+		p.next() // else
 		elseStmt = p.parseStmt()
 	}
 	return &ast.IfStmt{
@@ -1090,10 +1515,13 @@ func (p *parser) parseDoWhileStmt() ast.Stmt {
 func (p *parser) parseForStmt() ast.Stmt {
 	pk := p.exceptKeyword("for")
 	forStmt := &ast.ForStmt{For: pk.Position()}
-	if p.isTypeNameTok(p.cur) {
-		forStmt.Decl = p.parseDeclStmt()
+	p.exceptPunctuator("(")
+	if p.isDeclarationSpecifier(p.cur) || p.isAttribute() {
+		forStmt.Decl = p.parseDeclStmt(nil)
 	} else {
-		forStmt.Init = p.parseExpr()
+		if p.cur.Literal() != ";" {
+			forStmt.Init = p.parseExpr()
+		}
 		p.exceptPunctuator(";")
 	}
 	if p.cur.Literal() != ";" {
@@ -1101,11 +1529,11 @@ func (p *parser) parseForStmt() ast.Stmt {
 		forStmt.Cond = expr
 	}
 	p.exceptPunctuator(";")
-	if p.cur.Literal() != ";" {
+	if p.cur.Literal() != ")" {
 		expr := p.parseExpr()
 		forStmt.Post = expr
 	}
-	p.exceptPunctuator(";")
+	p.exceptPunctuator(")")
 	stmt := p.parseStmt()
 	forStmt.Stmt = stmt
 	return forStmt
@@ -1114,59 +1542,102 @@ func (p *parser) parseForStmt() ast.Stmt {
 func (p *parser) parseCompoundStmt() *ast.CompoundStmt {
 	comp := ast.CompoundStmt{}
 	lb := p.exceptPunctuator("{")
+	// 块开头的 __label__ 声明局部标签
+	local := p.isLabelDecl()
+	if local {
+		p.env.enterLocalLabelScope()
+	}
+	for p.isLabelDecl() {
+		comp.Stmts = append(comp.Stmts, p.parseLabelDecl(true))
+	}
 	for p.until("}") {
 		stmt := p.parseBlockItem()
 		comp.Stmts = append(comp.Stmts, stmt)
 	}
 	rb := p.exceptPunctuator("}")
+	if local {
+		p.env.leaveLocalLabelScope()
+	}
+	p.checkFallthrough(comp.Stmts)
 	comp.Lbrace = lb.Position()
 	comp.Rbrace = rb.Position()
 	return &comp
 }
 
 func (p *parser) parseBlockItem() ast.Stmt {
-	if declarationSpecifierMap[p.cur.Literal()] || p.isTypeNameTok(p.cur) {
-		return p.parseDeclStmt()
+	if p.isLabelDecl() {
+		p.addErr(p.cur.Position(), errors.ErrSyntaxLabelDeclNotAtStart)
+		return p.parseLabelDecl(false)
+	}
+	if p.isStaticAssert() {
+		stmt := ast.DeclStmt{p.parseStaticAssertDecl()}
+		return &stmt
+	}
+	if p.isAttribute() {
+		attrs := p.parseAttributes()
+		if p.isDeclarationSpecifier(p.cur) {
+			return p.parseDeclStmt(attrs)
+		}
+		return &ast.AttributedStmt{Attrs: attrs, Stmt: p.parseStmt()}
+	}
+	if p.isDeclarationSpecifier(p.cur) || p.isExtension() && p.isDeclarationSpecifier(p.peekOne()) {
+		return p.parseDeclStmt(nil)
 	}
 	return p.parseStmt()
 }
 
 func (p *parser) parseExprStmt() ast.Stmt {
 	expr := p.parseExpr()
+	p.checkNodiscard(expr)
 	sm := p.exceptPunctuator(";")
 	return &ast.ExprStmt{Expr: expr, Semicolon: sm.Position()}
 }
 
 func (p *parser) parseExternalDecl() ast.Decl {
-	typ, spec := p.parseDeclarationSpecifiers()
-	decl, comma := p.parseExternalDeclOrInit(typ, spec, true)
+	if p.isStaticAssert() {
+		return p.parseStaticAssertDecl()
+	}
+	typ, spec, align, fnSpec, attrs := p.parseDeclarationSpecifiers()
+	decl, comma := p.parseExternalDeclOrInit(typ, spec, align, fnSpec, attrs, true)
 	if comma {
 		p.exceptPunctuator(";")
 	}
 	return decl
 }
 
-func (p *parser) parseExternalDeclOrInit(inner ast.Typename, specifier *ast.StorageSpecifier, external bool) (ast.Decl, bool) {
+func (p *parser) parseExternalDeclOrInit(inner ast.Typename, specifier *ast.StorageSpecifier, align []*ast.AlignSpec, fnSpec *ast.FunctionSpecifier, attrs []*ast.Attribute, external bool) (ast.Decl, bool) {
 	isTypedef := false
 	if _, ok := (*specifier)["typedef"]; ok {
 		isTypedef = true
 	}
 
-	typ, ident := p.parseDeclarator(inner)
+	typ, ident, declAttrs := p.parseDeclarator(inner)
+	// 声明符中与声明符之后的属性只属于当前声明符
+	attrs = append(append(attrs[:len(attrs):len(attrs)], declAttrs...), p.parseAttributes()...)
 	if isTypedef {
+		p.disallowFuncSpec(fnSpec)
 		decl := &ast.TypedefDecl{
 			Typedef: (*specifier)["typedef"],
 			Type:    typ,
 			Name:    ident,
+			Attrs:   attrs,
 		}
 		p.env.declareType(decl)
+		p.typedefAlign(decl)
 		return decl, external
 	}
 
 	if v, ok := typ.(*ast.FuncType); ok && external {
+		p.disallowAlign(align)
+		if pos, ok := (*specifier)["_Thread_local"]; ok {
+			p.addErr(pos, errors.ErrSyntaxStorageNotAllowed, "_Thread_local")
+		}
 		fn := &ast.FuncDecl{
-			Type: v,
-			Name: ident,
+			Qua:   specifier,
+			Spec:  withNoreturn(fnSpec, attrs),
+			Type:  v,
+			Name:  ident,
+			Attrs: attrs,
 		}
 
 		obj := ast.NewDeclObject(ast.ObjectFunc, ident, fn)
@@ -1178,15 +1649,20 @@ func (p *parser) parseExternalDeclOrInit(inner ast.Typename, specifier *ast.Stor
 		// 如果函数中未定义参数类型 则在后续尝试解析语句定义
 		if len(v.Params) > 0 && v.Params[0].Type == nil {
 			for declarationSpecifierMap[p.cur.Literal()] {
-				fn.Decl = append(fn.Decl, p.parseDeclaration()...)
+				fn.Decl = append(fn.Decl, p.parseDeclaration(nil)...)
 			}
 		}
 
 		p.env.enterLabelScope()
 		p.env.enterScope(ast.FuncScope)
 		p.env.copyParameterScope(fn)
+		p.fn = fn
 		fn.Body = p.parseCompoundStmt()
-		p.env.leaveScope()
+		p.fn = nil
+		p.reportUnused(p.env.leaveScope(), false)
+		if isNoreturn(fn) && p.mayFallOff(fn.Body) {
+			p.addWarn(fn.Body.Rbrace, errors.ErrSyntaxNoreturnFallOff, fn.Name.Literal())
+		}
 		p.reportUnResolveLabel(p.env.leaveLabelScope())
 
 		obj.Completed = true
@@ -1194,7 +1670,24 @@ func (p *parser) parseExternalDeclOrInit(inner ast.Typename, specifier *ast.Stor
 		return fn, false
 	}
 
-	decl := &ast.VarDecl{Type: typ, Name: ident}
+	decl := &ast.VarDecl{Qua: specifier, Type: typ, Name: ident, Attrs: attrs}
+	if _, ok := typ.(*ast.FuncType); ok {
+		p.disallowAlign(align)
+		if pos, ok := (*specifier)["_Thread_local"]; ok {
+			p.addErr(pos, errors.ErrSyntaxStorageNotAllowed, "_Thread_local")
+		}
+	} else {
+		p.disallowFuncSpec(fnSpec)
+		if _, ok := (*specifier)["register"]; !ok {
+			decl.Align = align
+			p.checkAlign(align, typ)
+		}
+		p.checkInlineStaticVar(decl, external)
+		// 块作用域的线程存储期变量需要 static 或 extern，与其他说明符的冲突已经报告过
+		if pos, ok := (*specifier)["_Thread_local"]; ok && !external && len(*specifier) == 1 {
+			p.addErr(pos, errors.ErrSyntaxThreadLocalBlockScope)
+		}
+	}
 
 	if p.cur.Literal() == "=" {
 		p.exceptPunctuator("=")
@@ -1205,12 +1698,37 @@ func (p *parser) parseExternalDeclOrInit(inner ast.Typename, specifier *ast.Stor
 	return decl, external
 }
 
+func (p *parser) isStaticAssert() bool {
+	return p.cur.Type() == token.KEYWORD && p.cur.Literal() == "_Static_assert"
+}
+
+// _Static_assert ( constant-expression , string-literal ) ;
+func (p *parser) parseStaticAssertDecl() *ast.StaticAssertDecl {
+	pk := p.exceptKeyword("_Static_assert")
+	decl := &ast.StaticAssertDecl{StaticAssert: pk.Position()}
+	p.exceptPunctuator("(")
+	decl.Cond = p.parseConstantExpr()
+	// C23 可以省略提示信息
+	if p.cur.Literal() == "," {
+		p.next() // ,
+		if p.cur.Type() == token.STRING {
+			decl.Msg = p.parseStringLit()
+		} else {
+			p.addErr(p.cur.Position(), errors.ErrSyntaxExpectedGot, "字符串", p.cur.Literal())
+		}
+	}
+	p.exceptPunctuator(")")
+	decl.Semicolon = p.exceptPunctuator(";").Position()
+	p.checkStaticAssert(decl)
+	return decl
+}
+
 func (p *parser) parseFile() *ast.File {
 	unit := &ast.File{}
 	unit.Name = p.file
 	var decls []ast.Decl
 	for p.cur.Type() != token.EOF && p.cur.Position().Filename == p.file {
-		if p.isDeclarationSpecifier(p.cur) {
+		if p.isDeclarationSpecifier(p.cur) || p.isAttribute() || p.isExtension() || p.isStaticAssert() {
 			decl := p.parseDecl()
 			decls = append(decls, decl)
 		} else {
@@ -1219,6 +1737,7 @@ func (p *parser) parseFile() *ast.File {
 		}
 	}
 	unit.Decl = decls
+	p.reportUnused(p.env.nested, true)
 	return unit
 }
 
@@ -1228,7 +1747,7 @@ func (p *parser) parseDecl() ast.Decl {
 
 func (p *parser) isTypeNameTok(tok token.Token) bool {
 	name := tok.Literal()
-	if typeSpecifierQualifierMap[name] {
+	if typeSpecifierQualifierMap[name] || isTypeof(tok) {
 		return true
 	}
 	return p.isTypedefName(tok) != nil
@@ -1251,6 +1770,7 @@ func (p *parser) expectIdent() token.Token {
 // 获取下一个Token
 func (p *parser) next() token.Token {
 	p.cur = p.r.Scan()
+	p.checkDialect(p.cur)
 	return p.cur
 }
 
@@ -1279,7 +1799,7 @@ func (p *parser) addErr(pos token.Position, code errors.ErrCode, args ...interfa
 }
 
 func (p *parser) addWarn(pos token.Position, code errors.ErrCode, args ...interface{}) {
-	p.err(pos, errors.ErrTypeError, code, args...)
+	p.err(pos, errors.ErrTypeWarning, code, args...)
 }
 
 func (p *parser) reportUnResolveLabel(labels []*ast.Ident) {
@@ -5,6 +5,7 @@ import (
 	"dxkite.cn/c/errors"
 	"dxkite.cn/c/preprocess"
 	"dxkite.cn/c/scanner"
+	"dxkite.cn/c/target"
 	"dxkite.cn/c/token"
 	stderr "errors"
 	"fmt"
@@ -180,8 +181,27 @@ func testParseFile(filename string) error {
 	sep := "// ==========================="
 	code := strings.Split(string(file), sep)
 	ctx := preprocess.NewContext()
-	r := preprocess.New(ctx, scanner.NewStringScan(filename, code[0], nil), nil)
-	p := newMultiparser(r, errHandler)
+	// gnu- 开头的文件按 GNU 方言解析
+	var scanOpt *scanner.Option
+	var opt *Option
+	if strings.HasPrefix(filepath.Base(filename), "gnu-") {
+		scanOpt = &scanner.Option{Dialect: scanner.DialectGNU}
+		opt = &Option{GNU: true}
+	}
+	// gnuscan- 开头的文件只有扫描器使用 GNU 方言，用于检查方言不一致的警告
+	if strings.HasPrefix(filepath.Base(filename), "gnuscan-") {
+		scanOpt = &scanner.Option{Dialect: scanner.DialectGNU}
+	}
+	// 以目标平台名称开头的文件按该平台计算类型大小，如 ilp32-layout.c
+	if tgt, ok := target.Lookup(strings.SplitN(filepath.Base(filename), "-", 2)[0]); ok {
+		if opt == nil {
+			opt = &Option{}
+		}
+		opt.Target = tgt
+	}
+	// 统一使用 / 分隔路径，测试结果与平台无关
+	r := preprocess.New(ctx, scanner.NewStringScan(filepath.ToSlash(filename), code[0], scanOpt), nil)
+	p := newMultiparser(r, errHandler, opt)
 	t := p.parseUnit()
 
 	dump := ast.String(t, "// ", " ")
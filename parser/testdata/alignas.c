@@ -0,0 +1,244 @@
+struct packet {
+    char tag;
+    _Alignas(16) char data[4];
+    _Alignas(double) int len;
+};
+
+_Alignas(32) static char buffer[64];
+_Alignas(int) _Alignas(8) short counter;
+
+_Static_assert(_Alignof(struct packet) == 16, "packet align");
+_Static_assert(sizeof(struct packet) == 32, "packet size");
+_Static_assert(_Alignof(long long) == 8 && _Alignof(char[10]) == 1, "basic align");
+_Static_assert(_Alignof(counter) == 8, "declared align");
+
+_Alignas(3) int odd;
+_Alignas(2) double weak;
+typedef _Alignas(8) int aligned_int;
+
+struct flags {
+    _Alignas(4) unsigned int mode : 3;
+};
+
+int main(_Alignas(8) int argc) {
+    register _Alignas(16) int r = 0;
+    return (int) _Alignof(int);
+}
+// ===========================
+// TranslationUnit
+//  `+Files = 
+//   `-File
+//    |+Name = testdata/alignas.c
+//    |+Decl = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  struct packet
+//    | | |+Name = <nil>
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[static:testdata/alignas.c:7:14]
+//    | | |+Type =   char[64]
+//    | | |+Name = buffer
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | | `-AlignSpec
+//    | | |  |+Alignas = testdata/alignas.c:7:1
+//    | | |  |+Type = <nil>
+//    | | |  |+X = ConstantExpr
+//    | | |  | `+X = 32
+//    | | |  |+Rparen = testdata/alignas.c:7:12
+//    | | |  `+Align = 32
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  short
+//    | | |+Name = counter
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | | |-AlignSpec
+//    | | | | |+Alignas = testdata/alignas.c:8:1
+//    | | | | |+Type =  int
+//    | | | | |+X = <nil>
+//    | | | | |+Rparen = testdata/alignas.c:8:13
+//    | | | | `+Align = 4
+//    | | | `-AlignSpec
+//    | | |  |+Alignas = testdata/alignas.c:8:15
+//    | | |  |+Type = <nil>
+//    | | |  |+X = ConstantExpr
+//    | | |  | `+X = 8
+//    | | |  |+Rparen = testdata/alignas.c:8:25
+//    | | |  `+Align = 8
+//    | | `+Attrs = 
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/alignas.c:10:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = AlignOfExpr
+//    | | |  | |+Range = Range
+//    | | |  | | |+Begin = testdata/alignas.c:10:16
+//    | | |  | | `+End = testdata/alignas.c:10:38
+//    | | |  | `+Type =  struct packet
+//    | | |  |+Op = "=="<PUNCTUATOR@testdata/alignas.c:10:40>
+//    | | |  `+Y = 16
+//    | | |+Msg = "packet align"
+//    | | `+Semicolon = testdata/alignas.c:10:62
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/alignas.c:11:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = SizeOfExpr
+//    | | |  | |+Range = Range
+//    | | |  | | |+Begin = testdata/alignas.c:11:16
+//    | | |  | | `+End = testdata/alignas.c:11:36
+//    | | |  | `+Type =  struct packet
+//    | | |  |+Op = "=="<PUNCTUATOR@testdata/alignas.c:11:38>
+//    | | |  `+Y = 32
+//    | | |+Msg = "packet size"
+//    | | `+Semicolon = testdata/alignas.c:11:59
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/alignas.c:12:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = BinaryExpr
+//    | | |  | |+X = AlignOfExpr
+//    | | |  | | |+Range = Range
+//    | | |  | | | |+Begin = testdata/alignas.c:12:16
+//    | | |  | | | `+End = testdata/alignas.c:12:34
+//    | | |  | | `+Type =  long long
+//    | | |  | |+Op = "=="<PUNCTUATOR@testdata/alignas.c:12:36>
+//    | | |  | `+Y = 8
+//    | | |  |+Op = "&&"<PUNCTUATOR@testdata/alignas.c:12:41>
+//    | | |  `+Y = BinaryExpr
+//    | | |   |+X = AlignOfExpr
+//    | | |   | |+Range = Range
+//    | | |   | | |+Begin = testdata/alignas.c:12:44
+//    | | |   | | `+End = testdata/alignas.c:12:61
+//    | | |   | `+Type =   char[10]
+//    | | |   |+Op = "=="<PUNCTUATOR@testdata/alignas.c:12:63>
+//    | | |   `+Y = 1
+//    | | |+Msg = "basic align"
+//    | | `+Semicolon = testdata/alignas.c:12:83
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/alignas.c:13:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = UnaryExpr
+//    | | |  | |+Op = "_Alignof"<KEYWORD@testdata/alignas.c:13:16>
+//    | | |  | `+X = ParenExpr
+//    | | |  |  |+Lparen = testdata/alignas.c:13:24
+//    | | |  |  |+X = counter
+//    | | |  |  `+Rparen = testdata/alignas.c:13:32
+//    | | |  |+Op = "=="<PUNCTUATOR@testdata/alignas.c:13:34>
+//    | | |  `+Y = 8
+//    | | |+Msg = "declared align"
+//    | | `+Semicolon = testdata/alignas.c:13:57
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  int
+//    | | |+Name = odd
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | | `-AlignSpec
+//    | | |  |+Alignas = testdata/alignas.c:15:1
+//    | | |  |+Type = <nil>
+//    | | |  |+X = ConstantExpr
+//    | | |  | `+X = 3
+//    | | |  |+Rparen = testdata/alignas.c:15:11
+//    | | |  `+Align = 0
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  double
+//    | | |+Name = weak
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | | `-AlignSpec
+//    | | |  |+Alignas = testdata/alignas.c:16:1
+//    | | |  |+Type = <nil>
+//    | | |  |+X = ConstantExpr
+//    | | |  | `+X = 2
+//    | | |  |+Rparen = testdata/alignas.c:16:11
+//    | | |  `+Align = 2
+//    | | `+Attrs = 
+//    | |-TypedefDecl
+//    | | |+Typedef = testdata/alignas.c:17:1
+//    | | |+Type =  int
+//    | | |+Name = aligned_int
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  struct flags
+//    | | |+Name = <nil>
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | `-FuncDecl
+//    |  |+Qua = map[]
+//    |  |+Spec = map[]
+//    |  |+Name = main
+//    |  |+Type =  int ( int)
+//    |  |+Decl = 
+//    |  |+Body = CompoundStmt
+//    |  | |+Lbrace = testdata/alignas.c:23:32
+//    |  | |+Stmts = 
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[register:testdata/alignas.c:24:5]
+//    |  | | |  |+Type =  int
+//    |  | | |  |+Name = r
+//    |  | | |  |+Init = 0
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | `-ReturnStmt
+//    |  | |  |+Return = testdata/alignas.c:25:5
+//    |  | |  |+X = TypeCastExpr
+//    |  | |  | |+Lparen = testdata/alignas.c:25:12
+//    |  | |  | |+Type =  int
+//    |  | |  | |+Rparen = testdata/alignas.c:25:16
+//    |  | |  | `+X = AlignOfExpr
+//    |  | |  |  |+Range = Range
+//    |  | |  |  | |+Begin = testdata/alignas.c:25:18
+//    |  | |  |  | `+End = testdata/alignas.c:25:30
+//    |  | |  |  `+Type =  int
+//    |  | |  `+Semicolon = testdata/alignas.c:25:31
+//    |  | `+Rbrace = testdata/alignas.c:26:1
+//    |  |+InlineDef = false
+//    |  `+Attrs = 
+//    `+Unresolved = 
+// ===========================
+//
+// |-Error
+// | |+Pos = testdata/alignas.c:15:10
+// | |+Typ = 0
+// | `+Msg = 在 testdata/alignas.c 文件的第15行10列: 对齐值 3 不是 2 的幂
+// |-Error
+// | |+Pos = testdata/alignas.c:16:1
+// | |+Typ = 0
+// | `+Msg = 在 testdata/alignas.c 文件的第16行1列: 对齐值 2 小于类型 double 的自然对齐 8
+// |-Error
+// | |+Pos = testdata/alignas.c:17:9
+// | |+Typ = 0
+// | `+Msg = 在 testdata/alignas.c 文件的第17行9列: 这里不能使用 _Alignas
+// |-Error
+// | |+Pos = testdata/alignas.c:20:5
+// | |+Typ = 0
+// | `+Msg = 在 testdata/alignas.c 文件的第20行5列: 这里不能使用 _Alignas
+// |-Error
+// | |+Pos = testdata/alignas.c:23:10
+// | |+Typ = 0
+// | `+Msg = 在 testdata/alignas.c 文件的第23行10列: 这里不能使用 _Alignas
+// |-Error
+// | |+Pos = testdata/alignas.c:24:14
+// | |+Typ = 0
+// | `+Msg = 在 testdata/alignas.c 文件的第24行14列: 这里不能使用 _Alignas
+// |-Error
+// | |+Pos = testdata/alignas.c:24:31
+// | |+Typ = 1
+// | `+Msg = 在 testdata/alignas.c 文件的第24行31列: 变量 r 未使用
+// `-Error
+//  |+Pos = testdata/alignas.c:7:26
+//  |+Typ = 1
+//  `+Msg = 在 testdata/alignas.c 文件的第7行26列: 变量 buffer 未使用
+// ===========================
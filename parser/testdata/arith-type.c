@@ -0,0 +1,391 @@
+_Static_assert(sizeof(signed char) == 1, "signed char");
+_Static_assert(sizeof(long) == 8, "long");
+_Static_assert(sizeof(long double) == 16, "long double");
+_Static_assert(sizeof(float _Complex) == 8, "float _Complex");
+_Static_assert(sizeof(long double _Complex) == 32, "long double _Complex");
+_Static_assert(_Alignof(double _Complex) == 8, "double _Complex");
+_Static_assert(_Generic((long)0, int: 1, long: 2, default: 3) == 2, "long");
+_Static_assert(_Generic((char)0, signed char: 1, unsigned char: 2, char: 3) == 3, "char");
+_Static_assert(_Generic(1UL, unsigned long: 1, unsigned long long: 2) == 1, "unsigned long");
+_Static_assert(_Generic(1.0L, double: 1, long double: 2) == 2, "long double");
+
+long unsigned int long a;
+int long signed b;
+double long c;
+_Complex double d;
+int x = _Generic(0, long: 1, long int: 2, default: 0);
+
+long short e;
+signed unsigned f;
+long long long g;
+long long double h;
+long double long i;
+_Complex j;
+int _Complex k;
+char char l;
+double _Imaginary m;
+// ===========================
+// TranslationUnit
+//  `+Files = 
+//   `-File
+//    |+Name = testdata/arith-type.c
+//    |+Decl = 
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/arith-type.c:1:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = SizeOfExpr
+//    | | |  | |+Range = Range
+//    | | |  | | |+Begin = testdata/arith-type.c:1:16
+//    | | |  | | `+End = testdata/arith-type.c:1:34
+//    | | |  | `+Type =  signed char
+//    | | |  |+Op = "=="<PUNCTUATOR@testdata/arith-type.c:1:36>
+//    | | |  `+Y = 1
+//    | | |+Msg = "signed char"
+//    | | `+Semicolon = testdata/arith-type.c:1:56
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/arith-type.c:2:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = SizeOfExpr
+//    | | |  | |+Range = Range
+//    | | |  | | |+Begin = testdata/arith-type.c:2:16
+//    | | |  | | `+End = testdata/arith-type.c:2:27
+//    | | |  | `+Type =  long
+//    | | |  |+Op = "=="<PUNCTUATOR@testdata/arith-type.c:2:29>
+//    | | |  `+Y = 8
+//    | | |+Msg = "long"
+//    | | `+Semicolon = testdata/arith-type.c:2:42
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/arith-type.c:3:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = SizeOfExpr
+//    | | |  | |+Range = Range
+//    | | |  | | |+Begin = testdata/arith-type.c:3:16
+//    | | |  | | `+End = testdata/arith-type.c:3:34
+//    | | |  | `+Type =  long double
+//    | | |  |+Op = "=="<PUNCTUATOR@testdata/arith-type.c:3:36>
+//    | | |  `+Y = 16
+//    | | |+Msg = "long double"
+//    | | `+Semicolon = testdata/arith-type.c:3:57
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/arith-type.c:4:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = SizeOfExpr
+//    | | |  | |+Range = Range
+//    | | |  | | |+Begin = testdata/arith-type.c:4:16
+//    | | |  | | `+End = testdata/arith-type.c:4:37
+//    | | |  | `+Type =  float _Complex
+//    | | |  |+Op = "=="<PUNCTUATOR@testdata/arith-type.c:4:39>
+//    | | |  `+Y = 8
+//    | | |+Msg = "float _Complex"
+//    | | `+Semicolon = testdata/arith-type.c:4:62
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/arith-type.c:5:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = SizeOfExpr
+//    | | |  | |+Range = Range
+//    | | |  | | |+Begin = testdata/arith-type.c:5:16
+//    | | |  | | `+End = testdata/arith-type.c:5:43
+//    | | |  | `+Type =  long double _Complex
+//    | | |  |+Op = "=="<PUNCTUATOR@testdata/arith-type.c:5:45>
+//    | | |  `+Y = 32
+//    | | |+Msg = "long double _Complex"
+//    | | `+Semicolon = testdata/arith-type.c:5:75
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/arith-type.c:6:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = AlignOfExpr
+//    | | |  | |+Range = Range
+//    | | |  | | |+Begin = testdata/arith-type.c:6:16
+//    | | |  | | `+End = testdata/arith-type.c:6:40
+//    | | |  | `+Type =  double _Complex
+//    | | |  |+Op = "=="<PUNCTUATOR@testdata/arith-type.c:6:42>
+//    | | |  `+Y = 8
+//    | | |+Msg = "double _Complex"
+//    | | `+Semicolon = testdata/arith-type.c:6:66
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/arith-type.c:7:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = GenericSelectionExpr
+//    | | |  | |+Generic = testdata/arith-type.c:7:16
+//    | | |  | |+Lparen = testdata/arith-type.c:7:24
+//    | | |  | |+X = TypeCastExpr
+//    | | |  | | |+Lparen = testdata/arith-type.c:7:25
+//    | | |  | | |+Type =  long
+//    | | |  | | |+Rparen = testdata/arith-type.c:7:30
+//    | | |  | | `+X = 0
+//    | | |  | |+Assocs = 
+//    | | |  | | |-GenericAssoc
+//    | | |  | | | |+Type =  int
+//    | | |  | | | |+Default = :0:0
+//    | | |  | | | |+Colon = testdata/arith-type.c:7:37
+//    | | |  | | | `+X = 1
+//    | | |  | | |-GenericAssoc
+//    | | |  | | | |+Type =  long
+//    | | |  | | | |+Default = :0:0
+//    | | |  | | | |+Colon = testdata/arith-type.c:7:46
+//    | | |  | | | `+X = 2
+//    | | |  | | `-GenericAssoc
+//    | | |  | |  |+Type = <nil>
+//    | | |  | |  |+Default = testdata/arith-type.c:7:51
+//    | | |  | |  |+Colon = testdata/arith-type.c:7:58
+//    | | |  | |  `+X = 3
+//    | | |  | |+Rparen = testdata/arith-type.c:7:61
+//    | | |  | `+Selected = 1
+//    | | |  |+Op = "=="<PUNCTUATOR@testdata/arith-type.c:7:63>
+//    | | |  `+Y = 2
+//    | | |+Msg = "long"
+//    | | `+Semicolon = testdata/arith-type.c:7:76
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/arith-type.c:8:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = GenericSelectionExpr
+//    | | |  | |+Generic = testdata/arith-type.c:8:16
+//    | | |  | |+Lparen = testdata/arith-type.c:8:24
+//    | | |  | |+X = TypeCastExpr
+//    | | |  | | |+Lparen = testdata/arith-type.c:8:25
+//    | | |  | | |+Type =  char
+//    | | |  | | |+Rparen = testdata/arith-type.c:8:30
+//    | | |  | | `+X = 0
+//    | | |  | |+Assocs = 
+//    | | |  | | |-GenericAssoc
+//    | | |  | | | |+Type =  signed char
+//    | | |  | | | |+Default = :0:0
+//    | | |  | | | |+Colon = testdata/arith-type.c:8:45
+//    | | |  | | | `+X = 1
+//    | | |  | | |-GenericAssoc
+//    | | |  | | | |+Type =  unsigned char
+//    | | |  | | | |+Default = :0:0
+//    | | |  | | | |+Colon = testdata/arith-type.c:8:63
+//    | | |  | | | `+X = 2
+//    | | |  | | `-GenericAssoc
+//    | | |  | |  |+Type =  char
+//    | | |  | |  |+Default = :0:0
+//    | | |  | |  |+Colon = testdata/arith-type.c:8:72
+//    | | |  | |  `+X = 3
+//    | | |  | |+Rparen = testdata/arith-type.c:8:75
+//    | | |  | `+Selected = 2
+//    | | |  |+Op = "=="<PUNCTUATOR@testdata/arith-type.c:8:77>
+//    | | |  `+Y = 3
+//    | | |+Msg = "char"
+//    | | `+Semicolon = testdata/arith-type.c:8:90
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/arith-type.c:9:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = GenericSelectionExpr
+//    | | |  | |+Generic = testdata/arith-type.c:9:16
+//    | | |  | |+Lparen = testdata/arith-type.c:9:24
+//    | | |  | |+X = 1UL
+//    | | |  | |+Assocs = 
+//    | | |  | | |-GenericAssoc
+//    | | |  | | | |+Type =  unsigned long
+//    | | |  | | | |+Default = :0:0
+//    | | |  | | | |+Colon = testdata/arith-type.c:9:43
+//    | | |  | | | `+X = 1
+//    | | |  | | `-GenericAssoc
+//    | | |  | |  |+Type =  unsigned long long
+//    | | |  | |  |+Default = :0:0
+//    | | |  | |  |+Colon = testdata/arith-type.c:9:66
+//    | | |  | |  `+X = 2
+//    | | |  | |+Rparen = testdata/arith-type.c:9:69
+//    | | |  | `+Selected = 0
+//    | | |  |+Op = "=="<PUNCTUATOR@testdata/arith-type.c:9:71>
+//    | | |  `+Y = 1
+//    | | |+Msg = "unsigned long"
+//    | | `+Semicolon = testdata/arith-type.c:9:93
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/arith-type.c:10:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = GenericSelectionExpr
+//    | | |  | |+Generic = testdata/arith-type.c:10:16
+//    | | |  | |+Lparen = testdata/arith-type.c:10:24
+//    | | |  | |+X = 1.0L
+//    | | |  | |+Assocs = 
+//    | | |  | | |-GenericAssoc
+//    | | |  | | | |+Type =  double
+//    | | |  | | | |+Default = :0:0
+//    | | |  | | | |+Colon = testdata/arith-type.c:10:37
+//    | | |  | | | `+X = 1
+//    | | |  | | `-GenericAssoc
+//    | | |  | |  |+Type =  long double
+//    | | |  | |  |+Default = :0:0
+//    | | |  | |  |+Colon = testdata/arith-type.c:10:53
+//    | | |  | |  `+X = 2
+//    | | |  | |+Rparen = testdata/arith-type.c:10:56
+//    | | |  | `+Selected = 1
+//    | | |  |+Op = "=="<PUNCTUATOR@testdata/arith-type.c:10:58>
+//    | | |  `+Y = 2
+//    | | |+Msg = "long double"
+//    | | `+Semicolon = testdata/arith-type.c:10:78
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  unsigned long long
+//    | | |+Name = a
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  long
+//    | | |+Name = b
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  long double
+//    | | |+Name = c
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  double _Complex
+//    | | |+Name = d
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  int
+//    | | |+Name = x
+//    | | |+Init = GenericSelectionExpr
+//    | | | |+Generic = testdata/arith-type.c:16:9
+//    | | | |+Lparen = testdata/arith-type.c:16:17
+//    | | | |+X = 0
+//    | | | |+Assocs = 
+//    | | | | |-GenericAssoc
+//    | | | | | |+Type =  long
+//    | | | | | |+Default = :0:0
+//    | | | | | |+Colon = testdata/arith-type.c:16:25
+//    | | | | | `+X = 1
+//    | | | | |-GenericAssoc
+//    | | | | | |+Type =  long
+//    | | | | | |+Default = :0:0
+//    | | | | | |+Colon = testdata/arith-type.c:16:38
+//    | | | | | `+X = 2
+//    | | | | `-GenericAssoc
+//    | | | |  |+Type = <nil>
+//    | | | |  |+Default = testdata/arith-type.c:16:43
+//    | | | |  |+Colon = testdata/arith-type.c:16:50
+//    | | | |  `+X = 0
+//    | | | |+Rparen = testdata/arith-type.c:16:53
+//    | | | `+Selected = 2
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  int
+//    | | |+Name = e
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  int
+//    | | |+Name = f
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  int
+//    | | |+Name = g
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  int
+//    | | |+Name = h
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  int
+//    | | |+Name = i
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  int
+//    | | |+Name = j
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  int
+//    | | |+Name = k
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  int
+//    | | |+Name = l
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | `-VarDecl
+//    |  |+Qua = map[]
+//    |  |+Type =  double
+//    |  |+Name = m
+//    |  |+Init = <nil>
+//    |  |+Align = 
+//    |  `+Attrs = 
+//    `+Unresolved = 
+// ===========================
+//
+// |-Error
+// | |+Pos = testdata/arith-type.c:16:30
+// | |+Typ = 0
+// | `+Msg = 在 testdata/arith-type.c 文件的第16行30列: 泛型关联的类型 long 与 long 兼容，上次出现的位置 testdata/arith-type.c:16:21
+// |-Error
+// | |+Pos = testdata/arith-type.c:18:6
+// | |+Typ = 0
+// | `+Msg = 在 testdata/arith-type.c 文件的第18行6列: 类型说明符 short 不能与 long 组合使用
+// |-Error
+// | |+Pos = testdata/arith-type.c:19:8
+// | |+Typ = 0
+// | `+Msg = 在 testdata/arith-type.c 文件的第19行8列: 类型说明符 unsigned 不能与 signed 组合使用
+// |-Error
+// | |+Pos = testdata/arith-type.c:20:11
+// | |+Typ = 0
+// | `+Msg = 在 testdata/arith-type.c 文件的第20行11列: 类型说明符中的 long 过多
+// |-Error
+// | |+Pos = testdata/arith-type.c:21:11
+// | |+Typ = 0
+// | `+Msg = 在 testdata/arith-type.c 文件的第21行11列: 类型说明符 double 不能与 long long 组合使用
+// |-Error
+// | |+Pos = testdata/arith-type.c:22:13
+// | |+Typ = 0
+// | `+Msg = 在 testdata/arith-type.c 文件的第22行13列: 类型说明符 long 不能与 long double 组合使用
+// |-Error
+// | |+Pos = testdata/arith-type.c:23:1
+// | |+Typ = 0
+// | `+Msg = 在 testdata/arith-type.c 文件的第23行1列: _Complex 需要与 float、double 或 long double 组合使用
+// |-Error
+// | |+Pos = testdata/arith-type.c:24:5
+// | |+Typ = 0
+// | `+Msg = 在 testdata/arith-type.c 文件的第24行5列: 类型说明符 _Complex 不能与 int 组合使用
+// |-Error
+// | |+Pos = testdata/arith-type.c:25:6
+// | |+Typ = 0
+// | `+Msg = 在 testdata/arith-type.c 文件的第25行6列: 重复的类型定义符号 char
+// `-Error
+//  |+Pos = testdata/arith-type.c:26:1
+//  |+Typ = 0
+//  `+Msg = 在 testdata/arith-type.c 文件的第26行1列: 不支持虚数类型 double _Imaginary
+// ===========================
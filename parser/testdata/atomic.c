@@ -0,0 +1,258 @@
+struct node {
+    int value;
+    _Atomic(struct node *) next;
+};
+
+typedef int pair[2];
+typedef int word;
+_Atomic(word) shared;
+word plain;
+
+_Atomic int counter;
+_Atomic(struct node *) head;
+int * _Atomic tail;
+_Thread_local int errno_value;
+static _Thread_local struct node *cache;
+extern _Thread_local unsigned int depth;
+
+_Atomic(int[4]) bad_array;
+_Atomic(const int) bad_const;
+_Atomic pair bad_pair;
+_Thread_local int next_id(void);
+typedef _Thread_local int tls_int;
+
+_Static_assert(sizeof(_Atomic(int)) == 4, "atomic int");
+
+int main(_Thread_local int argc) {
+    static _Thread_local int calls;
+    extern _Thread_local unsigned int depth;
+    _Thread_local int local;
+    auto _Thread_local int bad;
+    return counter + calls;
+}
+// ===========================
+// TranslationUnit
+//  `+Files = 
+//   `-File
+//    |+Name = testdata/atomic.c
+//    |+Decl = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  struct node
+//    | | |+Name = <nil>
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-TypedefDecl
+//    | | |+Typedef = testdata/atomic.c:6:1
+//    | | |+Type = _Atomic  int[2]
+//    | | |+Name = pair
+//    | | `+Attrs = 
+//    | |-TypedefDecl
+//    | | |+Typedef = testdata/atomic.c:7:1
+//    | | |+Type =  int
+//    | | |+Name = word
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type = _Atomic int
+//    | | |+Name = shared
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  int
+//    | | |+Name = plain
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type = _Atomic int
+//    | | |+Name = counter
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  struct node *_Atomic
+//    | | |+Name = head
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  int *_Atomic
+//    | | |+Name = tail
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[_Thread_local:testdata/atomic.c:14:1]
+//    | | |+Type =  int
+//    | | |+Name = errno_value
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[_Thread_local:testdata/atomic.c:15:8 static:testdata/atomic.c:15:1]
+//    | | |+Type =  struct node *
+//    | | |+Name = cache
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[_Thread_local:testdata/atomic.c:16:8 extern:testdata/atomic.c:16:1]
+//    | | |+Type =  unsigned int
+//    | | |+Name = depth
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =   int[4]
+//    | | |+Name = bad_array
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type = const int
+//    | | |+Name = bad_const
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type = _Atomic  int[2]
+//    | | |+Name = bad_pair
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-FuncDecl
+//    | | |+Qua = map[_Thread_local:testdata/atomic.c:21:1]
+//    | | |+Spec = map[]
+//    | | |+Name = next_id
+//    | | |+Type =  int ( void)
+//    | | |+Decl = 
+//    | | |+Body = <nil>
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |-TypedefDecl
+//    | | |+Typedef = testdata/atomic.c:22:1
+//    | | |+Type =  int
+//    | | |+Name = tls_int
+//    | | `+Attrs = 
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/atomic.c:24:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = SizeOfExpr
+//    | | |  | |+Range = Range
+//    | | |  | | |+Begin = testdata/atomic.c:24:16
+//    | | |  | | `+End = testdata/atomic.c:24:35
+//    | | |  | `+Type = _Atomic int
+//    | | |  |+Op = "=="<PUNCTUATOR@testdata/atomic.c:24:37>
+//    | | |  `+Y = 4
+//    | | |+Msg = "atomic int"
+//    | | `+Semicolon = testdata/atomic.c:24:56
+//    | `-FuncDecl
+//    |  |+Qua = map[]
+//    |  |+Spec = map[]
+//    |  |+Name = main
+//    |  |+Type =  int ( int)
+//    |  |+Decl = 
+//    |  |+Body = CompoundStmt
+//    |  | |+Lbrace = testdata/atomic.c:26:34
+//    |  | |+Stmts = 
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[_Thread_local:testdata/atomic.c:27:12 static:testdata/atomic.c:27:5]
+//    |  | | |  |+Type =  int
+//    |  | | |  |+Name = calls
+//    |  | | |  |+Init = <nil>
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[_Thread_local:testdata/atomic.c:28:12 extern:testdata/atomic.c:28:5]
+//    |  | | |  |+Type =  unsigned int
+//    |  | | |  |+Name = depth
+//    |  | | |  |+Init = <nil>
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[_Thread_local:testdata/atomic.c:29:5]
+//    |  | | |  |+Type =  int
+//    |  | | |  |+Name = local
+//    |  | | |  |+Init = <nil>
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[_Thread_local:testdata/atomic.c:30:10 auto:testdata/atomic.c:30:5]
+//    |  | | |  |+Type =  int
+//    |  | | |  |+Name = bad
+//    |  | | |  |+Init = <nil>
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | `-ReturnStmt
+//    |  | |  |+Return = testdata/atomic.c:31:5
+//    |  | |  |+X = BinaryExpr
+//    |  | |  | |+X = counter
+//    |  | |  | |+Op = "+"<PUNCTUATOR@testdata/atomic.c:31:20>
+//    |  | |  | `+Y = calls
+//    |  | |  `+Semicolon = testdata/atomic.c:31:27
+//    |  | `+Rbrace = testdata/atomic.c:32:1
+//    |  |+InlineDef = false
+//    |  `+Attrs = 
+//    `+Unresolved = 
+// ===========================
+//
+// |-Error
+// | |+Pos = testdata/atomic.c:18:1
+// | |+Typ = 0
+// | `+Msg = 在 testdata/atomic.c 文件的第18行1列: 不能对类型 int[4] 使用 _Atomic
+// |-Error
+// | |+Pos = testdata/atomic.c:19:1
+// | |+Typ = 0
+// | `+Msg = 在 testdata/atomic.c 文件的第19行1列: 不能对类型 const int 使用 _Atomic
+// |-Error
+// | |+Pos = testdata/atomic.c:20:1
+// | |+Typ = 0
+// | `+Msg = 在 testdata/atomic.c 文件的第20行1列: 不能对类型 int[2] 使用 _Atomic
+// |-Error
+// | |+Pos = testdata/atomic.c:21:1
+// | |+Typ = 0
+// | `+Msg = 在 testdata/atomic.c 文件的第21行1列: 这里不能使用存储类说明符 _Thread_local
+// |-Error
+// | |+Pos = testdata/atomic.c:22:1
+// | |+Typ = 0
+// | `+Msg = 在 testdata/atomic.c 文件的第22行1列: 存储类说明符 typedef 不能与 _Thread_local 同时使用
+// |-Error
+// | |+Pos = testdata/atomic.c:26:10
+// | |+Typ = 0
+// | `+Msg = 在 testdata/atomic.c 文件的第26行10列: 这里不能使用存储类说明符 _Thread_local
+// |-Error
+// | |+Pos = testdata/atomic.c:29:5
+// | |+Typ = 0
+// | `+Msg = 在 testdata/atomic.c 文件的第29行5列: 块作用域中的 _Thread_local 变量需要同时声明为 static 或 extern
+// |-Error
+// | |+Pos = testdata/atomic.c:30:5
+// | |+Typ = 0
+// | `+Msg = 在 testdata/atomic.c 文件的第30行5列: 存储类说明符 auto 不能与 _Thread_local 同时使用
+// |-Error
+// | |+Pos = testdata/atomic.c:29:23
+// | |+Typ = 1
+// | `+Msg = 在 testdata/atomic.c 文件的第29行23列: 变量 local 未使用
+// |-Error
+// | |+Pos = testdata/atomic.c:30:28
+// | |+Typ = 1
+// | `+Msg = 在 testdata/atomic.c 文件的第30行28列: 变量 bad 未使用
+// `-Error
+//  |+Pos = testdata/atomic.c:15:35
+//  |+Typ = 1
+//  `+Msg = 在 testdata/atomic.c 文件的第15行35列: 变量 cache 未使用
+// ===========================
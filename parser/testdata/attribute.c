@@ -0,0 +1,278 @@
+struct __attribute__((packed)) header {
+    char tag;
+    int len __attribute__((aligned(8)));
+    unsigned flags : 4 __attribute__((unused));
+} __attribute__((aligned(16)));
+
+enum __attribute__((packed)) mode {
+    MODE_OLD __attribute__((deprecated("use MODE_NEW"))) = 1,
+    MODE_NEW,
+};
+
+typedef int v4si __attribute__((vector_size(16)));
+extern int my_printf(const char *fmt, ...) __attribute__((format(printf, 1, 2), visibility("hidden")));
+__attribute__((noreturn)) void die(void);
+void stop(int code) __attribute__((__noreturn__, cold));
+static int counter __attribute__((used, section(".data.counter"))) = 0;
+
+int run(int n __attribute__((unused)), int m) {
+    int x __attribute__((cleanup(die))) = 0, y;
+    __attribute__((unused)) int z;
+    switch (m) {
+    case 1:
+        x++;
+        __attribute__((fallthrough));
+    case 2:
+        break;
+    }
+out: __attribute__((unused));
+    return x;
+}
+
+int bad __attribute__((aligned(1, 2), no_such_attr, format(printf)));
+// ===========================
+// TranslationUnit
+//  `+Files = 
+//   `-File
+//    |+Name = testdata/attribute.c
+//    |+Decl = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  struct header
+//    | | |+Name = <nil>
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  enum mode
+//    | | |+Name = <nil>
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-TypedefDecl
+//    | | |+Typedef = testdata/attribute.c:12:1
+//    | | |+Type =  int
+//    | | |+Name = v4si
+//    | | `+Attrs = 
+//    | |  `-Attribute
+//    | |   |+Prefix = <nil>
+//    | |   |+Name = "vector_size"<IDENT@testdata/attribute.c:12:33>
+//    | |   |+Args = 
+//    | |   | `-16
+//    | |   `+Std = false
+//    | |-FuncDecl
+//    | | |+Qua = map[extern:testdata/attribute.c:13:1]
+//    | | |+Spec = map[]
+//    | | |+Name = my_printf
+//    | | |+Type =  int (const char *,...)
+//    | | |+Decl = 
+//    | | |+Body = <nil>
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |  |-Attribute
+//    | |  | |+Prefix = <nil>
+//    | |  | |+Name = "format"<IDENT@testdata/attribute.c:13:59>
+//    | |  | |+Args = 
+//    | |  | | |-printf
+//    | |  | | |-1
+//    | |  | | `-2
+//    | |  | `+Std = false
+//    | |  `-Attribute
+//    | |   |+Prefix = <nil>
+//    | |   |+Name = "visibility"<IDENT@testdata/attribute.c:13:81>
+//    | |   |+Args = 
+//    | |   | `-"hidden"
+//    | |   `+Std = false
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[_Noreturn:testdata/attribute.c:14:16]
+//    | | |+Name = die
+//    | | |+Type =  void ( void)
+//    | | |+Decl = 
+//    | | |+Body = <nil>
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |  `-Attribute
+//    | |   |+Prefix = <nil>
+//    | |   |+Name = "noreturn"<IDENT@testdata/attribute.c:14:16>
+//    | |   |+Args = 
+//    | |   `+Std = false
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[_Noreturn:testdata/attribute.c:15:36]
+//    | | |+Name = stop
+//    | | |+Type =  void ( int)
+//    | | |+Decl = 
+//    | | |+Body = <nil>
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |  |-Attribute
+//    | |  | |+Prefix = <nil>
+//    | |  | |+Name = "__noreturn__"<IDENT@testdata/attribute.c:15:36>
+//    | |  | |+Args = 
+//    | |  | `+Std = false
+//    | |  `-Attribute
+//    | |   |+Prefix = <nil>
+//    | |   |+Name = "cold"<IDENT@testdata/attribute.c:15:50>
+//    | |   |+Args = 
+//    | |   `+Std = false
+//    | |-VarDecl
+//    | | |+Qua = map[static:testdata/attribute.c:16:1]
+//    | | |+Type =  int
+//    | | |+Name = counter
+//    | | |+Init = 0
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |  |-Attribute
+//    | |  | |+Prefix = <nil>
+//    | |  | |+Name = "used"<IDENT@testdata/attribute.c:16:35>
+//    | |  | |+Args = 
+//    | |  | `+Std = false
+//    | |  `-Attribute
+//    | |   |+Prefix = <nil>
+//    | |   |+Name = "section"<IDENT@testdata/attribute.c:16:41>
+//    | |   |+Args = 
+//    | |   | `-".data.counter"
+//    | |   `+Std = false
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[]
+//    | | |+Name = run
+//    | | |+Type =  int ( int, int)
+//    | | |+Decl = 
+//    | | |+Body = CompoundStmt
+//    | | | |+Lbrace = testdata/attribute.c:18:47
+//    | | | |+Stmts = 
+//    | | | | |-DeclStmt
+//    | | | | | |-VarDecl
+//    | | | | | | |+Qua = map[]
+//    | | | | | | |+Type =  int
+//    | | | | | | |+Name = x
+//    | | | | | | |+Init = 0
+//    | | | | | | |+Align = 
+//    | | | | | | `+Attrs = 
+//    | | | | | |  `-Attribute
+//    | | | | | |   |+Prefix = <nil>
+//    | | | | | |   |+Name = "cleanup"<IDENT@testdata/attribute.c:19:26>
+//    | | | | | |   |+Args = 
+//    | | | | | |   | `-die
+//    | | | | | |   `+Std = false
+//    | | | | | `-VarDecl
+//    | | | | |  |+Qua = map[]
+//    | | | | |  |+Type =  int
+//    | | | | |  |+Name = y
+//    | | | | |  |+Init = <nil>
+//    | | | | |  |+Align = 
+//    | | | | |  `+Attrs = 
+//    | | | | |-DeclStmt
+//    | | | | | `-VarDecl
+//    | | | | |  |+Qua = map[]
+//    | | | | |  |+Type =  int
+//    | | | | |  |+Name = z
+//    | | | | |  |+Init = <nil>
+//    | | | | |  |+Align = 
+//    | | | | |  `+Attrs = 
+//    | | | | |   `-Attribute
+//    | | | | |    |+Prefix = <nil>
+//    | | | | |    |+Name = "unused"<IDENT@testdata/attribute.c:20:20>
+//    | | | | |    |+Args = 
+//    | | | | |    `+Std = false
+//    | | | | |-SwitchStmt
+//    | | | | | |+Switch = testdata/attribute.c:21:5
+//    | | | | | |+X = m
+//    | | | | | `+Stmt = CompoundStmt
+//    | | | | |  |+Lbrace = testdata/attribute.c:21:16
+//    | | | | |  |+Stmts = 
+//    | | | | |  | |-CaseStmt
+//    | | | | |  | | |+Case = testdata/attribute.c:22:5
+//    | | | | |  | | |+Expr = ConstantExpr
+//    | | | | |  | | | `+X = 1
+//    | | | | |  | | `+Stmt = ExprStmt
+//    | | | | |  | |  |+Expr = UnaryExpr
+//    | | | | |  | |  | |+Op = "++"<PUNCTUATOR@testdata/attribute.c:23:10>
+//    | | | | |  | |  | `+X = x
+//    | | | | |  | |  `+Semicolon = testdata/attribute.c:23:12
+//    | | | | |  | |-AttributedStmt
+//    | | | | |  | | |+Attrs = 
+//    | | | | |  | | | `-Attribute
+//    | | | | |  | | |  |+Prefix = <nil>
+//    | | | | |  | | |  |+Name = "fallthrough"<IDENT@testdata/attribute.c:24:24>
+//    | | | | |  | | |  |+Args = 
+//    | | | | |  | | |  `+Std = false
+//    | | | | |  | | `+Stmt = EmptyStmt
+//    | | | | |  | |  `+Semicolon = testdata/attribute.c:24:37
+//    | | | | |  | `-CaseStmt
+//    | | | | |  |  |+Case = testdata/attribute.c:25:5
+//    | | | | |  |  |+Expr = ConstantExpr
+//    | | | | |  |  | `+X = 2
+//    | | | | |  |  `+Stmt = BreakStmt
+//    | | | | |  |   |+Break = testdata/attribute.c:26:9
+//    | | | | |  |   `+Semicolon = testdata/attribute.c:26:14
+//    | | | | |  `+Rbrace = testdata/attribute.c:27:5
+//    | | | | |-LabelStmt
+//    | | | | | |+Id = out
+//    | | | | | |+Attrs = 
+//    | | | | | | `-Attribute
+//    | | | | | |  |+Prefix = <nil>
+//    | | | | | |  |+Name = "unused"<IDENT@testdata/attribute.c:28:21>
+//    | | | | | |  |+Args = 
+//    | | | | | |  `+Std = false
+//    | | | | | `+Stmt = EmptyStmt
+//    | | | | |  `+Semicolon = testdata/attribute.c:28:29
+//    | | | | `-ReturnStmt
+//    | | | |  |+Return = testdata/attribute.c:29:5
+//    | | | |  |+X = x
+//    | | | |  `+Semicolon = testdata/attribute.c:29:13
+//    | | | `+Rbrace = testdata/attribute.c:30:1
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | `-VarDecl
+//    |  |+Qua = map[]
+//    |  |+Type =  int
+//    |  |+Name = bad
+//    |  |+Init = <nil>
+//    |  |+Align = 
+//    |  `+Attrs = 
+//    |   |-Attribute
+//    |   | |+Prefix = <nil>
+//    |   | |+Name = "aligned"<IDENT@testdata/attribute.c:32:24>
+//    |   | |+Args = 
+//    |   | | |-1
+//    |   | | `-2
+//    |   | `+Std = false
+//    |   |-Attribute
+//    |   | |+Prefix = <nil>
+//    |   | |+Name = "no_such_attr"<IDENT@testdata/attribute.c:32:39>
+//    |   | |+Args = 
+//    |   | `+Std = false
+//    |   `-Attribute
+//    |    |+Prefix = <nil>
+//    |    |+Name = "format"<IDENT@testdata/attribute.c:32:53>
+//    |    |+Args = 
+//    |    | `-printf
+//    |    `+Std = false
+//    `+Unresolved = 
+// ===========================
+//
+// |-Error
+// | |+Pos = testdata/attribute.c:19:46
+// | |+Typ = 1
+// | `+Msg = 在 testdata/attribute.c 文件的第19行46列: 变量 y 未使用
+// |-Error
+// | |+Pos = testdata/attribute.c:32:24
+// | |+Typ = 0
+// | `+Msg = 在 testdata/attribute.c 文件的第32行24列: 属性 aligned 的参数数量应为 0 到 1，使用了 2 个参数
+// |-Error
+// | |+Pos = testdata/attribute.c:32:39
+// | |+Typ = 1
+// | `+Msg = 在 testdata/attribute.c 文件的第32行39列: 未知的属性 no_such_attr，已忽略
+// |-Error
+// | |+Pos = testdata/attribute.c:32:53
+// | |+Typ = 0
+// | `+Msg = 在 testdata/attribute.c 文件的第32行53列: 属性 format 的参数数量应为 3，使用了 1 个参数
+// `-Error
+//  |+Pos = testdata/attribute.c:16:12
+//  |+Typ = 1
+//  `+Msg = 在 testdata/attribute.c 文件的第16行12列: 变量 counter 未使用
+// ===========================
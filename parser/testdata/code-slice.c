@@ -13,46 +13,54 @@ int main() {
 //   | |+Name = testdata/printf.h
 //   | |+Decl = 
 //   | | `-FuncDecl
+//   | |  |+Qua = map[]
+//   | |  |+Spec = map[]
 //   | |  |+Name = printf
 //   | |  |+Type =  int (const char *,...)
 //   | |  |+Decl = 
-//   | |  `+Body = <nil>
+//   | |  |+Body = <nil>
+//   | |  |+InlineDef = false
+//   | |  `+Attrs = 
 //   | `+Unresolved = 
 //   `-File
-//    |+Name = testdata\code-slice.c
+//    |+Name = testdata/code-slice.c
 //    |+Decl = 
 //    | `-FuncDecl
+//    |  |+Qua = map[]
+//    |  |+Spec = map[]
 //    |  |+Name = main
 //    |  |+Type =  int ()
 //    |  |+Decl = 
-//    |  `+Body = CompoundStmt
-//    |   |+Lbrace = testdata\code-slice.c:3:12
-//    |   |+Stmts = 
-//    |   | |-ExprStmt
-//    |   | | |+Expr = CallExpr
-//    |   | | | |+Func = printf
-//    |   | | | |+Lparen = testdata/code-slice.h:1:7
-//    |   | | | |+Args = 
-//    |   | | | | `-"code"
-//    |   | | | `+Rparen = testdata/code-slice.h:1:14
-//    |   | | `+Semicolon = testdata/code-slice.h:1:15
-//    |   | |-ExprStmt
-//    |   | | |+Expr = CallExpr
-//    |   | | | |+Func = printf
-//    |   | | | |+Lparen = testdata/code-slice.h:1:7
-//    |   | | | |+Args = 
-//    |   | | | | `-"code"
-//    |   | | | `+Rparen = testdata/code-slice.h:1:14
-//    |   | | `+Semicolon = testdata/code-slice.h:1:15
-//    |   | `-ExprStmt
-//    |   |  |+Expr = CallExpr
-//    |   |  | |+Func = printf
-//    |   |  | |+Lparen = testdata/code-slice.h:1:7
-//    |   |  | |+Args = 
-//    |   |  | | `-"code"
-//    |   |  | `+Rparen = testdata/code-slice.h:1:14
-//    |   |  `+Semicolon = testdata/code-slice.h:1:15
-//    |   `+Rbrace = testdata\code-slice.c:7:1
+//    |  |+Body = CompoundStmt
+//    |  | |+Lbrace = testdata/code-slice.c:3:12
+//    |  | |+Stmts = 
+//    |  | | |-ExprStmt
+//    |  | | | |+Expr = CallExpr
+//    |  | | | | |+Func = printf
+//    |  | | | | |+Lparen = testdata/code-slice.h:1:7
+//    |  | | | | |+Args = 
+//    |  | | | | | `-"code"
+//    |  | | | | `+Rparen = testdata/code-slice.h:1:14
+//    |  | | | `+Semicolon = testdata/code-slice.h:1:15
+//    |  | | |-ExprStmt
+//    |  | | | |+Expr = CallExpr
+//    |  | | | | |+Func = printf
+//    |  | | | | |+Lparen = testdata/code-slice.h:1:7
+//    |  | | | | |+Args = 
+//    |  | | | | | `-"code"
+//    |  | | | | `+Rparen = testdata/code-slice.h:1:14
+//    |  | | | `+Semicolon = testdata/code-slice.h:1:15
+//    |  | | `-ExprStmt
+//    |  | |  |+Expr = CallExpr
+//    |  | |  | |+Func = printf
+//    |  | |  | |+Lparen = testdata/code-slice.h:1:7
+//    |  | |  | |+Args = 
+//    |  | |  | | `-"code"
+//    |  | |  | `+Rparen = testdata/code-slice.h:1:14
+//    |  | |  `+Semicolon = testdata/code-slice.h:1:15
+//    |  | `+Rbrace = testdata/code-slice.c:7:1
+//    |  |+InlineDef = false
+//    |  `+Attrs = 
 //    `+Unresolved = 
 // ===========================
 //
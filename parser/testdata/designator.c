@@ -7,49 +7,60 @@ int main() {
 // TranslationUnit
 //  `+Files = 
 //   `-File
-//    |+Name = testdata\designator.c
+//    |+Name = testdata/designator.c
 //    |+Decl = 
 //    | `-FuncDecl
+//    |  |+Qua = map[]
+//    |  |+Spec = map[]
 //    |  |+Name = main
 //    |  |+Type =  int ()
 //    |  |+Decl = 
-//    |  `+Body = CompoundStmt
-//    |   |+Lbrace = testdata\designator.c:1:12
-//    |   |+Stmts = 
-//    |   | |-DeclStmt
-//    |   | | `-VarDecl
-//    |   | |  |+Type =  int
-//    |   | |  |+Name = a
-//    |   | |  `+Init = InitializerExpr
-//    |   | |   |+Lbrace = testdata\designator.c:2:13
-//    |   | |   |+List = 
-//    |   | |   | |-RecordDesignatorExpr
-//    |   | |   | | |+Dot = testdata\designator.c:2:14
-//    |   | |   | | |+Field = xx
-//    |   | |   | | `+X = 10
-//    |   | |   | |-ArrayDesignatorExpr
-//    |   | |   | | |+Lbrack = testdata\designator.c:2:24
-//    |   | |   | | |+Index = ConstantExpr
-//    |   | |   | | | `+X = 21
-//    |   | |   | | |+Rbrack = testdata\designator.c:2:27
-//    |   | |   | | `+X = 13
-//    |   | |   | `-RecordDesignatorExpr
-//    |   | |   |  |+Dot = testdata\designator.c:2:35
-//    |   | |   |  |+Field = x
-//    |   | |   |  `+X = RecordDesignatorExpr
-//    |   | |   |   |+Dot = testdata\designator.c:2:37
-//    |   | |   |   |+Field = y
-//    |   | |   |   `+X = RecordDesignatorExpr
-//    |   | |   |    |+Dot = testdata\designator.c:2:39
-//    |   | |   |    |+Field = z
-//    |   | |   |    `+X = 10
-//    |   | |   `+Rbrace = testdata\designator.c:2:46
-//    |   | `-ReturnStmt
-//    |   |  |+Return = testdata\designator.c:3:5
-//    |   |  |+X = 0
-//    |   |  `+Semicolon = testdata\designator.c:3:13
-//    |   `+Rbrace = testdata\designator.c:4:1
+//    |  |+Body = CompoundStmt
+//    |  | |+Lbrace = testdata/designator.c:1:12
+//    |  | |+Stmts = 
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[]
+//    |  | | |  |+Type =  int
+//    |  | | |  |+Name = a
+//    |  | | |  |+Init = InitializerExpr
+//    |  | | |  | |+Lbrace = testdata/designator.c:2:13
+//    |  | | |  | |+List = 
+//    |  | | |  | | |-RecordDesignatorExpr
+//    |  | | |  | | | |+Dot = testdata/designator.c:2:14
+//    |  | | |  | | | |+Field = xx
+//    |  | | |  | | | `+X = 10
+//    |  | | |  | | |-ArrayDesignatorExpr
+//    |  | | |  | | | |+Lbrack = testdata/designator.c:2:24
+//    |  | | |  | | | |+Index = ConstantExpr
+//    |  | | |  | | | | `+X = 21
+//    |  | | |  | | | |+Rbrack = testdata/designator.c:2:27
+//    |  | | |  | | | `+X = 13
+//    |  | | |  | | `-RecordDesignatorExpr
+//    |  | | |  | |  |+Dot = testdata/designator.c:2:35
+//    |  | | |  | |  |+Field = x
+//    |  | | |  | |  `+X = RecordDesignatorExpr
+//    |  | | |  | |   |+Dot = testdata/designator.c:2:37
+//    |  | | |  | |   |+Field = y
+//    |  | | |  | |   `+X = RecordDesignatorExpr
+//    |  | | |  | |    |+Dot = testdata/designator.c:2:39
+//    |  | | |  | |    |+Field = z
+//    |  | | |  | |    `+X = 10
+//    |  | | |  | `+Rbrace = testdata/designator.c:2:46
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | `-ReturnStmt
+//    |  | |  |+Return = testdata/designator.c:3:5
+//    |  | |  |+X = 0
+//    |  | |  `+Semicolon = testdata/designator.c:3:13
+//    |  | `+Rbrace = testdata/designator.c:4:1
+//    |  |+InlineDef = false
+//    |  `+Attrs = 
 //    `+Unresolved = 
 // ===========================
 //
+// `-Error
+//  |+Pos = testdata/designator.c:2:9
+//  |+Typ = 1
+//  `+Msg = 在 testdata/designator.c 文件的第2行9列: 变量 a 未使用
 // ===========================
@@ -15,40 +15,66 @@ int main() {
 // TranslationUnit
 //  `+Files = 
 //   `-File
-//    |+Name = testdata\enum-error-conflict.c
+//    |+Name = testdata/enum-error-conflict.c
 //    |+Decl = 
 //    | |-TypedefDecl
-//    | | |+Typedef = testdata\enum-error-conflict.c:1:1
+//    | | |+Typedef = testdata/enum-error-conflict.c:1:1
 //    | | |+Type =  enum Color
-//    | | `+Name = color_t
+//    | | |+Name = color_t
+//    | | `+Attrs = 
 //    | `-FuncDecl
+//    |  |+Qua = map[]
+//    |  |+Spec = map[]
 //    |  |+Name = main
 //    |  |+Type =  int ()
 //    |  |+Decl = 
-//    |  `+Body = CompoundStmt
-//    |   |+Lbrace = testdata\enum-error-conflict.c:8:12
-//    |   |+Stmts = 
-//    |   | |-DeclStmt
-//    |   | | `-VarDecl
-//    |   | |  |+Type =  enum Color
-//    |   | |  |+Name = color1
-//    |   | |  `+Init = <nil>
-//    |   | |-DeclStmt
-//    |   | | `-VarDecl
-//    |   | |  |+Type =  int
-//    |   | |  |+Name = a
-//    |   | |  `+Init = YELLOW
-//    |   | `-DeclStmt
-//    |   |  `-VarDecl
-//    |   |   |+Type =  int
-//    |   |   |+Name = YELLOW
-//    |   |   `+Init = 10
-//    |   `+Rbrace = testdata\enum-error-conflict.c:12:1
+//    |  |+Body = CompoundStmt
+//    |  | |+Lbrace = testdata/enum-error-conflict.c:8:12
+//    |  | |+Stmts = 
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[]
+//    |  | | |  |+Type =  enum Color
+//    |  | | |  |+Name = color1
+//    |  | | |  |+Init = <nil>
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[]
+//    |  | | |  |+Type =  int
+//    |  | | |  |+Name = a
+//    |  | | |  |+Init = YELLOW
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | `-DeclStmt
+//    |  | |  `-VarDecl
+//    |  | |   |+Qua = map[]
+//    |  | |   |+Type =  int
+//    |  | |   |+Name = YELLOW
+//    |  | |   |+Init = 10
+//    |  | |   |+Align = 
+//    |  | |   `+Attrs = 
+//    |  | `+Rbrace = testdata/enum-error-conflict.c:12:1
+//    |  |+InlineDef = false
+//    |  `+Attrs = 
 //    `+Unresolved = 
 // ===========================
 //
+// |-Error
+// | |+Pos = testdata/enum-error-conflict.c:5:5
+// | |+Typ = 0
+// | `+Msg = 在 testdata/enum-error-conflict.c 文件的第5行5列: 重复的标识符 YELLOW，上次声明的位置 testdata/enum-error-conflict.c:4:5
+// |-Error
+// | |+Pos = testdata/enum-error-conflict.c:9:13
+// | |+Typ = 1
+// | `+Msg = 在 testdata/enum-error-conflict.c 文件的第9行13列: 变量 color1 未使用
+// |-Error
+// | |+Pos = testdata/enum-error-conflict.c:10:9
+// | |+Typ = 1
+// | `+Msg = 在 testdata/enum-error-conflict.c 文件的第10行9列: 变量 a 未使用
 // `-Error
-//  |+Pos = testdata\enum-error-conflict.c:5:5
-//  |+Typ = 0
-//  `+Msg = 在 testdata\enum-error-conflict.c 文件的第5行5列: 重复的标识符 YELLOW，上次声明的位置 testdata\enum-error-conflict.c:4:5
+//  |+Pos = testdata/enum-error-conflict.c:11:9
+//  |+Typ = 1
+//  `+Msg = 在 testdata/enum-error-conflict.c 文件的第11行9列: 变量 YELLOW 未使用
 // ===========================
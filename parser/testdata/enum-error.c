@@ -17,96 +17,109 @@ int main() {
 // TranslationUnit
 //  `+Files = 
 //   `-File
-//    |+Name = testdata\enum-error.c
+//    |+Name = testdata/enum-error.c
 //    |+Decl = 
 //    | |-TypedefDecl
-//    | | |+Typedef = testdata\enum-error.c:1:1
+//    | | |+Typedef = testdata/enum-error.c:1:1
 //    | | |+Type =  enum Color
-//    | | `+Name = color_t
+//    | | |+Name = color_t
+//    | | `+Attrs = 
 //    | `-FuncDecl
+//    |  |+Qua = map[]
+//    |  |+Spec = map[]
 //    |  |+Name = main
 //    |  |+Type =  int ()
 //    |  |+Decl = 
-//    |  `+Body = CompoundStmt
-//    |   |+Lbrace = testdata\enum-error.c:7:12
-//    |   |+Stmts = 
-//    |   | |-DeclStmt
-//    |   | | `-VarDecl
-//    |   | |  |+Type =  enum Color
-//    |   | |  |+Name = color1
-//    |   | |  `+Init = <nil>
-//    |   | |-DeclStmt
-//    |   | | `-VarDecl
-//    |   | |  |+Type =  int
-//    |   | |  |+Name = color1
-//    |   | |  `+Init = <nil>
-//    |   | |-DeclStmt
-//    |   | | `-VarDecl
-//    |   | |  |+Type =  char
-//    |   | |  |+Name = color2
-//    |   | |  `+Init = <nil>
-//    |   | |-DeclStmt
-//    |   | | `-VarDecl
-//    |   | |  |+Type =  UnknownType
-//    |   | |  |+Name = Color
-//    |   | |  `+Init = <nil>
-//    |   | |-ExprStmt
-//    |   | | |+Expr = color2
-//    |   | | `+Semicolon = testdata\enum-error.c:11:27
-//    |   | |-DeclStmt
-//    |   | | `-VarDecl
-//    |   | |  |+Type =  int
-//    |   | |  |+Name = Color
-//    |   | |  `+Init = <nil>
-//    |   | |-ExprStmt
-//    |   | | |+Expr = color2
-//    |   | | `+Semicolon = testdata\enum-error.c:12:27
-//    |   | |-DeclStmt
-//    |   | | `-VarDecl
-//    |   | |  |+Type = const int
-//    |   | |  |+Name = Color
-//    |   | |  `+Init = <nil>
-//    |   | `-ExprStmt
-//    |   |  |+Expr = color3
-//    |   |  `+Semicolon = testdata\enum-error.c:13:33
-//    |   `+Rbrace = testdata\enum-error.c:14:1
+//    |  |+Body = CompoundStmt
+//    |  | |+Lbrace = testdata/enum-error.c:7:12
+//    |  | |+Stmts = 
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[]
+//    |  | | |  |+Type =  enum Color
+//    |  | | |  |+Name = color1
+//    |  | | |  |+Init = <nil>
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[]
+//    |  | | |  |+Type =  int
+//    |  | | |  |+Name = color1
+//    |  | | |  |+Init = <nil>
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[]
+//    |  | | |  |+Type =  char
+//    |  | | |  |+Name = color2
+//    |  | | |  |+Init = <nil>
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[]
+//    |  | | |  |+Type =  long
+//    |  | | |  |+Name = Color
+//    |  | | |  |+Init = <nil>
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | |-ExprStmt
+//    |  | | | |+Expr = color2
+//    |  | | | `+Semicolon = testdata/enum-error.c:11:27
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[]
+//    |  | | |  |+Type =  long
+//    |  | | |  |+Name = color2
+//    |  | | |  |+Init = <nil>
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | `-DeclStmt
+//    |  | |  `-VarDecl
+//    |  | |   |+Qua = map[]
+//    |  | |   |+Type = const long
+//    |  | |   |+Name = color3
+//    |  | |   |+Init = <nil>
+//    |  | |   |+Align = 
+//    |  | |   `+Attrs = 
+//    |  | `+Rbrace = testdata/enum-error.c:14:1
+//    |  |+InlineDef = false
+//    |  `+Attrs = 
 //    `+Unresolved = 
 // ===========================
 //
 // |-Error
-// | |+Pos = testdata\enum-error.c:9:9
+// | |+Pos = testdata/enum-error.c:9:9
 // | |+Typ = 0
-// | `+Msg = 在 testdata\enum-error.c 文件的第9行9列: 重复声明的变量名 color1，上次声明的位置 testdata\enum-error.c:8:13
+// | `+Msg = 在 testdata/enum-error.c 文件的第9行9列: 重复声明的变量名 color1，上次声明的位置 testdata/enum-error.c:8:13
 // |-Error
-// | |+Pos = testdata\enum-error.c:11:21
+// | |+Pos = testdata/enum-error.c:11:21
 // | |+Typ = 0
-// | `+Msg = 在 testdata\enum-error.c 文件的第11行21列: 这里应该是一个 ; ，不应该出现 color2
+// | `+Msg = 在 testdata/enum-error.c 文件的第11行21列: 这里应该是一个 ; ，不应该出现 color2
 // |-Error
-// | |+Pos = testdata\enum-error.c:12:10
+// | |+Pos = testdata/enum-error.c:12:10
 // | |+Typ = 0
-// | `+Msg = 在 testdata\enum-error.c 文件的第12行10列: 非预期的类型定义符号 enum
+// | `+Msg = 在 testdata/enum-error.c 文件的第12行10列: 非预期的类型定义符号 enum
 // |-Error
-// | |+Pos = testdata\enum-error.c:12:15
+// | |+Pos = testdata/enum-error.c:12:21
 // | |+Typ = 0
-// | `+Msg = 在 testdata\enum-error.c 文件的第12行15列: 重复声明的变量名 Color，上次声明的位置 testdata\enum-error.c:11:15
+// | `+Msg = 在 testdata/enum-error.c 文件的第12行21列: 重复声明的变量名 color2，上次声明的位置 testdata/enum-error.c:10:10
 // |-Error
-// | |+Pos = testdata\enum-error.c:12:21
+// | |+Pos = testdata/enum-error.c:13:16
 // | |+Typ = 0
-// | `+Msg = 在 testdata\enum-error.c 文件的第12行21列: 这里应该是一个 ; ，不应该出现 color2
+// | `+Msg = 在 testdata/enum-error.c 文件的第13行16列: 非预期的类型定义符号 enum
 // |-Error
-// | |+Pos = testdata\enum-error.c:13:16
-// | |+Typ = 0
-// | `+Msg = 在 testdata\enum-error.c 文件的第13行16列: 非预期的类型定义符号 enum
-// |-Error
-// | |+Pos = testdata\enum-error.c:13:21
-// | |+Typ = 0
-// | `+Msg = 在 testdata\enum-error.c 文件的第13行21列: 重复声明的变量名 Color，上次声明的位置 testdata\enum-error.c:11:15
+// | |+Pos = testdata/enum-error.c:8:13
+// | |+Typ = 1
+// | `+Msg = 在 testdata/enum-error.c 文件的第8行13列: 变量 color1 未使用
 // |-Error
-// | |+Pos = testdata\enum-error.c:13:27
-// | |+Typ = 0
-// | `+Msg = 在 testdata\enum-error.c 文件的第13行27列: 这里应该是一个 ; ，不应该出现 color3
+// | |+Pos = testdata/enum-error.c:11:15
+// | |+Typ = 1
+// | `+Msg = 在 testdata/enum-error.c 文件的第11行15列: 变量 Color 未使用
 // `-Error
-//  |+Pos = testdata\enum-error.c:13:27
-//  |+Typ = 0
-//  `+Msg = 在 testdata\enum-error.c 文件的第13行27列: 未定义的标识符 color3
+//  |+Pos = testdata/enum-error.c:13:27
+//  |+Typ = 1
+//  `+Msg = 在 testdata/enum-error.c 文件的第13行27列: 变量 color3 未使用
 // ===========================
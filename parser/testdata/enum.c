@@ -13,31 +13,50 @@ int main() {
 // TranslationUnit
 //  `+Files = 
 //   `-File
-//    |+Name = testdata\enum.c
+//    |+Name = testdata/enum.c
 //    |+Decl = 
 //    | |-TypedefDecl
-//    | | |+Typedef = testdata\enum.c:1:1
+//    | | |+Typedef = testdata/enum.c:1:1
 //    | | |+Type =  enum Color
-//    | | `+Name = color_t
+//    | | |+Name = color_t
+//    | | `+Attrs = 
 //    | `-FuncDecl
+//    |  |+Qua = map[]
+//    |  |+Spec = map[]
 //    |  |+Name = main
 //    |  |+Type =  int ()
 //    |  |+Decl = 
-//    |  `+Body = CompoundStmt
-//    |   |+Lbrace = testdata\enum.c:7:12
-//    |   |+Stmts = 
-//    |   | |-DeclStmt
-//    |   | | `-VarDecl
-//    |   | |  |+Type =  enum Color
-//    |   | |  |+Name = color1
-//    |   | |  `+Init = <nil>
-//    |   | `-DeclStmt
-//    |   |  `-VarDecl
-//    |   |   |+Type =  enum Color
-//    |   |   |+Name = color2
-//    |   |   `+Init = <nil>
-//    |   `+Rbrace = testdata\enum.c:10:1
+//    |  |+Body = CompoundStmt
+//    |  | |+Lbrace = testdata/enum.c:7:12
+//    |  | |+Stmts = 
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[]
+//    |  | | |  |+Type =  enum Color
+//    |  | | |  |+Name = color1
+//    |  | | |  |+Init = <nil>
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | `-DeclStmt
+//    |  | |  `-VarDecl
+//    |  | |   |+Qua = map[]
+//    |  | |   |+Type =  enum Color
+//    |  | |   |+Name = color2
+//    |  | |   |+Init = <nil>
+//    |  | |   |+Align = 
+//    |  | |   `+Attrs = 
+//    |  | `+Rbrace = testdata/enum.c:10:1
+//    |  |+InlineDef = false
+//    |  `+Attrs = 
 //    `+Unresolved = 
 // ===========================
 //
+// |-Error
+// | |+Pos = testdata/enum.c:8:13
+// | |+Typ = 1
+// | `+Msg = 在 testdata/enum.c 文件的第8行13列: 变量 color1 未使用
+// `-Error
+//  |+Pos = testdata/enum.c:9:16
+//  |+Typ = 1
+//  `+Msg = 在 testdata/enum.c 文件的第9行16列: 变量 color2 未使用
 // ===========================
@@ -0,0 +1,643 @@
+_Noreturn void abort(void);
+[[noreturn]] void fatal(const char *msg);
+static int hidden;
+static int helper(void);
+
+_Noreturn void die(int code) {
+    if (code) {
+        abort();
+    } else {
+        fatal("die");
+    }
+}
+
+_Noreturn void spin(void) {
+    for (;;) {
+    }
+}
+
+_Noreturn void quit(int code) {
+    if (code)
+        return;
+    while (1) {
+        if (code)
+            break;
+    }
+}
+
+_Noreturn void stop(int x) {
+    switch (x) {
+    default:
+        for (;;);
+    }
+}
+
+_Noreturn void fail(int x) {
+    switch (x) {
+    case 0:
+        abort();
+    default:
+        fatal("fail");
+    }
+}
+
+_Noreturn void maybe(int x) {
+    switch (x) {
+    case 0:
+        abort();
+    }
+}
+
+_Noreturn void leave(int x) {
+    switch (x) {
+    default:
+        if (x)
+            break;
+        abort();
+    }
+}
+
+_Noreturn void idle(void) {
+    while (0x0) {
+    }
+}
+
+_Noreturn void loop(void) {
+    while (0x10 - 1) {
+    }
+}
+
+inline int square(int x) {
+    static int calls;
+    static const int one = 1;
+    return x * x * one + hidden + helper();
+}
+
+inline int cube(int x);
+extern int cube(int x);
+inline int cube(int x) {
+    return x * x * x;
+}
+
+static inline int twice(int x) {
+    static int calls;
+    return x + x + hidden;
+}
+
+inline int missing(int x);
+inline int counter;
+_Noreturn typedef int no_type;
+
+int main(inline int argc) {
+    return square(argc) + cube(argc) + twice(argc);
+}
+// ===========================
+// TranslationUnit
+//  `+Files = 
+//   `-File
+//    |+Name = testdata/funcspec.c
+//    |+Decl = 
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[_Noreturn:testdata/funcspec.c:1:1]
+//    | | |+Name = abort
+//    | | |+Type =  void ( void)
+//    | | |+Decl = 
+//    | | |+Body = <nil>
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[_Noreturn:testdata/funcspec.c:2:3]
+//    | | |+Name = fatal
+//    | | |+Type =  void (const char *)
+//    | | |+Decl = 
+//    | | |+Body = <nil>
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |  `-Attribute
+//    | |   |+Prefix = <nil>
+//    | |   |+Name = "noreturn"<IDENT@testdata/funcspec.c:2:3>
+//    | |   |+Args = 
+//    | |   `+Std = true
+//    | |-VarDecl
+//    | | |+Qua = map[static:testdata/funcspec.c:3:1]
+//    | | |+Type =  int
+//    | | |+Name = hidden
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-FuncDecl
+//    | | |+Qua = map[static:testdata/funcspec.c:4:1]
+//    | | |+Spec = map[]
+//    | | |+Name = helper
+//    | | |+Type =  int ( void)
+//    | | |+Decl = 
+//    | | |+Body = <nil>
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[_Noreturn:testdata/funcspec.c:6:1]
+//    | | |+Name = die
+//    | | |+Type =  void ( int)
+//    | | |+Decl = 
+//    | | |+Body = CompoundStmt
+//    | | | |+Lbrace = testdata/funcspec.c:6:30
+//    | | | |+Stmts = 
+//    | | | | `-IfStmt
+//    | | | |  |+If = testdata/funcspec.c:7:5
+//    | | | |  |+X = code
+//    | | | |  |+Then = CompoundStmt
+//    | | | |  | |+Lbrace = testdata/funcspec.c:7:15
+//    | | | |  | |+Stmts = 
+//    | | | |  | | `-ExprStmt
+//    | | | |  | |  |+Expr = CallExpr
+//    | | | |  | |  | |+Func = abort
+//    | | | |  | |  | |+Lparen = testdata/funcspec.c:8:14
+//    | | | |  | |  | |+Args = 
+//    | | | |  | |  | `+Rparen = testdata/funcspec.c:8:15
+//    | | | |  | |  `+Semicolon = testdata/funcspec.c:8:16
+//    | | | |  | `+Rbrace = testdata/funcspec.c:9:5
+//    | | | |  `+Else = CompoundStmt
+//    | | | |   |+Lbrace = testdata/funcspec.c:9:12
+//    | | | |   |+Stmts = 
+//    | | | |   | `-ExprStmt
+//    | | | |   |  |+Expr = CallExpr
+//    | | | |   |  | |+Func = fatal
+//    | | | |   |  | |+Lparen = testdata/funcspec.c:10:14
+//    | | | |   |  | |+Args = 
+//    | | | |   |  | | `-"die"
+//    | | | |   |  | `+Rparen = testdata/funcspec.c:10:20
+//    | | | |   |  `+Semicolon = testdata/funcspec.c:10:21
+//    | | | |   `+Rbrace = testdata/funcspec.c:11:5
+//    | | | `+Rbrace = testdata/funcspec.c:12:1
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[_Noreturn:testdata/funcspec.c:14:1]
+//    | | |+Name = spin
+//    | | |+Type =  void ( void)
+//    | | |+Decl = 
+//    | | |+Body = CompoundStmt
+//    | | | |+Lbrace = testdata/funcspec.c:14:27
+//    | | | |+Stmts = 
+//    | | | | `-ForStmt
+//    | | | |  |+For = testdata/funcspec.c:15:5
+//    | | | |  |+Init = <nil>
+//    | | | |  |+Decl = <nil>
+//    | | | |  |+Cond = <nil>
+//    | | | |  |+Post = <nil>
+//    | | | |  `+Stmt = CompoundStmt
+//    | | | |   |+Lbrace = testdata/funcspec.c:15:14
+//    | | | |   |+Stmts = 
+//    | | | |   `+Rbrace = testdata/funcspec.c:16:5
+//    | | | `+Rbrace = testdata/funcspec.c:17:1
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[_Noreturn:testdata/funcspec.c:19:1]
+//    | | |+Name = quit
+//    | | |+Type =  void ( int)
+//    | | |+Decl = 
+//    | | |+Body = CompoundStmt
+//    | | | |+Lbrace = testdata/funcspec.c:19:31
+//    | | | |+Stmts = 
+//    | | | | |-IfStmt
+//    | | | | | |+If = testdata/funcspec.c:20:5
+//    | | | | | |+X = code
+//    | | | | | |+Then = ReturnStmt
+//    | | | | | | |+Return = testdata/funcspec.c:21:9
+//    | | | | | | |+X = <nil>
+//    | | | | | | `+Semicolon = testdata/funcspec.c:21:15
+//    | | | | | `+Else = <nil>
+//    | | | | `-WhileStmt
+//    | | | |  |+While = testdata/funcspec.c:22:5
+//    | | | |  |+X = 1
+//    | | | |  `+Stmt = CompoundStmt
+//    | | | |   |+Lbrace = testdata/funcspec.c:22:15
+//    | | | |   |+Stmts = 
+//    | | | |   | `-IfStmt
+//    | | | |   |  |+If = testdata/funcspec.c:23:9
+//    | | | |   |  |+X = code
+//    | | | |   |  |+Then = BreakStmt
+//    | | | |   |  | |+Break = testdata/funcspec.c:24:13
+//    | | | |   |  | `+Semicolon = testdata/funcspec.c:24:18
+//    | | | |   |  `+Else = <nil>
+//    | | | |   `+Rbrace = testdata/funcspec.c:25:5
+//    | | | `+Rbrace = testdata/funcspec.c:26:1
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[_Noreturn:testdata/funcspec.c:28:1]
+//    | | |+Name = stop
+//    | | |+Type =  void ( int)
+//    | | |+Decl = 
+//    | | |+Body = CompoundStmt
+//    | | | |+Lbrace = testdata/funcspec.c:28:28
+//    | | | |+Stmts = 
+//    | | | | `-SwitchStmt
+//    | | | |  |+Switch = testdata/funcspec.c:29:5
+//    | | | |  |+X = x
+//    | | | |  `+Stmt = CompoundStmt
+//    | | | |   |+Lbrace = testdata/funcspec.c:29:16
+//    | | | |   |+Stmts = 
+//    | | | |   | `-DefaultStmt
+//    | | | |   |  |+Default = testdata/funcspec.c:30:5
+//    | | | |   |  `+Stmt = ForStmt
+//    | | | |   |   |+For = testdata/funcspec.c:31:9
+//    | | | |   |   |+Init = <nil>
+//    | | | |   |   |+Decl = <nil>
+//    | | | |   |   |+Cond = <nil>
+//    | | | |   |   |+Post = <nil>
+//    | | | |   |   `+Stmt = EmptyStmt
+//    | | | |   |    `+Semicolon = testdata/funcspec.c:31:17
+//    | | | |   `+Rbrace = testdata/funcspec.c:32:5
+//    | | | `+Rbrace = testdata/funcspec.c:33:1
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[_Noreturn:testdata/funcspec.c:35:1]
+//    | | |+Name = fail
+//    | | |+Type =  void ( int)
+//    | | |+Decl = 
+//    | | |+Body = CompoundStmt
+//    | | | |+Lbrace = testdata/funcspec.c:35:28
+//    | | | |+Stmts = 
+//    | | | | `-SwitchStmt
+//    | | | |  |+Switch = testdata/funcspec.c:36:5
+//    | | | |  |+X = x
+//    | | | |  `+Stmt = CompoundStmt
+//    | | | |   |+Lbrace = testdata/funcspec.c:36:16
+//    | | | |   |+Stmts = 
+//    | | | |   | |-CaseStmt
+//    | | | |   | | |+Case = testdata/funcspec.c:37:5
+//    | | | |   | | |+Expr = ConstantExpr
+//    | | | |   | | | `+X = 0
+//    | | | |   | | `+Stmt = ExprStmt
+//    | | | |   | |  |+Expr = CallExpr
+//    | | | |   | |  | |+Func = abort
+//    | | | |   | |  | |+Lparen = testdata/funcspec.c:38:14
+//    | | | |   | |  | |+Args = 
+//    | | | |   | |  | `+Rparen = testdata/funcspec.c:38:15
+//    | | | |   | |  `+Semicolon = testdata/funcspec.c:38:16
+//    | | | |   | `-DefaultStmt
+//    | | | |   |  |+Default = testdata/funcspec.c:39:5
+//    | | | |   |  `+Stmt = ExprStmt
+//    | | | |   |   |+Expr = CallExpr
+//    | | | |   |   | |+Func = fatal
+//    | | | |   |   | |+Lparen = testdata/funcspec.c:40:14
+//    | | | |   |   | |+Args = 
+//    | | | |   |   | | `-"fail"
+//    | | | |   |   | `+Rparen = testdata/funcspec.c:40:21
+//    | | | |   |   `+Semicolon = testdata/funcspec.c:40:22
+//    | | | |   `+Rbrace = testdata/funcspec.c:41:5
+//    | | | `+Rbrace = testdata/funcspec.c:42:1
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[_Noreturn:testdata/funcspec.c:44:1]
+//    | | |+Name = maybe
+//    | | |+Type =  void ( int)
+//    | | |+Decl = 
+//    | | |+Body = CompoundStmt
+//    | | | |+Lbrace = testdata/funcspec.c:44:29
+//    | | | |+Stmts = 
+//    | | | | `-SwitchStmt
+//    | | | |  |+Switch = testdata/funcspec.c:45:5
+//    | | | |  |+X = x
+//    | | | |  `+Stmt = CompoundStmt
+//    | | | |   |+Lbrace = testdata/funcspec.c:45:16
+//    | | | |   |+Stmts = 
+//    | | | |   | `-CaseStmt
+//    | | | |   |  |+Case = testdata/funcspec.c:46:5
+//    | | | |   |  |+Expr = ConstantExpr
+//    | | | |   |  | `+X = 0
+//    | | | |   |  `+Stmt = ExprStmt
+//    | | | |   |   |+Expr = CallExpr
+//    | | | |   |   | |+Func = abort
+//    | | | |   |   | |+Lparen = testdata/funcspec.c:47:14
+//    | | | |   |   | |+Args = 
+//    | | | |   |   | `+Rparen = testdata/funcspec.c:47:15
+//    | | | |   |   `+Semicolon = testdata/funcspec.c:47:16
+//    | | | |   `+Rbrace = testdata/funcspec.c:48:5
+//    | | | `+Rbrace = testdata/funcspec.c:49:1
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[_Noreturn:testdata/funcspec.c:51:1]
+//    | | |+Name = leave
+//    | | |+Type =  void ( int)
+//    | | |+Decl = 
+//    | | |+Body = CompoundStmt
+//    | | | |+Lbrace = testdata/funcspec.c:51:29
+//    | | | |+Stmts = 
+//    | | | | `-SwitchStmt
+//    | | | |  |+Switch = testdata/funcspec.c:52:5
+//    | | | |  |+X = x
+//    | | | |  `+Stmt = CompoundStmt
+//    | | | |   |+Lbrace = testdata/funcspec.c:52:16
+//    | | | |   |+Stmts = 
+//    | | | |   | |-DefaultStmt
+//    | | | |   | | |+Default = testdata/funcspec.c:53:5
+//    | | | |   | | `+Stmt = IfStmt
+//    | | | |   | |  |+If = testdata/funcspec.c:54:9
+//    | | | |   | |  |+X = x
+//    | | | |   | |  |+Then = BreakStmt
+//    | | | |   | |  | |+Break = testdata/funcspec.c:55:13
+//    | | | |   | |  | `+Semicolon = testdata/funcspec.c:55:18
+//    | | | |   | |  `+Else = <nil>
+//    | | | |   | `-ExprStmt
+//    | | | |   |  |+Expr = CallExpr
+//    | | | |   |  | |+Func = abort
+//    | | | |   |  | |+Lparen = testdata/funcspec.c:56:14
+//    | | | |   |  | |+Args = 
+//    | | | |   |  | `+Rparen = testdata/funcspec.c:56:15
+//    | | | |   |  `+Semicolon = testdata/funcspec.c:56:16
+//    | | | |   `+Rbrace = testdata/funcspec.c:57:5
+//    | | | `+Rbrace = testdata/funcspec.c:58:1
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[_Noreturn:testdata/funcspec.c:60:1]
+//    | | |+Name = idle
+//    | | |+Type =  void ( void)
+//    | | |+Decl = 
+//    | | |+Body = CompoundStmt
+//    | | | |+Lbrace = testdata/funcspec.c:60:27
+//    | | | |+Stmts = 
+//    | | | | `-WhileStmt
+//    | | | |  |+While = testdata/funcspec.c:61:5
+//    | | | |  |+X = 0x0
+//    | | | |  `+Stmt = CompoundStmt
+//    | | | |   |+Lbrace = testdata/funcspec.c:61:17
+//    | | | |   |+Stmts = 
+//    | | | |   `+Rbrace = testdata/funcspec.c:62:5
+//    | | | `+Rbrace = testdata/funcspec.c:63:1
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[_Noreturn:testdata/funcspec.c:65:1]
+//    | | |+Name = loop
+//    | | |+Type =  void ( void)
+//    | | |+Decl = 
+//    | | |+Body = CompoundStmt
+//    | | | |+Lbrace = testdata/funcspec.c:65:27
+//    | | | |+Stmts = 
+//    | | | | `-WhileStmt
+//    | | | |  |+While = testdata/funcspec.c:66:5
+//    | | | |  |+X = BinaryExpr
+//    | | | |  | |+X = 0x10
+//    | | | |  | |+Op = "-"<PUNCTUATOR@testdata/funcspec.c:66:17>
+//    | | | |  | `+Y = 1
+//    | | | |  `+Stmt = CompoundStmt
+//    | | | |   |+Lbrace = testdata/funcspec.c:66:22
+//    | | | |   |+Stmts = 
+//    | | | |   `+Rbrace = testdata/funcspec.c:67:5
+//    | | | `+Rbrace = testdata/funcspec.c:68:1
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[inline:testdata/funcspec.c:70:1]
+//    | | |+Name = square
+//    | | |+Type =  int ( int)
+//    | | |+Decl = 
+//    | | |+Body = CompoundStmt
+//    | | | |+Lbrace = testdata/funcspec.c:70:26
+//    | | | |+Stmts = 
+//    | | | | |-DeclStmt
+//    | | | | | `-VarDecl
+//    | | | | |  |+Qua = map[static:testdata/funcspec.c:71:5]
+//    | | | | |  |+Type =  int
+//    | | | | |  |+Name = calls
+//    | | | | |  |+Init = <nil>
+//    | | | | |  |+Align = 
+//    | | | | |  `+Attrs = 
+//    | | | | |-DeclStmt
+//    | | | | | `-VarDecl
+//    | | | | |  |+Qua = map[static:testdata/funcspec.c:72:5]
+//    | | | | |  |+Type = const int
+//    | | | | |  |+Name = one
+//    | | | | |  |+Init = 1
+//    | | | | |  |+Align = 
+//    | | | | |  `+Attrs = 
+//    | | | | `-ReturnStmt
+//    | | | |  |+Return = testdata/funcspec.c:73:5
+//    | | | |  |+X = BinaryExpr
+//    | | | |  | |+X = BinaryExpr
+//    | | | |  | | |+X = BinaryExpr
+//    | | | |  | | | |+X = x
+//    | | | |  | | | |+Op = "*"<PUNCTUATOR@testdata/funcspec.c:73:14>
+//    | | | |  | | | `+Y = x
+//    | | | |  | | |+Op = "*"<PUNCTUATOR@testdata/funcspec.c:73:18>
+//    | | | |  | | `+Y = one
+//    | | | |  | |+Op = "+"<PUNCTUATOR@testdata/funcspec.c:73:24>
+//    | | | |  | `+Y = BinaryExpr
+//    | | | |  |  |+X = hidden
+//    | | | |  |  |+Op = "+"<PUNCTUATOR@testdata/funcspec.c:73:33>
+//    | | | |  |  `+Y = CallExpr
+//    | | | |  |   |+Func = helper
+//    | | | |  |   |+Lparen = testdata/funcspec.c:73:41
+//    | | | |  |   |+Args = 
+//    | | | |  |   `+Rparen = testdata/funcspec.c:73:42
+//    | | | |  `+Semicolon = testdata/funcspec.c:73:43
+//    | | | `+Rbrace = testdata/funcspec.c:74:1
+//    | | |+InlineDef = true
+//    | | `+Attrs = 
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[inline:testdata/funcspec.c:76:1]
+//    | | |+Name = cube
+//    | | |+Type =  int ( int)
+//    | | |+Decl = 
+//    | | |+Body = <nil>
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |-FuncDecl
+//    | | |+Qua = map[extern:testdata/funcspec.c:77:1]
+//    | | |+Spec = map[]
+//    | | |+Name = cube
+//    | | |+Type =  int ( int)
+//    | | |+Decl = 
+//    | | |+Body = <nil>
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[inline:testdata/funcspec.c:78:1]
+//    | | |+Name = cube
+//    | | |+Type =  int ( int)
+//    | | |+Decl = 
+//    | | |+Body = CompoundStmt
+//    | | | |+Lbrace = testdata/funcspec.c:78:24
+//    | | | |+Stmts = 
+//    | | | | `-ReturnStmt
+//    | | | |  |+Return = testdata/funcspec.c:79:5
+//    | | | |  |+X = BinaryExpr
+//    | | | |  | |+X = BinaryExpr
+//    | | | |  | | |+X = x
+//    | | | |  | | |+Op = "*"<PUNCTUATOR@testdata/funcspec.c:79:14>
+//    | | | |  | | `+Y = x
+//    | | | |  | |+Op = "*"<PUNCTUATOR@testdata/funcspec.c:79:18>
+//    | | | |  | `+Y = x
+//    | | | |  `+Semicolon = testdata/funcspec.c:79:21
+//    | | | `+Rbrace = testdata/funcspec.c:80:1
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |-FuncDecl
+//    | | |+Qua = map[static:testdata/funcspec.c:82:1]
+//    | | |+Spec = map[inline:testdata/funcspec.c:82:8]
+//    | | |+Name = twice
+//    | | |+Type =  int ( int)
+//    | | |+Decl = 
+//    | | |+Body = CompoundStmt
+//    | | | |+Lbrace = testdata/funcspec.c:82:32
+//    | | | |+Stmts = 
+//    | | | | |-DeclStmt
+//    | | | | | `-VarDecl
+//    | | | | |  |+Qua = map[static:testdata/funcspec.c:83:5]
+//    | | | | |  |+Type =  int
+//    | | | | |  |+Name = calls
+//    | | | | |  |+Init = <nil>
+//    | | | | |  |+Align = 
+//    | | | | |  `+Attrs = 
+//    | | | | `-ReturnStmt
+//    | | | |  |+Return = testdata/funcspec.c:84:5
+//    | | | |  |+X = BinaryExpr
+//    | | | |  | |+X = BinaryExpr
+//    | | | |  | | |+X = x
+//    | | | |  | | |+Op = "+"<PUNCTUATOR@testdata/funcspec.c:84:14>
+//    | | | |  | | `+Y = x
+//    | | | |  | |+Op = "+"<PUNCTUATOR@testdata/funcspec.c:84:18>
+//    | | | |  | `+Y = hidden
+//    | | | |  `+Semicolon = testdata/funcspec.c:84:26
+//    | | | `+Rbrace = testdata/funcspec.c:85:1
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[inline:testdata/funcspec.c:87:1]
+//    | | |+Name = missing
+//    | | |+Type =  int ( int)
+//    | | |+Decl = 
+//    | | |+Body = <nil>
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  int
+//    | | |+Name = counter
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-TypedefDecl
+//    | | |+Typedef = testdata/funcspec.c:89:11
+//    | | |+Type =  int
+//    | | |+Name = no_type
+//    | | `+Attrs = 
+//    | `-FuncDecl
+//    |  |+Qua = map[]
+//    |  |+Spec = map[]
+//    |  |+Name = main
+//    |  |+Type =  int ( int)
+//    |  |+Decl = 
+//    |  |+Body = CompoundStmt
+//    |  | |+Lbrace = testdata/funcspec.c:91:27
+//    |  | |+Stmts = 
+//    |  | | `-ReturnStmt
+//    |  | |  |+Return = testdata/funcspec.c:92:5
+//    |  | |  |+X = BinaryExpr
+//    |  | |  | |+X = BinaryExpr
+//    |  | |  | | |+X = CallExpr
+//    |  | |  | | | |+Func = square
+//    |  | |  | | | |+Lparen = testdata/funcspec.c:92:18
+//    |  | |  | | | |+Args = 
+//    |  | |  | | | | `-argc
+//    |  | |  | | | `+Rparen = testdata/funcspec.c:92:23
+//    |  | |  | | |+Op = "+"<PUNCTUATOR@testdata/funcspec.c:92:25>
+//    |  | |  | | `+Y = CallExpr
+//    |  | |  | |  |+Func = cube
+//    |  | |  | |  |+Lparen = testdata/funcspec.c:92:31
+//    |  | |  | |  |+Args = 
+//    |  | |  | |  | `-argc
+//    |  | |  | |  `+Rparen = testdata/funcspec.c:92:36
+//    |  | |  | |+Op = "+"<PUNCTUATOR@testdata/funcspec.c:92:38>
+//    |  | |  | `+Y = CallExpr
+//    |  | |  |  |+Func = twice
+//    |  | |  |  |+Lparen = testdata/funcspec.c:92:45
+//    |  | |  |  |+Args = 
+//    |  | |  |  | `-argc
+//    |  | |  |  `+Rparen = testdata/funcspec.c:92:50
+//    |  | |  `+Semicolon = testdata/funcspec.c:92:51
+//    |  | `+Rbrace = testdata/funcspec.c:93:1
+//    |  |+InlineDef = false
+//    |  `+Attrs = 
+//    `+Unresolved = 
+// ===========================
+//
+// |-Error
+// | |+Pos = testdata/funcspec.c:21:9
+// | |+Typ = 1
+// | `+Msg = 在 testdata/funcspec.c 文件的第21行9列: _Noreturn 函数 quit 中不应该有 return 语句
+// |-Error
+// | |+Pos = testdata/funcspec.c:26:1
+// | |+Typ = 1
+// | `+Msg = 在 testdata/funcspec.c 文件的第26行1列: _Noreturn 函数 quit 可能会执行到函数末尾
+// |-Error
+// | |+Pos = testdata/funcspec.c:49:1
+// | |+Typ = 1
+// | `+Msg = 在 testdata/funcspec.c 文件的第49行1列: _Noreturn 函数 maybe 可能会执行到函数末尾
+// |-Error
+// | |+Pos = testdata/funcspec.c:58:1
+// | |+Typ = 1
+// | `+Msg = 在 testdata/funcspec.c 文件的第58行1列: _Noreturn 函数 leave 可能会执行到函数末尾
+// |-Error
+// | |+Pos = testdata/funcspec.c:63:1
+// | |+Typ = 1
+// | `+Msg = 在 testdata/funcspec.c 文件的第63行1列: _Noreturn 函数 idle 可能会执行到函数末尾
+// |-Error
+// | |+Pos = testdata/funcspec.c:71:16
+// | |+Typ = 0
+// | `+Msg = 在 testdata/funcspec.c 文件的第71行16列: 具有外部链接的内联函数 square 中不能定义可修改的静态变量 calls
+// |-Error
+// | |+Pos = testdata/funcspec.c:73:26
+// | |+Typ = 0
+// | `+Msg = 在 testdata/funcspec.c 文件的第73行26列: 具有外部链接的内联函数 square 中不能引用具有内部链接的 hidden
+// |-Error
+// | |+Pos = testdata/funcspec.c:73:35
+// | |+Typ = 0
+// | `+Msg = 在 testdata/funcspec.c 文件的第73行35列: 具有外部链接的内联函数 square 中不能引用具有内部链接的 helper
+// |-Error
+// | |+Pos = testdata/funcspec.c:71:16
+// | |+Typ = 1
+// | `+Msg = 在 testdata/funcspec.c 文件的第71行16列: 变量 calls 未使用
+// |-Error
+// | |+Pos = testdata/funcspec.c:83:16
+// | |+Typ = 1
+// | `+Msg = 在 testdata/funcspec.c 文件的第83行16列: 变量 calls 未使用
+// |-Error
+// | |+Pos = testdata/funcspec.c:88:1
+// | |+Typ = 0
+// | `+Msg = 在 testdata/funcspec.c 文件的第88行1列: inline 只能用于函数声明
+// |-Error
+// | |+Pos = testdata/funcspec.c:89:1
+// | |+Typ = 0
+// | `+Msg = 在 testdata/funcspec.c 文件的第89行1列: _Noreturn 只能用于函数声明
+// |-Error
+// | |+Pos = testdata/funcspec.c:91:10
+// | |+Typ = 0
+// | `+Msg = 在 testdata/funcspec.c 文件的第91行10列: inline 只能用于函数声明
+// `-Error
+//  |+Pos = testdata/funcspec.c:87:12
+//  |+Typ = 1
+//  `+Msg = 在 testdata/funcspec.c 文件的第87行12列: 内联函数 missing 已声明但没有定义
+// ===========================
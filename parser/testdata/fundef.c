@@ -13,53 +13,61 @@ int main() {
 // TranslationUnit
 //  `+Files = 
 //   `-File
-//    |+Name = testdata\fundef.c
+//    |+Name = testdata/fundef.c
 //    |+Decl = 
 //    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[]
 //    | | |+Name = max
 //    | | |+Type =  int ( int, int)
 //    | | |+Decl = 
-//    | | `+Body = CompoundStmt
-//    | |  |+Lbrace = testdata\fundef.c:1:23
-//    | |  |+Stmts = 
-//    | |  | |-IfStmt
-//    | |  | | |+If = testdata\fundef.c:2:5
-//    | |  | | |+X = BinaryExpr
-//    | |  | | | |+X = a
-//    | |  | | | |+Op = ">"<PUNCTUATOR@testdata\fundef.c:2:10>
-//    | |  | | | `+Y = b
-//    | |  | | |+Then = CompoundStmt
-//    | |  | | | |+Lbrace = testdata\fundef.c:2:14
-//    | |  | | | |+Stmts = 
-//    | |  | | | | `-ReturnStmt
-//    | |  | | | |  |+Return = testdata\fundef.c:3:9
-//    | |  | | | |  |+X = a
-//    | |  | | | |  `+Semicolon = testdata\fundef.c:3:17
-//    | |  | | | `+Rbrace = testdata\fundef.c:4:5
-//    | |  | | `+Else = <nil>
-//    | |  | `-ReturnStmt
-//    | |  |  |+Return = testdata\fundef.c:5:5
-//    | |  |  |+X = b
-//    | |  |  `+Semicolon = testdata\fundef.c:5:13
-//    | |  `+Rbrace = testdata\fundef.c:6:1
+//    | | |+Body = CompoundStmt
+//    | | | |+Lbrace = testdata/fundef.c:1:23
+//    | | | |+Stmts = 
+//    | | | | |-IfStmt
+//    | | | | | |+If = testdata/fundef.c:2:5
+//    | | | | | |+X = BinaryExpr
+//    | | | | | | |+X = a
+//    | | | | | | |+Op = ">"<PUNCTUATOR@testdata/fundef.c:2:10>
+//    | | | | | | `+Y = b
+//    | | | | | |+Then = CompoundStmt
+//    | | | | | | |+Lbrace = testdata/fundef.c:2:14
+//    | | | | | | |+Stmts = 
+//    | | | | | | | `-ReturnStmt
+//    | | | | | | |  |+Return = testdata/fundef.c:3:9
+//    | | | | | | |  |+X = a
+//    | | | | | | |  `+Semicolon = testdata/fundef.c:3:17
+//    | | | | | | `+Rbrace = testdata/fundef.c:4:5
+//    | | | | | `+Else = <nil>
+//    | | | | `-ReturnStmt
+//    | | | |  |+Return = testdata/fundef.c:5:5
+//    | | | |  |+X = b
+//    | | | |  `+Semicolon = testdata/fundef.c:5:13
+//    | | | `+Rbrace = testdata/fundef.c:6:1
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
 //    | `-FuncDecl
+//    |  |+Qua = map[]
+//    |  |+Spec = map[]
 //    |  |+Name = main
 //    |  |+Type =  int ()
 //    |  |+Decl = 
-//    |  `+Body = CompoundStmt
-//    |   |+Lbrace = testdata\fundef.c:8:12
-//    |   |+Stmts = 
-//    |   | `-ReturnStmt
-//    |   |  |+Return = testdata\fundef.c:9:5
-//    |   |  |+X = CallExpr
-//    |   |  | |+Func = max
-//    |   |  | |+Lparen = testdata\fundef.c:9:15
-//    |   |  | |+Args = 
-//    |   |  | | |-10
-//    |   |  | | `-20
-//    |   |  | `+Rparen = testdata\fundef.c:9:21
-//    |   |  `+Semicolon = testdata\fundef.c:9:22
-//    |   `+Rbrace = testdata\fundef.c:10:1
+//    |  |+Body = CompoundStmt
+//    |  | |+Lbrace = testdata/fundef.c:8:12
+//    |  | |+Stmts = 
+//    |  | | `-ReturnStmt
+//    |  | |  |+Return = testdata/fundef.c:9:5
+//    |  | |  |+X = CallExpr
+//    |  | |  | |+Func = max
+//    |  | |  | |+Lparen = testdata/fundef.c:9:15
+//    |  | |  | |+Args = 
+//    |  | |  | | |-10
+//    |  | |  | | `-20
+//    |  | |  | `+Rparen = testdata/fundef.c:9:21
+//    |  | |  `+Semicolon = testdata/fundef.c:9:22
+//    |  | `+Rbrace = testdata/fundef.c:10:1
+//    |  |+InlineDef = false
+//    |  `+Attrs = 
 //    `+Unresolved = 
 // ===========================
 //
@@ -0,0 +1,731 @@
+typedef int word;
+enum color { RED, GREEN };
+int abs_i(int x);
+double abs_d(double x);
+long long abs_l(long long x);
+double sq(double x);
+struct point { int x; double y; };
+
+#define abs(x) _Generic((x), int: abs_i, double: abs_d, long long: abs_l)(x)
+
+int main() {
+    int i = -1;
+    const double d = 2.0;
+    char buf[8];
+    int n = abs(i) + abs(d) + abs(3LL);
+    _Static_assert(_Generic(RED, int: 1, default: 0), "enum constant");
+    _Static_assert(_Generic(buf, char *: 1, char: 2), "array decay");
+    _Static_assert(_Generic('a', char: 0, default: 1), "char constant");
+    _Static_assert(_Generic(d, const double: 0, double: 1), "lvalue conversion");
+    _Static_assert(_Generic(abs_i, int(*)(int): 1, default: 0), "function designator");
+    _Static_assert(_Generic(&abs_i, int (*)(int): 1, default: 0), "function address");
+    _Static_assert(sizeof(int (*)[3]) == sizeof(void *), "pointer to array");
+    int arr[3];
+    struct point pt;
+    struct point *pp = &pt;
+    _Static_assert(_Generic(1, int[3]: 1, int[4]: 2, default: 3) == 3, "array size");
+    _Static_assert(_Generic(&arr, int (*)[4]: 0, int (*)[3]: 1), "pointer to array size");
+    _Static_assert(_Generic(d + 1.0f, double: 1, default: 0), "arithmetic conversion");
+    _Static_assert(_Generic(i ? 1 : 2.0f, float: 1, default: 0), "conditional");
+    _Static_assert(_Generic(sq(1.0), double: 1, default: 0), "call");
+    _Static_assert(_Generic(pt.y, double: 1, default: 0) && _Generic(pp->x, int: 1, default: 0), "member");
+    _Static_assert(_Generic(arr[1] << 1L, int: 1, default: 0), "shift");
+    n = _Generic(i, int: 1, word: 2, default: 3, default: 4);
+    n = _Generic(1.0f, int: 1, double: 2);
+    n = _Generic(n + 1, int: 1);
+    return n;
+}
+
+// ===========================
+// TranslationUnit
+//  `+Files = 
+//   `-File
+//    |+Name = testdata/generic.c
+//    |+Decl = 
+//    | |-TypedefDecl
+//    | | |+Typedef = testdata/generic.c:1:1
+//    | | |+Type =  int
+//    | | |+Name = word
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  enum color
+//    | | |+Name = <nil>
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[]
+//    | | |+Name = abs_i
+//    | | |+Type =  int ( int)
+//    | | |+Decl = 
+//    | | |+Body = <nil>
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[]
+//    | | |+Name = abs_d
+//    | | |+Type =  double ( double)
+//    | | |+Decl = 
+//    | | |+Body = <nil>
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[]
+//    | | |+Name = abs_l
+//    | | |+Type =  long long ( long long)
+//    | | |+Decl = 
+//    | | |+Body = <nil>
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[]
+//    | | |+Name = sq
+//    | | |+Type =  double ( double)
+//    | | |+Decl = 
+//    | | |+Body = <nil>
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  struct point
+//    | | |+Name = <nil>
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | `-FuncDecl
+//    |  |+Qua = map[]
+//    |  |+Spec = map[]
+//    |  |+Name = main
+//    |  |+Type =  int ()
+//    |  |+Decl = 
+//    |  |+Body = CompoundStmt
+//    |  | |+Lbrace = testdata/generic.c:11:12
+//    |  | |+Stmts = 
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[]
+//    |  | | |  |+Type =  int
+//    |  | | |  |+Name = i
+//    |  | | |  |+Init = UnaryExpr
+//    |  | | |  | |+Op = "-"<PUNCTUATOR@testdata/generic.c:12:13>
+//    |  | | |  | `+X = 1
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[]
+//    |  | | |  |+Type = const double
+//    |  | | |  |+Name = d
+//    |  | | |  |+Init = 2.0
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[]
+//    |  | | |  |+Type =   char[8]
+//    |  | | |  |+Name = buf
+//    |  | | |  |+Init = <nil>
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[]
+//    |  | | |  |+Type =  int
+//    |  | | |  |+Name = n
+//    |  | | |  |+Init = BinaryExpr
+//    |  | | |  | |+X = BinaryExpr
+//    |  | | |  | | |+X = CallExpr
+//    |  | | |  | | | |+Func = GenericSelectionExpr
+//    |  | | |  | | | | |+Generic = testdata/generic.c:15:13
+//    |  | | |  | | | | |+Lparen = testdata/generic.c:15:21
+//    |  | | |  | | | | |+X = ParenExpr
+//    |  | | |  | | | | | |+Lparen = testdata/generic.c:15:22
+//    |  | | |  | | | | | |+X = i
+//    |  | | |  | | | | | `+Rparen = testdata/generic.c:15:24
+//    |  | | |  | | | | |+Assocs = 
+//    |  | | |  | | | | | |-GenericAssoc
+//    |  | | |  | | | | | | |+Type =  int
+//    |  | | |  | | | | | | |+Default = :0:0
+//    |  | | |  | | | | | | |+Colon = testdata/generic.c:15:30
+//    |  | | |  | | | | | | `+X = abs_i
+//    |  | | |  | | | | | |-GenericAssoc
+//    |  | | |  | | | | | | |+Type =  double
+//    |  | | |  | | | | | | |+Default = :0:0
+//    |  | | |  | | | | | | |+Colon = testdata/generic.c:15:45
+//    |  | | |  | | | | | | `+X = abs_d
+//    |  | | |  | | | | | `-GenericAssoc
+//    |  | | |  | | | | |  |+Type =  long long
+//    |  | | |  | | | | |  |+Default = :0:0
+//    |  | | |  | | | | |  |+Colon = testdata/generic.c:15:63
+//    |  | | |  | | | | |  `+X = abs_l
+//    |  | | |  | | | | |+Rparen = testdata/generic.c:15:70
+//    |  | | |  | | | | `+Selected = 0
+//    |  | | |  | | | |+Lparen = testdata/generic.c:15:71
+//    |  | | |  | | | |+Args = 
+//    |  | | |  | | | | `-i
+//    |  | | |  | | | `+Rparen = testdata/generic.c:15:73
+//    |  | | |  | | |+Op = "+"<PUNCTUATOR@testdata/generic.c:15:74>
+//    |  | | |  | | `+Y = CallExpr
+//    |  | | |  | |  |+Func = GenericSelectionExpr
+//    |  | | |  | |  | |+Generic = testdata/generic.c:15:76
+//    |  | | |  | |  | |+Lparen = testdata/generic.c:15:84
+//    |  | | |  | |  | |+X = ParenExpr
+//    |  | | |  | |  | | |+Lparen = testdata/generic.c:15:85
+//    |  | | |  | |  | | |+X = d
+//    |  | | |  | |  | | `+Rparen = testdata/generic.c:15:87
+//    |  | | |  | |  | |+Assocs = 
+//    |  | | |  | |  | | |-GenericAssoc
+//    |  | | |  | |  | | | |+Type =  int
+//    |  | | |  | |  | | | |+Default = :0:0
+//    |  | | |  | |  | | | |+Colon = testdata/generic.c:15:93
+//    |  | | |  | |  | | | `+X = abs_i
+//    |  | | |  | |  | | |-GenericAssoc
+//    |  | | |  | |  | | | |+Type =  double
+//    |  | | |  | |  | | | |+Default = :0:0
+//    |  | | |  | |  | | | |+Colon = testdata/generic.c:15:108
+//    |  | | |  | |  | | | `+X = abs_d
+//    |  | | |  | |  | | `-GenericAssoc
+//    |  | | |  | |  | |  |+Type =  long long
+//    |  | | |  | |  | |  |+Default = :0:0
+//    |  | | |  | |  | |  |+Colon = testdata/generic.c:15:126
+//    |  | | |  | |  | |  `+X = abs_l
+//    |  | | |  | |  | |+Rparen = testdata/generic.c:15:133
+//    |  | | |  | |  | `+Selected = 1
+//    |  | | |  | |  |+Lparen = testdata/generic.c:15:134
+//    |  | | |  | |  |+Args = 
+//    |  | | |  | |  | `-d
+//    |  | | |  | |  `+Rparen = testdata/generic.c:15:136
+//    |  | | |  | |+Op = "+"<PUNCTUATOR@testdata/generic.c:15:137>
+//    |  | | |  | `+Y = CallExpr
+//    |  | | |  |  |+Func = GenericSelectionExpr
+//    |  | | |  |  | |+Generic = testdata/generic.c:15:139
+//    |  | | |  |  | |+Lparen = testdata/generic.c:15:147
+//    |  | | |  |  | |+X = ParenExpr
+//    |  | | |  |  | | |+Lparen = testdata/generic.c:15:148
+//    |  | | |  |  | | |+X = 3LL
+//    |  | | |  |  | | `+Rparen = testdata/generic.c:15:152
+//    |  | | |  |  | |+Assocs = 
+//    |  | | |  |  | | |-GenericAssoc
+//    |  | | |  |  | | | |+Type =  int
+//    |  | | |  |  | | | |+Default = :0:0
+//    |  | | |  |  | | | |+Colon = testdata/generic.c:15:158
+//    |  | | |  |  | | | `+X = abs_i
+//    |  | | |  |  | | |-GenericAssoc
+//    |  | | |  |  | | | |+Type =  double
+//    |  | | |  |  | | | |+Default = :0:0
+//    |  | | |  |  | | | |+Colon = testdata/generic.c:15:173
+//    |  | | |  |  | | | `+X = abs_d
+//    |  | | |  |  | | `-GenericAssoc
+//    |  | | |  |  | |  |+Type =  long long
+//    |  | | |  |  | |  |+Default = :0:0
+//    |  | | |  |  | |  |+Colon = testdata/generic.c:15:191
+//    |  | | |  |  | |  `+X = abs_l
+//    |  | | |  |  | |+Rparen = testdata/generic.c:15:198
+//    |  | | |  |  | `+Selected = 2
+//    |  | | |  |  |+Lparen = testdata/generic.c:15:199
+//    |  | | |  |  |+Args = 
+//    |  | | |  |  | `-3LL
+//    |  | | |  |  `+Rparen = testdata/generic.c:15:203
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | |-DeclStmt
+//    |  | | | `-StaticAssertDecl
+//    |  | | |  |+StaticAssert = testdata/generic.c:16:5
+//    |  | | |  |+Cond = ConstantExpr
+//    |  | | |  | `+X = GenericSelectionExpr
+//    |  | | |  |  |+Generic = testdata/generic.c:16:20
+//    |  | | |  |  |+Lparen = testdata/generic.c:16:28
+//    |  | | |  |  |+X = RED
+//    |  | | |  |  |+Assocs = 
+//    |  | | |  |  | |-GenericAssoc
+//    |  | | |  |  | | |+Type =  int
+//    |  | | |  |  | | |+Default = :0:0
+//    |  | | |  |  | | |+Colon = testdata/generic.c:16:37
+//    |  | | |  |  | | `+X = 1
+//    |  | | |  |  | `-GenericAssoc
+//    |  | | |  |  |  |+Type = <nil>
+//    |  | | |  |  |  |+Default = testdata/generic.c:16:42
+//    |  | | |  |  |  |+Colon = testdata/generic.c:16:49
+//    |  | | |  |  |  `+X = 0
+//    |  | | |  |  |+Rparen = testdata/generic.c:16:52
+//    |  | | |  |  `+Selected = 0
+//    |  | | |  |+Msg = "enum constant"
+//    |  | | |  `+Semicolon = testdata/generic.c:16:71
+//    |  | | |-DeclStmt
+//    |  | | | `-StaticAssertDecl
+//    |  | | |  |+StaticAssert = testdata/generic.c:17:5
+//    |  | | |  |+Cond = ConstantExpr
+//    |  | | |  | `+X = GenericSelectionExpr
+//    |  | | |  |  |+Generic = testdata/generic.c:17:20
+//    |  | | |  |  |+Lparen = testdata/generic.c:17:28
+//    |  | | |  |  |+X = buf
+//    |  | | |  |  |+Assocs = 
+//    |  | | |  |  | |-GenericAssoc
+//    |  | | |  |  | | |+Type =  char *
+//    |  | | |  |  | | |+Default = :0:0
+//    |  | | |  |  | | |+Colon = testdata/generic.c:17:40
+//    |  | | |  |  | | `+X = 1
+//    |  | | |  |  | `-GenericAssoc
+//    |  | | |  |  |  |+Type =  char
+//    |  | | |  |  |  |+Default = :0:0
+//    |  | | |  |  |  |+Colon = testdata/generic.c:17:49
+//    |  | | |  |  |  `+X = 2
+//    |  | | |  |  |+Rparen = testdata/generic.c:17:52
+//    |  | | |  |  `+Selected = 0
+//    |  | | |  |+Msg = "array decay"
+//    |  | | |  `+Semicolon = testdata/generic.c:17:69
+//    |  | | |-DeclStmt
+//    |  | | | `-StaticAssertDecl
+//    |  | | |  |+StaticAssert = testdata/generic.c:18:5
+//    |  | | |  |+Cond = ConstantExpr
+//    |  | | |  | `+X = GenericSelectionExpr
+//    |  | | |  |  |+Generic = testdata/generic.c:18:20
+//    |  | | |  |  |+Lparen = testdata/generic.c:18:28
+//    |  | | |  |  |+X = 'a'
+//    |  | | |  |  |+Assocs = 
+//    |  | | |  |  | |-GenericAssoc
+//    |  | | |  |  | | |+Type =  char
+//    |  | | |  |  | | |+Default = :0:0
+//    |  | | |  |  | | |+Colon = testdata/generic.c:18:38
+//    |  | | |  |  | | `+X = 0
+//    |  | | |  |  | `-GenericAssoc
+//    |  | | |  |  |  |+Type = <nil>
+//    |  | | |  |  |  |+Default = testdata/generic.c:18:43
+//    |  | | |  |  |  |+Colon = testdata/generic.c:18:50
+//    |  | | |  |  |  `+X = 1
+//    |  | | |  |  |+Rparen = testdata/generic.c:18:53
+//    |  | | |  |  `+Selected = 1
+//    |  | | |  |+Msg = "char constant"
+//    |  | | |  `+Semicolon = testdata/generic.c:18:72
+//    |  | | |-DeclStmt
+//    |  | | | `-StaticAssertDecl
+//    |  | | |  |+StaticAssert = testdata/generic.c:19:5
+//    |  | | |  |+Cond = ConstantExpr
+//    |  | | |  | `+X = GenericSelectionExpr
+//    |  | | |  |  |+Generic = testdata/generic.c:19:20
+//    |  | | |  |  |+Lparen = testdata/generic.c:19:28
+//    |  | | |  |  |+X = d
+//    |  | | |  |  |+Assocs = 
+//    |  | | |  |  | |-GenericAssoc
+//    |  | | |  |  | | |+Type = const double
+//    |  | | |  |  | | |+Default = :0:0
+//    |  | | |  |  | | |+Colon = testdata/generic.c:19:44
+//    |  | | |  |  | | `+X = 0
+//    |  | | |  |  | `-GenericAssoc
+//    |  | | |  |  |  |+Type =  double
+//    |  | | |  |  |  |+Default = :0:0
+//    |  | | |  |  |  |+Colon = testdata/generic.c:19:55
+//    |  | | |  |  |  `+X = 1
+//    |  | | |  |  |+Rparen = testdata/generic.c:19:58
+//    |  | | |  |  `+Selected = 1
+//    |  | | |  |+Msg = "lvalue conversion"
+//    |  | | |  `+Semicolon = testdata/generic.c:19:81
+//    |  | | |-DeclStmt
+//    |  | | | `-StaticAssertDecl
+//    |  | | |  |+StaticAssert = testdata/generic.c:20:5
+//    |  | | |  |+Cond = ConstantExpr
+//    |  | | |  | `+X = GenericSelectionExpr
+//    |  | | |  |  |+Generic = testdata/generic.c:20:20
+//    |  | | |  |  |+Lparen = testdata/generic.c:20:28
+//    |  | | |  |  |+X = abs_i
+//    |  | | |  |  |+Assocs = 
+//    |  | | |  |  | |-GenericAssoc
+//    |  | | |  |  | | |+Type = ( int *) ( int)
+//    |  | | |  |  | | |+Default = :0:0
+//    |  | | |  |  | | |+Colon = testdata/generic.c:20:47
+//    |  | | |  |  | | `+X = 1
+//    |  | | |  |  | `-GenericAssoc
+//    |  | | |  |  |  |+Type = <nil>
+//    |  | | |  |  |  |+Default = testdata/generic.c:20:52
+//    |  | | |  |  |  |+Colon = testdata/generic.c:20:59
+//    |  | | |  |  |  `+X = 0
+//    |  | | |  |  |+Rparen = testdata/generic.c:20:62
+//    |  | | |  |  `+Selected = 0
+//    |  | | |  |+Msg = "function designator"
+//    |  | | |  `+Semicolon = testdata/generic.c:20:87
+//    |  | | |-DeclStmt
+//    |  | | | `-StaticAssertDecl
+//    |  | | |  |+StaticAssert = testdata/generic.c:21:5
+//    |  | | |  |+Cond = ConstantExpr
+//    |  | | |  | `+X = GenericSelectionExpr
+//    |  | | |  |  |+Generic = testdata/generic.c:21:20
+//    |  | | |  |  |+Lparen = testdata/generic.c:21:28
+//    |  | | |  |  |+X = UnaryExpr
+//    |  | | |  |  | |+Op = "&"<PUNCTUATOR@testdata/generic.c:21:29>
+//    |  | | |  |  | `+X = abs_i
+//    |  | | |  |  |+Assocs = 
+//    |  | | |  |  | |-GenericAssoc
+//    |  | | |  |  | | |+Type = ( int *) ( int)
+//    |  | | |  |  | | |+Default = :0:0
+//    |  | | |  |  | | |+Colon = testdata/generic.c:21:49
+//    |  | | |  |  | | `+X = 1
+//    |  | | |  |  | `-GenericAssoc
+//    |  | | |  |  |  |+Type = <nil>
+//    |  | | |  |  |  |+Default = testdata/generic.c:21:54
+//    |  | | |  |  |  |+Colon = testdata/generic.c:21:61
+//    |  | | |  |  |  `+X = 0
+//    |  | | |  |  |+Rparen = testdata/generic.c:21:64
+//    |  | | |  |  `+Selected = 0
+//    |  | | |  |+Msg = "function address"
+//    |  | | |  `+Semicolon = testdata/generic.c:21:86
+//    |  | | |-DeclStmt
+//    |  | | | `-StaticAssertDecl
+//    |  | | |  |+StaticAssert = testdata/generic.c:22:5
+//    |  | | |  |+Cond = ConstantExpr
+//    |  | | |  | `+X = BinaryExpr
+//    |  | | |  |  |+X = SizeOfExpr
+//    |  | | |  |  | |+Range = Range
+//    |  | | |  |  | | |+Begin = testdata/generic.c:22:20
+//    |  | | |  |  | | `+End = testdata/generic.c:22:37
+//    |  | | |  |  | `+Type =  ( int *)[3]
+//    |  | | |  |  |+Op = "=="<PUNCTUATOR@testdata/generic.c:22:39>
+//    |  | | |  |  `+Y = SizeOfExpr
+//    |  | | |  |   |+Range = Range
+//    |  | | |  |   | |+Begin = testdata/generic.c:22:42
+//    |  | | |  |   | `+End = testdata/generic.c:22:55
+//    |  | | |  |   `+Type =  void *
+//    |  | | |  |+Msg = "pointer to array"
+//    |  | | |  `+Semicolon = testdata/generic.c:22:77
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[]
+//    |  | | |  |+Type =   int[3]
+//    |  | | |  |+Name = arr
+//    |  | | |  |+Init = <nil>
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[]
+//    |  | | |  |+Type =  struct point
+//    |  | | |  |+Name = pt
+//    |  | | |  |+Init = <nil>
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[]
+//    |  | | |  |+Type =  struct point *
+//    |  | | |  |+Name = pp
+//    |  | | |  |+Init = UnaryExpr
+//    |  | | |  | |+Op = "&"<PUNCTUATOR@testdata/generic.c:25:24>
+//    |  | | |  | `+X = pt
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | |-DeclStmt
+//    |  | | | `-StaticAssertDecl
+//    |  | | |  |+StaticAssert = testdata/generic.c:26:5
+//    |  | | |  |+Cond = ConstantExpr
+//    |  | | |  | `+X = BinaryExpr
+//    |  | | |  |  |+X = GenericSelectionExpr
+//    |  | | |  |  | |+Generic = testdata/generic.c:26:20
+//    |  | | |  |  | |+Lparen = testdata/generic.c:26:28
+//    |  | | |  |  | |+X = 1
+//    |  | | |  |  | |+Assocs = 
+//    |  | | |  |  | | |-GenericAssoc
+//    |  | | |  |  | | | |+Type =   int[3]
+//    |  | | |  |  | | | |+Default = :0:0
+//    |  | | |  |  | | | |+Colon = testdata/generic.c:26:38
+//    |  | | |  |  | | | `+X = 1
+//    |  | | |  |  | | |-GenericAssoc
+//    |  | | |  |  | | | |+Type =   int[4]
+//    |  | | |  |  | | | |+Default = :0:0
+//    |  | | |  |  | | | |+Colon = testdata/generic.c:26:49
+//    |  | | |  |  | | | `+X = 2
+//    |  | | |  |  | | `-GenericAssoc
+//    |  | | |  |  | |  |+Type = <nil>
+//    |  | | |  |  | |  |+Default = testdata/generic.c:26:54
+//    |  | | |  |  | |  |+Colon = testdata/generic.c:26:61
+//    |  | | |  |  | |  `+X = 3
+//    |  | | |  |  | |+Rparen = testdata/generic.c:26:64
+//    |  | | |  |  | `+Selected = 2
+//    |  | | |  |  |+Op = "=="<PUNCTUATOR@testdata/generic.c:26:66>
+//    |  | | |  |  `+Y = 3
+//    |  | | |  |+Msg = "array size"
+//    |  | | |  `+Semicolon = testdata/generic.c:26:85
+//    |  | | |-DeclStmt
+//    |  | | | `-StaticAssertDecl
+//    |  | | |  |+StaticAssert = testdata/generic.c:27:5
+//    |  | | |  |+Cond = ConstantExpr
+//    |  | | |  | `+X = GenericSelectionExpr
+//    |  | | |  |  |+Generic = testdata/generic.c:27:20
+//    |  | | |  |  |+Lparen = testdata/generic.c:27:28
+//    |  | | |  |  |+X = UnaryExpr
+//    |  | | |  |  | |+Op = "&"<PUNCTUATOR@testdata/generic.c:27:29>
+//    |  | | |  |  | `+X = arr
+//    |  | | |  |  |+Assocs = 
+//    |  | | |  |  | |-GenericAssoc
+//    |  | | |  |  | | |+Type =  ( int *)[4]
+//    |  | | |  |  | | |+Default = :0:0
+//    |  | | |  |  | | |+Colon = testdata/generic.c:27:45
+//    |  | | |  |  | | `+X = 0
+//    |  | | |  |  | `-GenericAssoc
+//    |  | | |  |  |  |+Type =  ( int *)[3]
+//    |  | | |  |  |  |+Default = :0:0
+//    |  | | |  |  |  |+Colon = testdata/generic.c:27:60
+//    |  | | |  |  |  `+X = 1
+//    |  | | |  |  |+Rparen = testdata/generic.c:27:63
+//    |  | | |  |  `+Selected = 1
+//    |  | | |  |+Msg = "pointer to array size"
+//    |  | | |  `+Semicolon = testdata/generic.c:27:90
+//    |  | | |-DeclStmt
+//    |  | | | `-StaticAssertDecl
+//    |  | | |  |+StaticAssert = testdata/generic.c:28:5
+//    |  | | |  |+Cond = ConstantExpr
+//    |  | | |  | `+X = GenericSelectionExpr
+//    |  | | |  |  |+Generic = testdata/generic.c:28:20
+//    |  | | |  |  |+Lparen = testdata/generic.c:28:28
+//    |  | | |  |  |+X = BinaryExpr
+//    |  | | |  |  | |+X = d
+//    |  | | |  |  | |+Op = "+"<PUNCTUATOR@testdata/generic.c:28:31>
+//    |  | | |  |  | `+Y = 1.0f
+//    |  | | |  |  |+Assocs = 
+//    |  | | |  |  | |-GenericAssoc
+//    |  | | |  |  | | |+Type =  double
+//    |  | | |  |  | | |+Default = :0:0
+//    |  | | |  |  | | |+Colon = testdata/generic.c:28:45
+//    |  | | |  |  | | `+X = 1
+//    |  | | |  |  | `-GenericAssoc
+//    |  | | |  |  |  |+Type = <nil>
+//    |  | | |  |  |  |+Default = testdata/generic.c:28:50
+//    |  | | |  |  |  |+Colon = testdata/generic.c:28:57
+//    |  | | |  |  |  `+X = 0
+//    |  | | |  |  |+Rparen = testdata/generic.c:28:60
+//    |  | | |  |  `+Selected = 0
+//    |  | | |  |+Msg = "arithmetic conversion"
+//    |  | | |  `+Semicolon = testdata/generic.c:28:87
+//    |  | | |-DeclStmt
+//    |  | | | `-StaticAssertDecl
+//    |  | | |  |+StaticAssert = testdata/generic.c:29:5
+//    |  | | |  |+Cond = ConstantExpr
+//    |  | | |  | `+X = GenericSelectionExpr
+//    |  | | |  |  |+Generic = testdata/generic.c:29:20
+//    |  | | |  |  |+Lparen = testdata/generic.c:29:28
+//    |  | | |  |  |+X = CondExpr
+//    |  | | |  |  | |+X = i
+//    |  | | |  |  | |+Op = "?"<PUNCTUATOR@testdata/generic.c:29:31>
+//    |  | | |  |  | |+Then = 1
+//    |  | | |  |  | `+Else = 2.0f
+//    |  | | |  |  |+Assocs = 
+//    |  | | |  |  | |-GenericAssoc
+//    |  | | |  |  | | |+Type =  float
+//    |  | | |  |  | | |+Default = :0:0
+//    |  | | |  |  | | |+Colon = testdata/generic.c:29:48
+//    |  | | |  |  | | `+X = 1
+//    |  | | |  |  | `-GenericAssoc
+//    |  | | |  |  |  |+Type = <nil>
+//    |  | | |  |  |  |+Default = testdata/generic.c:29:53
+//    |  | | |  |  |  |+Colon = testdata/generic.c:29:60
+//    |  | | |  |  |  `+X = 0
+//    |  | | |  |  |+Rparen = testdata/generic.c:29:63
+//    |  | | |  |  `+Selected = 0
+//    |  | | |  |+Msg = "conditional"
+//    |  | | |  `+Semicolon = testdata/generic.c:29:80
+//    |  | | |-DeclStmt
+//    |  | | | `-StaticAssertDecl
+//    |  | | |  |+StaticAssert = testdata/generic.c:30:5
+//    |  | | |  |+Cond = ConstantExpr
+//    |  | | |  | `+X = GenericSelectionExpr
+//    |  | | |  |  |+Generic = testdata/generic.c:30:20
+//    |  | | |  |  |+Lparen = testdata/generic.c:30:28
+//    |  | | |  |  |+X = CallExpr
+//    |  | | |  |  | |+Func = sq
+//    |  | | |  |  | |+Lparen = testdata/generic.c:30:31
+//    |  | | |  |  | |+Args = 
+//    |  | | |  |  | | `-1.0
+//    |  | | |  |  | `+Rparen = testdata/generic.c:30:35
+//    |  | | |  |  |+Assocs = 
+//    |  | | |  |  | |-GenericAssoc
+//    |  | | |  |  | | |+Type =  double
+//    |  | | |  |  | | |+Default = :0:0
+//    |  | | |  |  | | |+Colon = testdata/generic.c:30:44
+//    |  | | |  |  | | `+X = 1
+//    |  | | |  |  | `-GenericAssoc
+//    |  | | |  |  |  |+Type = <nil>
+//    |  | | |  |  |  |+Default = testdata/generic.c:30:49
+//    |  | | |  |  |  |+Colon = testdata/generic.c:30:56
+//    |  | | |  |  |  `+X = 0
+//    |  | | |  |  |+Rparen = testdata/generic.c:30:59
+//    |  | | |  |  `+Selected = 0
+//    |  | | |  |+Msg = "call"
+//    |  | | |  `+Semicolon = testdata/generic.c:30:69
+//    |  | | |-DeclStmt
+//    |  | | | `-StaticAssertDecl
+//    |  | | |  |+StaticAssert = testdata/generic.c:31:5
+//    |  | | |  |+Cond = ConstantExpr
+//    |  | | |  | `+X = BinaryExpr
+//    |  | | |  |  |+X = GenericSelectionExpr
+//    |  | | |  |  | |+Generic = testdata/generic.c:31:20
+//    |  | | |  |  | |+Lparen = testdata/generic.c:31:28
+//    |  | | |  |  | |+X = SelectorExpr
+//    |  | | |  |  | | |+X = pt
+//    |  | | |  |  | | |+Op = "."<PUNCTUATOR@testdata/generic.c:31:31>
+//    |  | | |  |  | | `+Name = y
+//    |  | | |  |  | |+Assocs = 
+//    |  | | |  |  | | |-GenericAssoc
+//    |  | | |  |  | | | |+Type =  double
+//    |  | | |  |  | | | |+Default = :0:0
+//    |  | | |  |  | | | |+Colon = testdata/generic.c:31:41
+//    |  | | |  |  | | | `+X = 1
+//    |  | | |  |  | | `-GenericAssoc
+//    |  | | |  |  | |  |+Type = <nil>
+//    |  | | |  |  | |  |+Default = testdata/generic.c:31:46
+//    |  | | |  |  | |  |+Colon = testdata/generic.c:31:53
+//    |  | | |  |  | |  `+X = 0
+//    |  | | |  |  | |+Rparen = testdata/generic.c:31:56
+//    |  | | |  |  | `+Selected = 0
+//    |  | | |  |  |+Op = "&&"<PUNCTUATOR@testdata/generic.c:31:58>
+//    |  | | |  |  `+Y = GenericSelectionExpr
+//    |  | | |  |   |+Generic = testdata/generic.c:31:61
+//    |  | | |  |   |+Lparen = testdata/generic.c:31:69
+//    |  | | |  |   |+X = SelectorExpr
+//    |  | | |  |   | |+X = pp
+//    |  | | |  |   | |+Op = "->"<PUNCTUATOR@testdata/generic.c:31:72>
+//    |  | | |  |   | `+Name = x
+//    |  | | |  |   |+Assocs = 
+//    |  | | |  |   | |-GenericAssoc
+//    |  | | |  |   | | |+Type =  int
+//    |  | | |  |   | | |+Default = :0:0
+//    |  | | |  |   | | |+Colon = testdata/generic.c:31:80
+//    |  | | |  |   | | `+X = 1
+//    |  | | |  |   | `-GenericAssoc
+//    |  | | |  |   |  |+Type = <nil>
+//    |  | | |  |   |  |+Default = testdata/generic.c:31:85
+//    |  | | |  |   |  |+Colon = testdata/generic.c:31:92
+//    |  | | |  |   |  `+X = 0
+//    |  | | |  |   |+Rparen = testdata/generic.c:31:95
+//    |  | | |  |   `+Selected = 0
+//    |  | | |  |+Msg = "member"
+//    |  | | |  `+Semicolon = testdata/generic.c:31:107
+//    |  | | |-DeclStmt
+//    |  | | | `-StaticAssertDecl
+//    |  | | |  |+StaticAssert = testdata/generic.c:32:5
+//    |  | | |  |+Cond = ConstantExpr
+//    |  | | |  | `+X = GenericSelectionExpr
+//    |  | | |  |  |+Generic = testdata/generic.c:32:20
+//    |  | | |  |  |+Lparen = testdata/generic.c:32:28
+//    |  | | |  |  |+X = BinaryExpr
+//    |  | | |  |  | |+X = IndexExpr
+//    |  | | |  |  | | |+Arr = arr
+//    |  | | |  |  | | |+Lbrack = testdata/generic.c:32:32
+//    |  | | |  |  | | |+Index = 1
+//    |  | | |  |  | | `+Rbrack = testdata/generic.c:32:34
+//    |  | | |  |  | |+Op = "<<"<PUNCTUATOR@testdata/generic.c:32:36>
+//    |  | | |  |  | `+Y = 1L
+//    |  | | |  |  |+Assocs = 
+//    |  | | |  |  | |-GenericAssoc
+//    |  | | |  |  | | |+Type =  int
+//    |  | | |  |  | | |+Default = :0:0
+//    |  | | |  |  | | |+Colon = testdata/generic.c:32:46
+//    |  | | |  |  | | `+X = 1
+//    |  | | |  |  | `-GenericAssoc
+//    |  | | |  |  |  |+Type = <nil>
+//    |  | | |  |  |  |+Default = testdata/generic.c:32:51
+//    |  | | |  |  |  |+Colon = testdata/generic.c:32:58
+//    |  | | |  |  |  `+X = 0
+//    |  | | |  |  |+Rparen = testdata/generic.c:32:61
+//    |  | | |  |  `+Selected = 0
+//    |  | | |  |+Msg = "shift"
+//    |  | | |  `+Semicolon = testdata/generic.c:32:72
+//    |  | | |-ExprStmt
+//    |  | | | |+Expr = AssignExpr
+//    |  | | | | |+X = n
+//    |  | | | | |+Op = "="<PUNCTUATOR@testdata/generic.c:33:7>
+//    |  | | | | `+Y = GenericSelectionExpr
+//    |  | | | |  |+Generic = testdata/generic.c:33:9
+//    |  | | | |  |+Lparen = testdata/generic.c:33:17
+//    |  | | | |  |+X = i
+//    |  | | | |  |+Assocs = 
+//    |  | | | |  | |-GenericAssoc
+//    |  | | | |  | | |+Type =  int
+//    |  | | | |  | | |+Default = :0:0
+//    |  | | | |  | | |+Colon = testdata/generic.c:33:24
+//    |  | | | |  | | `+X = 1
+//    |  | | | |  | |-GenericAssoc
+//    |  | | | |  | | |+Type =  int
+//    |  | | | |  | | |+Default = :0:0
+//    |  | | | |  | | |+Colon = testdata/generic.c:33:33
+//    |  | | | |  | | `+X = 2
+//    |  | | | |  | |-GenericAssoc
+//    |  | | | |  | | |+Type = <nil>
+//    |  | | | |  | | |+Default = testdata/generic.c:33:38
+//    |  | | | |  | | |+Colon = testdata/generic.c:33:45
+//    |  | | | |  | | `+X = 3
+//    |  | | | |  | `-GenericAssoc
+//    |  | | | |  |  |+Type = <nil>
+//    |  | | | |  |  |+Default = testdata/generic.c:33:50
+//    |  | | | |  |  |+Colon = testdata/generic.c:33:57
+//    |  | | | |  |  `+X = 4
+//    |  | | | |  |+Rparen = testdata/generic.c:33:60
+//    |  | | | |  `+Selected = 0
+//    |  | | | `+Semicolon = testdata/generic.c:33:61
+//    |  | | |-ExprStmt
+//    |  | | | |+Expr = AssignExpr
+//    |  | | | | |+X = n
+//    |  | | | | |+Op = "="<PUNCTUATOR@testdata/generic.c:34:7>
+//    |  | | | | `+Y = GenericSelectionExpr
+//    |  | | | |  |+Generic = testdata/generic.c:34:9
+//    |  | | | |  |+Lparen = testdata/generic.c:34:17
+//    |  | | | |  |+X = 1.0f
+//    |  | | | |  |+Assocs = 
+//    |  | | | |  | |-GenericAssoc
+//    |  | | | |  | | |+Type =  int
+//    |  | | | |  | | |+Default = :0:0
+//    |  | | | |  | | |+Colon = testdata/generic.c:34:27
+//    |  | | | |  | | `+X = 1
+//    |  | | | |  | `-GenericAssoc
+//    |  | | | |  |  |+Type =  double
+//    |  | | | |  |  |+Default = :0:0
+//    |  | | | |  |  |+Colon = testdata/generic.c:34:38
+//    |  | | | |  |  `+X = 2
+//    |  | | | |  |+Rparen = testdata/generic.c:34:41
+//    |  | | | |  `+Selected = -1
+//    |  | | | `+Semicolon = testdata/generic.c:34:42
+//    |  | | |-ExprStmt
+//    |  | | | |+Expr = AssignExpr
+//    |  | | | | |+X = n
+//    |  | | | | |+Op = "="<PUNCTUATOR@testdata/generic.c:35:7>
+//    |  | | | | `+Y = GenericSelectionExpr
+//    |  | | | |  |+Generic = testdata/generic.c:35:9
+//    |  | | | |  |+Lparen = testdata/generic.c:35:17
+//    |  | | | |  |+X = BinaryExpr
+//    |  | | | |  | |+X = n
+//    |  | | | |  | |+Op = "+"<PUNCTUATOR@testdata/generic.c:35:20>
+//    |  | | | |  | `+Y = 1
+//    |  | | | |  |+Assocs = 
+//    |  | | | |  | `-GenericAssoc
+//    |  | | | |  |  |+Type =  int
+//    |  | | | |  |  |+Default = :0:0
+//    |  | | | |  |  |+Colon = testdata/generic.c:35:28
+//    |  | | | |  |  `+X = 1
+//    |  | | | |  |+Rparen = testdata/generic.c:35:31
+//    |  | | | |  `+Selected = 0
+//    |  | | | `+Semicolon = testdata/generic.c:35:32
+//    |  | | `-ReturnStmt
+//    |  | |  |+Return = testdata/generic.c:36:5
+//    |  | |  |+X = n
+//    |  | |  `+Semicolon = testdata/generic.c:36:13
+//    |  | `+Rbrace = testdata/generic.c:37:1
+//    |  |+InlineDef = false
+//    |  `+Attrs = 
+//    `+Unresolved = 
+// ===========================
+//
+// |-Error
+// | |+Pos = testdata/generic.c:33:29
+// | |+Typ = 0
+// | `+Msg = 在 testdata/generic.c 文件的第33行29列: 泛型关联的类型 int 与 int 兼容，上次出现的位置 testdata/generic.c:33:21
+// |-Error
+// | |+Pos = testdata/generic.c:33:50
+// | |+Typ = 0
+// | `+Msg = 在 testdata/generic.c 文件的第33行50列: 重复的 default 泛型关联，上次出现的位置 testdata/generic.c:33:38
+// `-Error
+//  |+Pos = testdata/generic.c:34:18
+//  |+Typ = 0
+//  `+Msg = 在 testdata/generic.c 文件的第34行18列: 没有与类型 float 匹配的泛型关联
+// ===========================
@@ -0,0 +1,373 @@
+struct __attribute__((packed)) pair {
+    char c;
+    int i;
+};
+
+struct wide {
+    char c;
+    int i __attribute__((aligned(16)));
+};
+
+struct loose {
+    char c;
+    int i __attribute__((packed));
+    short s;
+};
+
+struct __attribute__((packed)) header {
+    char tag;
+    int len __attribute__((aligned(8)));
+    unsigned flags : 4;
+} __attribute__((aligned(16)));
+
+struct bits {
+    char c;
+    unsigned a : 7, b : 3;
+} __attribute__((packed));
+
+int buffer[4] __attribute__((aligned(32)));
+
+_Static_assert(sizeof(struct pair) == 5 && _Alignof(struct pair) == 1, "packed struct");
+_Static_assert(__builtin_offsetof(struct pair, i) == 1, "packed member offset");
+_Static_assert(sizeof(struct wide) == 32 && _Alignof(struct wide) == 16, "aligned member");
+_Static_assert(__builtin_offsetof(struct wide, i) == 16, "aligned member offset");
+_Static_assert(sizeof(struct loose) == 8 && __builtin_offsetof(struct loose, s) == 6, "packed member");
+_Static_assert(sizeof(struct header) == 16 && __builtin_offsetof(struct header, len) == 8, "aligned in packed");
+_Static_assert(sizeof(struct bits) == 3, "packed bit-fields");
+_Static_assert(_Alignof(buffer) == 32, "aligned variable");
+
+int *__attribute__((aligned(16))) const ap;
+typedef int myint __attribute__((aligned(16)));
+int (__attribute__((aligned(8))) wrapped);
+
+_Static_assert(_Alignof(ap) == 16 && _Alignof(int *) == 8, "aligned pointer");
+_Static_assert(_Alignof(myint) == 16 && _Alignof(int) == 4, "aligned typedef");
+_Static_assert(_Alignof(wrapped) == 8, "aligned in parens");
+
+struct odd {
+    int x __attribute__((aligned(3)));
+};
+int five __attribute__((aligned(5)));
+// ===========================
+// TranslationUnit
+//  `+Files = 
+//   `-File
+//    |+Name = testdata/gnu-attribute-layout.c
+//    |+Decl = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  struct pair
+//    | | |+Name = <nil>
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  struct wide
+//    | | |+Name = <nil>
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  struct loose
+//    | | |+Name = <nil>
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  struct header
+//    | | |+Name = <nil>
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  struct bits
+//    | | |+Name = <nil>
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =   int[4]
+//    | | |+Name = buffer
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |  `-Attribute
+//    | |   |+Prefix = <nil>
+//    | |   |+Name = "aligned"<IDENT@testdata/gnu-attribute-layout.c:28:30>
+//    | |   |+Args = 
+//    | |   | `-32
+//    | |   `+Std = false
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/gnu-attribute-layout.c:30:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = BinaryExpr
+//    | | |  | |+X = SizeOfExpr
+//    | | |  | | |+Range = Range
+//    | | |  | | | |+Begin = testdata/gnu-attribute-layout.c:30:16
+//    | | |  | | | `+End = testdata/gnu-attribute-layout.c:30:34
+//    | | |  | | `+Type =  struct pair
+//    | | |  | |+Op = "=="<PUNCTUATOR@testdata/gnu-attribute-layout.c:30:36>
+//    | | |  | `+Y = 5
+//    | | |  |+Op = "&&"<PUNCTUATOR@testdata/gnu-attribute-layout.c:30:41>
+//    | | |  `+Y = BinaryExpr
+//    | | |   |+X = AlignOfExpr
+//    | | |   | |+Range = Range
+//    | | |   | | |+Begin = testdata/gnu-attribute-layout.c:30:44
+//    | | |   | | `+End = testdata/gnu-attribute-layout.c:30:64
+//    | | |   | `+Type =  struct pair
+//    | | |   |+Op = "=="<PUNCTUATOR@testdata/gnu-attribute-layout.c:30:66>
+//    | | |   `+Y = 1
+//    | | |+Msg = "packed struct"
+//    | | `+Semicolon = testdata/gnu-attribute-layout.c:30:88
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/gnu-attribute-layout.c:31:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = OffsetOfExpr
+//    | | |  | |+Builtin = testdata/gnu-attribute-layout.c:31:16
+//    | | |  | |+Type =  struct pair
+//    | | |  | |+Member = i
+//    | | |  | `+Rparen = testdata/gnu-attribute-layout.c:31:49
+//    | | |  |+Op = "=="<PUNCTUATOR@testdata/gnu-attribute-layout.c:31:51>
+//    | | |  `+Y = 1
+//    | | |+Msg = "packed member offset"
+//    | | `+Semicolon = testdata/gnu-attribute-layout.c:31:80
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/gnu-attribute-layout.c:32:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = BinaryExpr
+//    | | |  | |+X = SizeOfExpr
+//    | | |  | | |+Range = Range
+//    | | |  | | | |+Begin = testdata/gnu-attribute-layout.c:32:16
+//    | | |  | | | `+End = testdata/gnu-attribute-layout.c:32:34
+//    | | |  | | `+Type =  struct wide
+//    | | |  | |+Op = "=="<PUNCTUATOR@testdata/gnu-attribute-layout.c:32:36>
+//    | | |  | `+Y = 32
+//    | | |  |+Op = "&&"<PUNCTUATOR@testdata/gnu-attribute-layout.c:32:42>
+//    | | |  `+Y = BinaryExpr
+//    | | |   |+X = AlignOfExpr
+//    | | |   | |+Range = Range
+//    | | |   | | |+Begin = testdata/gnu-attribute-layout.c:32:45
+//    | | |   | | `+End = testdata/gnu-attribute-layout.c:32:65
+//    | | |   | `+Type =  struct wide
+//    | | |   |+Op = "=="<PUNCTUATOR@testdata/gnu-attribute-layout.c:32:67>
+//    | | |   `+Y = 16
+//    | | |+Msg = "aligned member"
+//    | | `+Semicolon = testdata/gnu-attribute-layout.c:32:91
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/gnu-attribute-layout.c:33:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = OffsetOfExpr
+//    | | |  | |+Builtin = testdata/gnu-attribute-layout.c:33:16
+//    | | |  | |+Type =  struct wide
+//    | | |  | |+Member = i
+//    | | |  | `+Rparen = testdata/gnu-attribute-layout.c:33:49
+//    | | |  |+Op = "=="<PUNCTUATOR@testdata/gnu-attribute-layout.c:33:51>
+//    | | |  `+Y = 16
+//    | | |+Msg = "aligned member offset"
+//    | | `+Semicolon = testdata/gnu-attribute-layout.c:33:82
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/gnu-attribute-layout.c:34:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = BinaryExpr
+//    | | |  | |+X = SizeOfExpr
+//    | | |  | | |+Range = Range
+//    | | |  | | | |+Begin = testdata/gnu-attribute-layout.c:34:16
+//    | | |  | | | `+End = testdata/gnu-attribute-layout.c:34:35
+//    | | |  | | `+Type =  struct loose
+//    | | |  | |+Op = "=="<PUNCTUATOR@testdata/gnu-attribute-layout.c:34:37>
+//    | | |  | `+Y = 8
+//    | | |  |+Op = "&&"<PUNCTUATOR@testdata/gnu-attribute-layout.c:34:42>
+//    | | |  `+Y = BinaryExpr
+//    | | |   |+X = OffsetOfExpr
+//    | | |   | |+Builtin = testdata/gnu-attribute-layout.c:34:45
+//    | | |   | |+Type =  struct loose
+//    | | |   | |+Member = s
+//    | | |   | `+Rparen = testdata/gnu-attribute-layout.c:34:79
+//    | | |   |+Op = "=="<PUNCTUATOR@testdata/gnu-attribute-layout.c:34:81>
+//    | | |   `+Y = 6
+//    | | |+Msg = "packed member"
+//    | | `+Semicolon = testdata/gnu-attribute-layout.c:34:103
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/gnu-attribute-layout.c:35:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = BinaryExpr
+//    | | |  | |+X = SizeOfExpr
+//    | | |  | | |+Range = Range
+//    | | |  | | | |+Begin = testdata/gnu-attribute-layout.c:35:16
+//    | | |  | | | `+End = testdata/gnu-attribute-layout.c:35:36
+//    | | |  | | `+Type =  struct header
+//    | | |  | |+Op = "=="<PUNCTUATOR@testdata/gnu-attribute-layout.c:35:38>
+//    | | |  | `+Y = 16
+//    | | |  |+Op = "&&"<PUNCTUATOR@testdata/gnu-attribute-layout.c:35:44>
+//    | | |  `+Y = BinaryExpr
+//    | | |   |+X = OffsetOfExpr
+//    | | |   | |+Builtin = testdata/gnu-attribute-layout.c:35:47
+//    | | |   | |+Type =  struct header
+//    | | |   | |+Member = len
+//    | | |   | `+Rparen = testdata/gnu-attribute-layout.c:35:84
+//    | | |   |+Op = "=="<PUNCTUATOR@testdata/gnu-attribute-layout.c:35:86>
+//    | | |   `+Y = 8
+//    | | |+Msg = "aligned in packed"
+//    | | `+Semicolon = testdata/gnu-attribute-layout.c:35:112
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/gnu-attribute-layout.c:36:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = SizeOfExpr
+//    | | |  | |+Range = Range
+//    | | |  | | |+Begin = testdata/gnu-attribute-layout.c:36:16
+//    | | |  | | `+End = testdata/gnu-attribute-layout.c:36:34
+//    | | |  | `+Type =  struct bits
+//    | | |  |+Op = "=="<PUNCTUATOR@testdata/gnu-attribute-layout.c:36:36>
+//    | | |  `+Y = 3
+//    | | |+Msg = "packed bit-fields"
+//    | | `+Semicolon = testdata/gnu-attribute-layout.c:36:62
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/gnu-attribute-layout.c:37:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = UnaryExpr
+//    | | |  | |+Op = "_Alignof"<KEYWORD@testdata/gnu-attribute-layout.c:37:16>
+//    | | |  | `+X = ParenExpr
+//    | | |  |  |+Lparen = testdata/gnu-attribute-layout.c:37:24
+//    | | |  |  |+X = buffer
+//    | | |  |  `+Rparen = testdata/gnu-attribute-layout.c:37:31
+//    | | |  |+Op = "=="<PUNCTUATOR@testdata/gnu-attribute-layout.c:37:33>
+//    | | |  `+Y = 32
+//    | | |+Msg = "aligned variable"
+//    | | `+Semicolon = testdata/gnu-attribute-layout.c:37:59
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  int *const
+//    | | |+Name = ap
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-TypedefDecl
+//    | | |+Typedef = testdata/gnu-attribute-layout.c:40:1
+//    | | |+Type =  int
+//    | | |+Name = myint
+//    | | `+Attrs = 
+//    | |  `-Attribute
+//    | |   |+Prefix = <nil>
+//    | |   |+Name = "aligned"<IDENT@testdata/gnu-attribute-layout.c:40:34>
+//    | |   |+Args = 
+//    | |   | `-16
+//    | |   `+Std = false
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type = ( int)
+//    | | |+Name = wrapped
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |  `-Attribute
+//    | |   |+Prefix = <nil>
+//    | |   |+Name = "aligned"<IDENT@testdata/gnu-attribute-layout.c:41:21>
+//    | |   |+Args = 
+//    | |   | `-8
+//    | |   `+Std = false
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/gnu-attribute-layout.c:43:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = BinaryExpr
+//    | | |  | |+X = UnaryExpr
+//    | | |  | | |+Op = "_Alignof"<KEYWORD@testdata/gnu-attribute-layout.c:43:16>
+//    | | |  | | `+X = ParenExpr
+//    | | |  | |  |+Lparen = testdata/gnu-attribute-layout.c:43:24
+//    | | |  | |  |+X = ap
+//    | | |  | |  `+Rparen = testdata/gnu-attribute-layout.c:43:27
+//    | | |  | |+Op = "=="<PUNCTUATOR@testdata/gnu-attribute-layout.c:43:29>
+//    | | |  | `+Y = 16
+//    | | |  |+Op = "&&"<PUNCTUATOR@testdata/gnu-attribute-layout.c:43:35>
+//    | | |  `+Y = BinaryExpr
+//    | | |   |+X = AlignOfExpr
+//    | | |   | |+Range = Range
+//    | | |   | | |+Begin = testdata/gnu-attribute-layout.c:43:38
+//    | | |   | | `+End = testdata/gnu-attribute-layout.c:43:52
+//    | | |   | `+Type =  int *
+//    | | |   |+Op = "=="<PUNCTUATOR@testdata/gnu-attribute-layout.c:43:54>
+//    | | |   `+Y = 8
+//    | | |+Msg = "aligned pointer"
+//    | | `+Semicolon = testdata/gnu-attribute-layout.c:43:78
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/gnu-attribute-layout.c:44:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = BinaryExpr
+//    | | |  | |+X = AlignOfExpr
+//    | | |  | | |+Range = Range
+//    | | |  | | | |+Begin = testdata/gnu-attribute-layout.c:44:16
+//    | | |  | | | `+End = testdata/gnu-attribute-layout.c:44:30
+//    | | |  | | `+Type =  int
+//    | | |  | |+Op = "=="<PUNCTUATOR@testdata/gnu-attribute-layout.c:44:32>
+//    | | |  | `+Y = 16
+//    | | |  |+Op = "&&"<PUNCTUATOR@testdata/gnu-attribute-layout.c:44:38>
+//    | | |  `+Y = BinaryExpr
+//    | | |   |+X = AlignOfExpr
+//    | | |   | |+Range = Range
+//    | | |   | | |+Begin = testdata/gnu-attribute-layout.c:44:41
+//    | | |   | | `+End = testdata/gnu-attribute-layout.c:44:53
+//    | | |   | `+Type =  int
+//    | | |   |+Op = "=="<PUNCTUATOR@testdata/gnu-attribute-layout.c:44:55>
+//    | | |   `+Y = 4
+//    | | |+Msg = "aligned typedef"
+//    | | `+Semicolon = testdata/gnu-attribute-layout.c:44:79
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/gnu-attribute-layout.c:45:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = UnaryExpr
+//    | | |  | |+Op = "_Alignof"<KEYWORD@testdata/gnu-attribute-layout.c:45:16>
+//    | | |  | `+X = ParenExpr
+//    | | |  |  |+Lparen = testdata/gnu-attribute-layout.c:45:24
+//    | | |  |  |+X = wrapped
+//    | | |  |  `+Rparen = testdata/gnu-attribute-layout.c:45:32
+//    | | |  |+Op = "=="<PUNCTUATOR@testdata/gnu-attribute-layout.c:45:34>
+//    | | |  `+Y = 8
+//    | | |+Msg = "aligned in parens"
+//    | | `+Semicolon = testdata/gnu-attribute-layout.c:45:60
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  struct odd
+//    | | |+Name = <nil>
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | `-VarDecl
+//    |  |+Qua = map[]
+//    |  |+Type =  int
+//    |  |+Name = five
+//    |  |+Init = <nil>
+//    |  |+Align = 
+//    |  `+Attrs = 
+//    |   `-Attribute
+//    |    |+Prefix = <nil>
+//    |    |+Name = "aligned"<IDENT@testdata/gnu-attribute-layout.c:50:25>
+//    |    |+Args = 
+//    |    | `-5
+//    |    `+Std = false
+//    `+Unresolved = 
+// ===========================
+//
+// |-Error
+// | |+Pos = testdata/gnu-attribute-layout.c:48:34
+// | |+Typ = 0
+// | `+Msg = 在 testdata/gnu-attribute-layout.c 文件的第48行34列: 对齐值 3 不是 2 的幂
+// `-Error
+//  |+Pos = testdata/gnu-attribute-layout.c:50:33
+//  |+Typ = 0
+//  `+Msg = 在 testdata/gnu-attribute-layout.c 文件的第50行33列: 对齐值 5 不是 2 的幂
+// ===========================
@@ -0,0 +1,447 @@
+struct point {
+    int x;
+    int y;
+    __extension__ union {
+        long tag;
+        char name[8];
+    };
+    int coords[4];
+};
+
+typedef __builtin_va_list va_list;
+__extension__ typedef long long int64;
+
+_Static_assert(__builtin_offsetof(struct point, y) == 4, "y");
+_Static_assert(__builtin_offsetof(struct point, name[2]) == 10, "name");
+_Static_assert(__builtin_offsetof(struct point, coords[1]) == 20, "coords");
+_Static_assert((0 ?: 3) == 3, "elvis");
+
+int sum(int n, ...) {
+    va_list ap, aq;
+    int total = 0;
+    __builtin_va_start(ap, n);
+    __builtin_va_copy(aq, ap);
+    while (n--)
+        total += __builtin_va_arg(ap, int);
+    __builtin_va_end(aq);
+    __builtin_va_end(ap);
+    return total;
+}
+
+int classify(int c) {
+    typeof(c) copy = c;
+    __typeof__(int *) ptr = &copy;
+    int max = ({ int a = *ptr; int b = 10; a > b ? a : b; });
+    int64 wide = __extension__ 1LL;
+    switch (max) {
+    case 0 ... 9:
+        return 1;
+    case 'a' ... 'z':
+        return 2;
+    case 9 ... 0:
+        break;
+    }
+    void *next = &&done;
+    goto *next;
+done:
+    return (int) wide ?: max;
+}
+
+int nowhere(void) {
+    return &&;
+}
+
+int bad = ({ 1; });
+_Static_assert(__builtin_offsetof(struct point, z) == 0, "z");
+// ===========================
+// TranslationUnit
+//  `+Files = 
+//   `-File
+//    |+Name = testdata/gnu-extension.c
+//    |+Decl = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  struct point
+//    | | |+Name = <nil>
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-TypedefDecl
+//    | | |+Typedef = testdata/gnu-extension.c:11:1
+//    | | |+Type =  void *
+//    | | |+Name = va_list
+//    | | `+Attrs = 
+//    | |-TypedefDecl
+//    | | |+Typedef = testdata/gnu-extension.c:12:15
+//    | | |+Type =  long long
+//    | | |+Name = int64
+//    | | `+Attrs = 
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/gnu-extension.c:14:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = OffsetOfExpr
+//    | | |  | |+Builtin = testdata/gnu-extension.c:14:16
+//    | | |  | |+Type =  struct point
+//    | | |  | |+Member = y
+//    | | |  | `+Rparen = testdata/gnu-extension.c:14:50
+//    | | |  |+Op = "=="<PUNCTUATOR@testdata/gnu-extension.c:14:52>
+//    | | |  `+Y = 4
+//    | | |+Msg = "y"
+//    | | `+Semicolon = testdata/gnu-extension.c:14:62
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/gnu-extension.c:15:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = OffsetOfExpr
+//    | | |  | |+Builtin = testdata/gnu-extension.c:15:16
+//    | | |  | |+Type =  struct point
+//    | | |  | |+Member = IndexExpr
+//    | | |  | | |+Arr = name
+//    | | |  | | |+Lbrack = testdata/gnu-extension.c:15:53
+//    | | |  | | |+Index = 2
+//    | | |  | | `+Rbrack = testdata/gnu-extension.c:15:55
+//    | | |  | `+Rparen = testdata/gnu-extension.c:15:56
+//    | | |  |+Op = "=="<PUNCTUATOR@testdata/gnu-extension.c:15:58>
+//    | | |  `+Y = 10
+//    | | |+Msg = "name"
+//    | | `+Semicolon = testdata/gnu-extension.c:15:72
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/gnu-extension.c:16:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = OffsetOfExpr
+//    | | |  | |+Builtin = testdata/gnu-extension.c:16:16
+//    | | |  | |+Type =  struct point
+//    | | |  | |+Member = IndexExpr
+//    | | |  | | |+Arr = coords
+//    | | |  | | |+Lbrack = testdata/gnu-extension.c:16:55
+//    | | |  | | |+Index = 1
+//    | | |  | | `+Rbrack = testdata/gnu-extension.c:16:57
+//    | | |  | `+Rparen = testdata/gnu-extension.c:16:58
+//    | | |  |+Op = "=="<PUNCTUATOR@testdata/gnu-extension.c:16:60>
+//    | | |  `+Y = 20
+//    | | |+Msg = "coords"
+//    | | `+Semicolon = testdata/gnu-extension.c:16:76
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/gnu-extension.c:17:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = ParenExpr
+//    | | |  | |+Lparen = testdata/gnu-extension.c:17:16
+//    | | |  | |+X = BinaryCondExpr
+//    | | |  | | |+X = 0
+//    | | |  | | |+Op = "?"<PUNCTUATOR@testdata/gnu-extension.c:17:19>
+//    | | |  | | |+Colon = testdata/gnu-extension.c:17:20
+//    | | |  | | `+Else = 3
+//    | | |  | `+Rparen = testdata/gnu-extension.c:17:23
+//    | | |  |+Op = "=="<PUNCTUATOR@testdata/gnu-extension.c:17:25>
+//    | | |  `+Y = 3
+//    | | |+Msg = "elvis"
+//    | | `+Semicolon = testdata/gnu-extension.c:17:39
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[]
+//    | | |+Name = sum
+//    | | |+Type =  int ( int,...)
+//    | | |+Decl = 
+//    | | |+Body = CompoundStmt
+//    | | | |+Lbrace = testdata/gnu-extension.c:19:21
+//    | | | |+Stmts = 
+//    | | | | |-DeclStmt
+//    | | | | | |-VarDecl
+//    | | | | | | |+Qua = map[]
+//    | | | | | | |+Type =  void *
+//    | | | | | | |+Name = ap
+//    | | | | | | |+Init = <nil>
+//    | | | | | | |+Align = 
+//    | | | | | | `+Attrs = 
+//    | | | | | `-VarDecl
+//    | | | | |  |+Qua = map[]
+//    | | | | |  |+Type =  void *
+//    | | | | |  |+Name = aq
+//    | | | | |  |+Init = <nil>
+//    | | | | |  |+Align = 
+//    | | | | |  `+Attrs = 
+//    | | | | |-DeclStmt
+//    | | | | | `-VarDecl
+//    | | | | |  |+Qua = map[]
+//    | | | | |  |+Type =  int
+//    | | | | |  |+Name = total
+//    | | | | |  |+Init = 0
+//    | | | | |  |+Align = 
+//    | | | | |  `+Attrs = 
+//    | | | | |-ExprStmt
+//    | | | | | |+Expr = CallExpr
+//    | | | | | | |+Func = __builtin_va_start
+//    | | | | | | |+Lparen = testdata/gnu-extension.c:22:23
+//    | | | | | | |+Args = 
+//    | | | | | | | |-ap
+//    | | | | | | | `-n
+//    | | | | | | `+Rparen = testdata/gnu-extension.c:22:29
+//    | | | | | `+Semicolon = testdata/gnu-extension.c:22:30
+//    | | | | |-ExprStmt
+//    | | | | | |+Expr = CallExpr
+//    | | | | | | |+Func = __builtin_va_copy
+//    | | | | | | |+Lparen = testdata/gnu-extension.c:23:22
+//    | | | | | | |+Args = 
+//    | | | | | | | |-aq
+//    | | | | | | | `-ap
+//    | | | | | | `+Rparen = testdata/gnu-extension.c:23:29
+//    | | | | | `+Semicolon = testdata/gnu-extension.c:23:30
+//    | | | | |-WhileStmt
+//    | | | | | |+While = testdata/gnu-extension.c:24:5
+//    | | | | | |+X = UnaryExpr
+//    | | | | | | |+Op = "--"<PUNCTUATOR@testdata/gnu-extension.c:24:13>
+//    | | | | | | `+X = n
+//    | | | | | `+Stmt = ExprStmt
+//    | | | | |  |+Expr = AssignExpr
+//    | | | | |  | |+X = total
+//    | | | | |  | |+Op = "+="<PUNCTUATOR@testdata/gnu-extension.c:25:15>
+//    | | | | |  | `+Y = VaArgExpr
+//    | | | | |  |  |+Builtin = testdata/gnu-extension.c:25:18
+//    | | | | |  |  |+X = ap
+//    | | | | |  |  |+Type =  int
+//    | | | | |  |  `+Rparen = testdata/gnu-extension.c:25:42
+//    | | | | |  `+Semicolon = testdata/gnu-extension.c:25:43
+//    | | | | |-ExprStmt
+//    | | | | | |+Expr = CallExpr
+//    | | | | | | |+Func = __builtin_va_end
+//    | | | | | | |+Lparen = testdata/gnu-extension.c:26:21
+//    | | | | | | |+Args = 
+//    | | | | | | | `-aq
+//    | | | | | | `+Rparen = testdata/gnu-extension.c:26:24
+//    | | | | | `+Semicolon = testdata/gnu-extension.c:26:25
+//    | | | | |-ExprStmt
+//    | | | | | |+Expr = CallExpr
+//    | | | | | | |+Func = __builtin_va_end
+//    | | | | | | |+Lparen = testdata/gnu-extension.c:27:21
+//    | | | | | | |+Args = 
+//    | | | | | | | `-ap
+//    | | | | | | `+Rparen = testdata/gnu-extension.c:27:24
+//    | | | | | `+Semicolon = testdata/gnu-extension.c:27:25
+//    | | | | `-ReturnStmt
+//    | | | |  |+Return = testdata/gnu-extension.c:28:5
+//    | | | |  |+X = total
+//    | | | |  `+Semicolon = testdata/gnu-extension.c:28:17
+//    | | | `+Rbrace = testdata/gnu-extension.c:29:1
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[]
+//    | | |+Name = classify
+//    | | |+Type =  int ( int)
+//    | | |+Decl = 
+//    | | |+Body = CompoundStmt
+//    | | | |+Lbrace = testdata/gnu-extension.c:31:21
+//    | | | |+Stmts = 
+//    | | | | |-DeclStmt
+//    | | | | | `-VarDecl
+//    | | | | |  |+Qua = map[]
+//    | | | | |  |+Type =  typeof( int)
+//    | | | | |  |+Name = copy
+//    | | | | |  |+Init = c
+//    | | | | |  |+Align = 
+//    | | | | |  `+Attrs = 
+//    | | | | |-DeclStmt
+//    | | | | | `-VarDecl
+//    | | | | |  |+Qua = map[]
+//    | | | | |  |+Type =  typeof( int *)
+//    | | | | |  |+Name = ptr
+//    | | | | |  |+Init = UnaryExpr
+//    | | | | |  | |+Op = "&"<PUNCTUATOR@testdata/gnu-extension.c:33:29>
+//    | | | | |  | `+X = copy
+//    | | | | |  |+Align = 
+//    | | | | |  `+Attrs = 
+//    | | | | |-DeclStmt
+//    | | | | | `-VarDecl
+//    | | | | |  |+Qua = map[]
+//    | | | | |  |+Type =  int
+//    | | | | |  |+Name = max
+//    | | | | |  |+Init = StmtExpr
+//    | | | | |  | |+Lparen = testdata/gnu-extension.c:34:15
+//    | | | | |  | |+Body = CompoundStmt
+//    | | | | |  | | |+Lbrace = testdata/gnu-extension.c:34:16
+//    | | | | |  | | |+Stmts = 
+//    | | | | |  | | | |-DeclStmt
+//    | | | | |  | | | | `-VarDecl
+//    | | | | |  | | | |  |+Qua = map[]
+//    | | | | |  | | | |  |+Type =  int
+//    | | | | |  | | | |  |+Name = a
+//    | | | | |  | | | |  |+Init = UnaryExpr
+//    | | | | |  | | | |  | |+Op = "*"<PUNCTUATOR@testdata/gnu-extension.c:34:26>
+//    | | | | |  | | | |  | `+X = ptr
+//    | | | | |  | | | |  |+Align = 
+//    | | | | |  | | | |  `+Attrs = 
+//    | | | | |  | | | |-DeclStmt
+//    | | | | |  | | | | `-VarDecl
+//    | | | | |  | | | |  |+Qua = map[]
+//    | | | | |  | | | |  |+Type =  int
+//    | | | | |  | | | |  |+Name = b
+//    | | | | |  | | | |  |+Init = 10
+//    | | | | |  | | | |  |+Align = 
+//    | | | | |  | | | |  `+Attrs = 
+//    | | | | |  | | | `-ExprStmt
+//    | | | | |  | | |  |+Expr = CondExpr
+//    | | | | |  | | |  | |+X = BinaryExpr
+//    | | | | |  | | |  | | |+X = a
+//    | | | | |  | | |  | | |+Op = ">"<PUNCTUATOR@testdata/gnu-extension.c:34:46>
+//    | | | | |  | | |  | | `+Y = b
+//    | | | | |  | | |  | |+Op = "?"<PUNCTUATOR@testdata/gnu-extension.c:34:50>
+//    | | | | |  | | |  | |+Then = a
+//    | | | | |  | | |  | `+Else = b
+//    | | | | |  | | |  `+Semicolon = testdata/gnu-extension.c:34:57
+//    | | | | |  | | `+Rbrace = testdata/gnu-extension.c:34:59
+//    | | | | |  | `+Rparen = testdata/gnu-extension.c:34:60
+//    | | | | |  |+Align = 
+//    | | | | |  `+Attrs = 
+//    | | | | |-DeclStmt
+//    | | | | | `-VarDecl
+//    | | | | |  |+Qua = map[]
+//    | | | | |  |+Type =  long long
+//    | | | | |  |+Name = wide
+//    | | | | |  |+Init = ExtensionExpr
+//    | | | | |  | |+Extension = testdata/gnu-extension.c:35:18
+//    | | | | |  | `+X = 1LL
+//    | | | | |  |+Align = 
+//    | | | | |  `+Attrs = 
+//    | | | | |-SwitchStmt
+//    | | | | | |+Switch = testdata/gnu-extension.c:36:5
+//    | | | | | |+X = max
+//    | | | | | `+Stmt = CompoundStmt
+//    | | | | |  |+Lbrace = testdata/gnu-extension.c:36:18
+//    | | | | |  |+Stmts = 
+//    | | | | |  | |-CaseRangeStmt
+//    | | | | |  | | |+Case = testdata/gnu-extension.c:37:5
+//    | | | | |  | | |+Low = ConstantExpr
+//    | | | | |  | | | `+X = 0
+//    | | | | |  | | |+Ellipsis = testdata/gnu-extension.c:37:12
+//    | | | | |  | | |+High = ConstantExpr
+//    | | | | |  | | | `+X = 9
+//    | | | | |  | | `+Stmt = ReturnStmt
+//    | | | | |  | |  |+Return = testdata/gnu-extension.c:38:9
+//    | | | | |  | |  |+X = 1
+//    | | | | |  | |  `+Semicolon = testdata/gnu-extension.c:38:17
+//    | | | | |  | |-CaseRangeStmt
+//    | | | | |  | | |+Case = testdata/gnu-extension.c:39:5
+//    | | | | |  | | |+Low = ConstantExpr
+//    | | | | |  | | | `+X = 'a'
+//    | | | | |  | | |+Ellipsis = testdata/gnu-extension.c:39:14
+//    | | | | |  | | |+High = ConstantExpr
+//    | | | | |  | | | `+X = 'z'
+//    | | | | |  | | `+Stmt = ReturnStmt
+//    | | | | |  | |  |+Return = testdata/gnu-extension.c:40:9
+//    | | | | |  | |  |+X = 2
+//    | | | | |  | |  `+Semicolon = testdata/gnu-extension.c:40:17
+//    | | | | |  | `-CaseRangeStmt
+//    | | | | |  |  |+Case = testdata/gnu-extension.c:41:5
+//    | | | | |  |  |+Low = ConstantExpr
+//    | | | | |  |  | `+X = 9
+//    | | | | |  |  |+Ellipsis = testdata/gnu-extension.c:41:12
+//    | | | | |  |  |+High = ConstantExpr
+//    | | | | |  |  | `+X = 0
+//    | | | | |  |  `+Stmt = BreakStmt
+//    | | | | |  |   |+Break = testdata/gnu-extension.c:42:9
+//    | | | | |  |   `+Semicolon = testdata/gnu-extension.c:42:14
+//    | | | | |  `+Rbrace = testdata/gnu-extension.c:43:5
+//    | | | | |-DeclStmt
+//    | | | | | `-VarDecl
+//    | | | | |  |+Qua = map[]
+//    | | | | |  |+Type =  void *
+//    | | | | |  |+Name = next
+//    | | | | |  |+Init = LabelAddrExpr
+//    | | | | |  | |+AndAnd = testdata/gnu-extension.c:44:18
+//    | | | | |  | `+Label = done
+//    | | | | |  |+Align = 
+//    | | | | |  `+Attrs = 
+//    | | | | |-IndirectGotoStmt
+//    | | | | | |+Goto = testdata/gnu-extension.c:45:5
+//    | | | | | |+Star = testdata/gnu-extension.c:45:10
+//    | | | | | |+X = next
+//    | | | | | `+Semicolon = testdata/gnu-extension.c:45:15
+//    | | | | `-LabelStmt
+//    | | | |  |+Id = done
+//    | | | |  |+Attrs = 
+//    | | | |  `+Stmt = ReturnStmt
+//    | | | |   |+Return = testdata/gnu-extension.c:47:5
+//    | | | |   |+X = BinaryCondExpr
+//    | | | |   | |+X = TypeCastExpr
+//    | | | |   | | |+Lparen = testdata/gnu-extension.c:47:12
+//    | | | |   | | |+Type =  int
+//    | | | |   | | |+Rparen = testdata/gnu-extension.c:47:16
+//    | | | |   | | `+X = wide
+//    | | | |   | |+Op = "?"<PUNCTUATOR@testdata/gnu-extension.c:47:23>
+//    | | | |   | |+Colon = testdata/gnu-extension.c:47:24
+//    | | | |   | `+Else = max
+//    | | | |   `+Semicolon = testdata/gnu-extension.c:47:29
+//    | | | `+Rbrace = testdata/gnu-extension.c:48:1
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[]
+//    | | |+Name = nowhere
+//    | | |+Type =  int ( void)
+//    | | |+Decl = 
+//    | | |+Body = CompoundStmt
+//    | | | |+Lbrace = testdata/gnu-extension.c:50:19
+//    | | | |+Stmts = 
+//    | | | | `-ReturnStmt
+//    | | | |  |+Return = testdata/gnu-extension.c:51:5
+//    | | | |  |+X = LabelAddrExpr
+//    | | | |  | |+AndAnd = testdata/gnu-extension.c:51:12
+//    | | | |  | `+Label = ;
+//    | | | |  `+Semicolon = testdata/gnu-extension.c:51:14
+//    | | | `+Rbrace = testdata/gnu-extension.c:52:1
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  int
+//    | | |+Name = bad
+//    | | |+Init = StmtExpr
+//    | | | |+Lparen = testdata/gnu-extension.c:54:11
+//    | | | |+Body = CompoundStmt
+//    | | | | |+Lbrace = testdata/gnu-extension.c:54:12
+//    | | | | |+Stmts = 
+//    | | | | | `-ExprStmt
+//    | | | | |  |+Expr = 1
+//    | | | | |  `+Semicolon = testdata/gnu-extension.c:54:15
+//    | | | | `+Rbrace = testdata/gnu-extension.c:54:17
+//    | | | `+Rparen = testdata/gnu-extension.c:54:18
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | `-StaticAssertDecl
+//    |  |+StaticAssert = testdata/gnu-extension.c:55:1
+//    |  |+Cond = ConstantExpr
+//    |  | `+X = BinaryExpr
+//    |  |  |+X = OffsetOfExpr
+//    |  |  | |+Builtin = testdata/gnu-extension.c:55:16
+//    |  |  | |+Type =  struct point
+//    |  |  | |+Member = z
+//    |  |  | `+Rparen = testdata/gnu-extension.c:55:50
+//    |  |  |+Op = "=="<PUNCTUATOR@testdata/gnu-extension.c:55:52>
+//    |  |  `+Y = 0
+//    |  |+Msg = "z"
+//    |  `+Semicolon = testdata/gnu-extension.c:55:62
+//    `+Unresolved = 
+// ===========================
+//
+// |-Error
+// | |+Pos = testdata/gnu-extension.c:41:12
+// | |+Typ = 1
+// | `+Msg = 在 testdata/gnu-extension.c 文件的第41行12列: case 范围 9 ... 0 为空
+// |-Error
+// | |+Pos = testdata/gnu-extension.c:51:14
+// | |+Typ = 0
+// | `+Msg = 在 testdata/gnu-extension.c 文件的第51行14列: 这里应该是一个名称，不应该出现 ; 符号
+// |-Error
+// | |+Pos = testdata/gnu-extension.c:54:11
+// | |+Typ = 0
+// | `+Msg = 在 testdata/gnu-extension.c 文件的第54行11列: 语句表达式只能在函数内使用
+// `-Error
+//  |+Pos = testdata/gnu-extension.c:55:49
+//  |+Typ = 0
+//  `+Msg = 在 testdata/gnu-extension.c 文件的第55行49列: struct point 中没有名为 z 的成员
+// ===========================
@@ -0,0 +1,306 @@
+struct pair {
+    int a;
+    int b[2];
+};
+
+int pick(int x, int y) {
+    int m = ({ __label__ out; int r = x; if (r > y) goto out; r = y; out: r; });
+    int n = ({ __label__ out, skip; int s = m; if (s > 3) goto out; s = 3; out: s; });
+    {
+        __label__ done;
+        goto done;
+    }
+    goto out;
+done:
+    return m + n;
+}
+
+int twice(int x) {
+    {
+        __label__ again;
+        __label__ again;
+    again:
+        x++;
+    }
+    int y = x;
+    __label__ late;
+    return y;
+}
+
+int member(struct pair *p, int i) {
+    return __builtin_offsetof(struct pair, c) + __builtin_offsetof(struct pair, b[i]) + __builtin_offsetof(struct pair, a[0]);
+}
+// ===========================
+// TranslationUnit
+//  `+Files = 
+//   `-File
+//    |+Name = testdata/gnu-local-label.c
+//    |+Decl = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  struct pair
+//    | | |+Name = <nil>
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[]
+//    | | |+Name = pick
+//    | | |+Type =  int ( int, int)
+//    | | |+Decl = 
+//    | | |+Body = CompoundStmt
+//    | | | |+Lbrace = testdata/gnu-local-label.c:6:24
+//    | | | |+Stmts = 
+//    | | | | |-DeclStmt
+//    | | | | | `-VarDecl
+//    | | | | |  |+Qua = map[]
+//    | | | | |  |+Type =  int
+//    | | | | |  |+Name = m
+//    | | | | |  |+Init = StmtExpr
+//    | | | | |  | |+Lparen = testdata/gnu-local-label.c:7:13
+//    | | | | |  | |+Body = CompoundStmt
+//    | | | | |  | | |+Lbrace = testdata/gnu-local-label.c:7:14
+//    | | | | |  | | |+Stmts = 
+//    | | | | |  | | | |-LabelDeclStmt
+//    | | | | |  | | | | |+Label = testdata/gnu-local-label.c:7:16
+//    | | | | |  | | | | |+Names = 
+//    | | | | |  | | | | | `-out
+//    | | | | |  | | | | `+Semicolon = testdata/gnu-local-label.c:7:29
+//    | | | | |  | | | |-DeclStmt
+//    | | | | |  | | | | `-VarDecl
+//    | | | | |  | | | |  |+Qua = map[]
+//    | | | | |  | | | |  |+Type =  int
+//    | | | | |  | | | |  |+Name = r
+//    | | | | |  | | | |  |+Init = x
+//    | | | | |  | | | |  |+Align = 
+//    | | | | |  | | | |  `+Attrs = 
+//    | | | | |  | | | |-IfStmt
+//    | | | | |  | | | | |+If = testdata/gnu-local-label.c:7:42
+//    | | | | |  | | | | |+X = BinaryExpr
+//    | | | | |  | | | | | |+X = r
+//    | | | | |  | | | | | |+Op = ">"<PUNCTUATOR@testdata/gnu-local-label.c:7:48>
+//    | | | | |  | | | | | `+Y = y
+//    | | | | |  | | | | |+Then = GotoStmt
+//    | | | | |  | | | | | |+Goto = testdata/gnu-local-label.c:7:53
+//    | | | | |  | | | | | |+Id = out
+//    | | | | |  | | | | | `+Semicolon = testdata/gnu-local-label.c:7:61
+//    | | | | |  | | | | `+Else = <nil>
+//    | | | | |  | | | |-ExprStmt
+//    | | | | |  | | | | |+Expr = AssignExpr
+//    | | | | |  | | | | | |+X = r
+//    | | | | |  | | | | | |+Op = "="<PUNCTUATOR@testdata/gnu-local-label.c:7:65>
+//    | | | | |  | | | | | `+Y = y
+//    | | | | |  | | | | `+Semicolon = testdata/gnu-local-label.c:7:68
+//    | | | | |  | | | `-LabelStmt
+//    | | | | |  | | |  |+Id = out
+//    | | | | |  | | |  |+Attrs = 
+//    | | | | |  | | |  `+Stmt = ExprStmt
+//    | | | | |  | | |   |+Expr = r
+//    | | | | |  | | |   `+Semicolon = testdata/gnu-local-label.c:7:76
+//    | | | | |  | | `+Rbrace = testdata/gnu-local-label.c:7:78
+//    | | | | |  | `+Rparen = testdata/gnu-local-label.c:7:79
+//    | | | | |  |+Align = 
+//    | | | | |  `+Attrs = 
+//    | | | | |-DeclStmt
+//    | | | | | `-VarDecl
+//    | | | | |  |+Qua = map[]
+//    | | | | |  |+Type =  int
+//    | | | | |  |+Name = n
+//    | | | | |  |+Init = StmtExpr
+//    | | | | |  | |+Lparen = testdata/gnu-local-label.c:8:13
+//    | | | | |  | |+Body = CompoundStmt
+//    | | | | |  | | |+Lbrace = testdata/gnu-local-label.c:8:14
+//    | | | | |  | | |+Stmts = 
+//    | | | | |  | | | |-LabelDeclStmt
+//    | | | | |  | | | | |+Label = testdata/gnu-local-label.c:8:16
+//    | | | | |  | | | | |+Names = 
+//    | | | | |  | | | | | |-out
+//    | | | | |  | | | | | `-skip
+//    | | | | |  | | | | `+Semicolon = testdata/gnu-local-label.c:8:35
+//    | | | | |  | | | |-DeclStmt
+//    | | | | |  | | | | `-VarDecl
+//    | | | | |  | | | |  |+Qua = map[]
+//    | | | | |  | | | |  |+Type =  int
+//    | | | | |  | | | |  |+Name = s
+//    | | | | |  | | | |  |+Init = m
+//    | | | | |  | | | |  |+Align = 
+//    | | | | |  | | | |  `+Attrs = 
+//    | | | | |  | | | |-IfStmt
+//    | | | | |  | | | | |+If = testdata/gnu-local-label.c:8:48
+//    | | | | |  | | | | |+X = BinaryExpr
+//    | | | | |  | | | | | |+X = s
+//    | | | | |  | | | | | |+Op = ">"<PUNCTUATOR@testdata/gnu-local-label.c:8:54>
+//    | | | | |  | | | | | `+Y = 3
+//    | | | | |  | | | | |+Then = GotoStmt
+//    | | | | |  | | | | | |+Goto = testdata/gnu-local-label.c:8:59
+//    | | | | |  | | | | | |+Id = out
+//    | | | | |  | | | | | `+Semicolon = testdata/gnu-local-label.c:8:67
+//    | | | | |  | | | | `+Else = <nil>
+//    | | | | |  | | | |-ExprStmt
+//    | | | | |  | | | | |+Expr = AssignExpr
+//    | | | | |  | | | | | |+X = s
+//    | | | | |  | | | | | |+Op = "="<PUNCTUATOR@testdata/gnu-local-label.c:8:71>
+//    | | | | |  | | | | | `+Y = 3
+//    | | | | |  | | | | `+Semicolon = testdata/gnu-local-label.c:8:74
+//    | | | | |  | | | `-LabelStmt
+//    | | | | |  | | |  |+Id = out
+//    | | | | |  | | |  |+Attrs = 
+//    | | | | |  | | |  `+Stmt = ExprStmt
+//    | | | | |  | | |   |+Expr = s
+//    | | | | |  | | |   `+Semicolon = testdata/gnu-local-label.c:8:82
+//    | | | | |  | | `+Rbrace = testdata/gnu-local-label.c:8:84
+//    | | | | |  | `+Rparen = testdata/gnu-local-label.c:8:85
+//    | | | | |  |+Align = 
+//    | | | | |  `+Attrs = 
+//    | | | | |-CompoundStmt
+//    | | | | | |+Lbrace = testdata/gnu-local-label.c:9:5
+//    | | | | | |+Stmts = 
+//    | | | | | | |-LabelDeclStmt
+//    | | | | | | | |+Label = testdata/gnu-local-label.c:10:9
+//    | | | | | | | |+Names = 
+//    | | | | | | | | `-done
+//    | | | | | | | `+Semicolon = testdata/gnu-local-label.c:10:23
+//    | | | | | | `-GotoStmt
+//    | | | | | |  |+Goto = testdata/gnu-local-label.c:11:9
+//    | | | | | |  |+Id = done
+//    | | | | | |  `+Semicolon = testdata/gnu-local-label.c:11:18
+//    | | | | | `+Rbrace = testdata/gnu-local-label.c:12:5
+//    | | | | |-GotoStmt
+//    | | | | | |+Goto = testdata/gnu-local-label.c:13:5
+//    | | | | | |+Id = out
+//    | | | | | `+Semicolon = testdata/gnu-local-label.c:13:13
+//    | | | | `-LabelStmt
+//    | | | |  |+Id = done
+//    | | | |  |+Attrs = 
+//    | | | |  `+Stmt = ReturnStmt
+//    | | | |   |+Return = testdata/gnu-local-label.c:15:5
+//    | | | |   |+X = BinaryExpr
+//    | | | |   | |+X = m
+//    | | | |   | |+Op = "+"<PUNCTUATOR@testdata/gnu-local-label.c:15:14>
+//    | | | |   | `+Y = n
+//    | | | |   `+Semicolon = testdata/gnu-local-label.c:15:17
+//    | | | `+Rbrace = testdata/gnu-local-label.c:16:1
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[]
+//    | | |+Name = twice
+//    | | |+Type =  int ( int)
+//    | | |+Decl = 
+//    | | |+Body = CompoundStmt
+//    | | | |+Lbrace = testdata/gnu-local-label.c:18:18
+//    | | | |+Stmts = 
+//    | | | | |-CompoundStmt
+//    | | | | | |+Lbrace = testdata/gnu-local-label.c:19:5
+//    | | | | | |+Stmts = 
+//    | | | | | | |-LabelDeclStmt
+//    | | | | | | | |+Label = testdata/gnu-local-label.c:20:9
+//    | | | | | | | |+Names = 
+//    | | | | | | | | `-again
+//    | | | | | | | `+Semicolon = testdata/gnu-local-label.c:20:24
+//    | | | | | | |-LabelDeclStmt
+//    | | | | | | | |+Label = testdata/gnu-local-label.c:21:9
+//    | | | | | | | |+Names = 
+//    | | | | | | | | `-again
+//    | | | | | | | `+Semicolon = testdata/gnu-local-label.c:21:24
+//    | | | | | | `-LabelStmt
+//    | | | | | |  |+Id = again
+//    | | | | | |  |+Attrs = 
+//    | | | | | |  `+Stmt = ExprStmt
+//    | | | | | |   |+Expr = UnaryExpr
+//    | | | | | |   | |+Op = "++"<PUNCTUATOR@testdata/gnu-local-label.c:23:10>
+//    | | | | | |   | `+X = x
+//    | | | | | |   `+Semicolon = testdata/gnu-local-label.c:23:12
+//    | | | | | `+Rbrace = testdata/gnu-local-label.c:24:5
+//    | | | | |-DeclStmt
+//    | | | | | `-VarDecl
+//    | | | | |  |+Qua = map[]
+//    | | | | |  |+Type =  int
+//    | | | | |  |+Name = y
+//    | | | | |  |+Init = x
+//    | | | | |  |+Align = 
+//    | | | | |  `+Attrs = 
+//    | | | | |-LabelDeclStmt
+//    | | | | | |+Label = testdata/gnu-local-label.c:26:5
+//    | | | | | |+Names = 
+//    | | | | | | `-late
+//    | | | | | `+Semicolon = testdata/gnu-local-label.c:26:19
+//    | | | | `-ReturnStmt
+//    | | | |  |+Return = testdata/gnu-local-label.c:27:5
+//    | | | |  |+X = y
+//    | | | |  `+Semicolon = testdata/gnu-local-label.c:27:13
+//    | | | `+Rbrace = testdata/gnu-local-label.c:28:1
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | `-FuncDecl
+//    |  |+Qua = map[]
+//    |  |+Spec = map[]
+//    |  |+Name = member
+//    |  |+Type =  int ( struct pair *, int)
+//    |  |+Decl = 
+//    |  |+Body = CompoundStmt
+//    |  | |+Lbrace = testdata/gnu-local-label.c:30:35
+//    |  | |+Stmts = 
+//    |  | | `-ReturnStmt
+//    |  | |  |+Return = testdata/gnu-local-label.c:31:5
+//    |  | |  |+X = BinaryExpr
+//    |  | |  | |+X = BinaryExpr
+//    |  | |  | | |+X = OffsetOfExpr
+//    |  | |  | | | |+Builtin = testdata/gnu-local-label.c:31:12
+//    |  | |  | | | |+Type =  struct pair
+//    |  | |  | | | |+Member = c
+//    |  | |  | | | `+Rparen = testdata/gnu-local-label.c:31:45
+//    |  | |  | | |+Op = "+"<PUNCTUATOR@testdata/gnu-local-label.c:31:47>
+//    |  | |  | | `+Y = OffsetOfExpr
+//    |  | |  | |  |+Builtin = testdata/gnu-local-label.c:31:49
+//    |  | |  | |  |+Type =  struct pair
+//    |  | |  | |  |+Member = IndexExpr
+//    |  | |  | |  | |+Arr = b
+//    |  | |  | |  | |+Lbrack = testdata/gnu-local-label.c:31:82
+//    |  | |  | |  | |+Index = i
+//    |  | |  | |  | `+Rbrack = testdata/gnu-local-label.c:31:84
+//    |  | |  | |  `+Rparen = testdata/gnu-local-label.c:31:85
+//    |  | |  | |+Op = "+"<PUNCTUATOR@testdata/gnu-local-label.c:31:87>
+//    |  | |  | `+Y = OffsetOfExpr
+//    |  | |  |  |+Builtin = testdata/gnu-local-label.c:31:89
+//    |  | |  |  |+Type =  struct pair
+//    |  | |  |  |+Member = IndexExpr
+//    |  | |  |  | |+Arr = a
+//    |  | |  |  | |+Lbrack = testdata/gnu-local-label.c:31:122
+//    |  | |  |  | |+Index = 0
+//    |  | |  |  | `+Rbrack = testdata/gnu-local-label.c:31:124
+//    |  | |  |  `+Rparen = testdata/gnu-local-label.c:31:125
+//    |  | |  `+Semicolon = testdata/gnu-local-label.c:31:126
+//    |  | `+Rbrace = testdata/gnu-local-label.c:32:1
+//    |  |+InlineDef = false
+//    |  `+Attrs = 
+//    `+Unresolved = 
+// ===========================
+//
+// |-Error
+// | |+Pos = testdata/gnu-local-label.c:11:14
+// | |+Typ = 0
+// | `+Msg = 在 testdata/gnu-local-label.c 文件的第11行14列: 未定义的标签 done
+// |-Error
+// | |+Pos = testdata/gnu-local-label.c:13:10
+// | |+Typ = 0
+// | `+Msg = 在 testdata/gnu-local-label.c 文件的第13行10列: 未定义的标签 out
+// |-Error
+// | |+Pos = testdata/gnu-local-label.c:21:19
+// | |+Typ = 0
+// | `+Msg = 在 testdata/gnu-local-label.c 文件的第21行19列: 重复定义的标签 again，上次定义的位置 testdata/gnu-local-label.c:20:19
+// |-Error
+// | |+Pos = testdata/gnu-local-label.c:26:5
+// | |+Typ = 0
+// | `+Msg = 在 testdata/gnu-local-label.c 文件的第26行5列: 局部标签声明只能位于块的开头
+// |-Error
+// | |+Pos = testdata/gnu-local-label.c:31:44
+// | |+Typ = 0
+// | `+Msg = 在 testdata/gnu-local-label.c 文件的第31行44列: struct pair 中没有名为 c 的成员
+// `-Error
+//  |+Pos = testdata/gnu-local-label.c:31:122
+//  |+Typ = 0
+//  `+Msg = 在 testdata/gnu-local-label.c 文件的第31行122列: 这里应该是一个 数组 ，不应该出现 int
+// ===========================
@@ -0,0 +1,55 @@
+__extension__ typedef long long int64;
+
+int get(void) {
+    int64 v = __extension__ 1LL;
+    return (int) v;
+}
+// ===========================
+// TranslationUnit
+//  `+Files = 
+//   `-File
+//    |+Name = testdata/gnuscan-dialect.c
+//    |+Decl = 
+//    | |-TypedefDecl
+//    | | |+Typedef = testdata/gnuscan-dialect.c:1:15
+//    | | |+Type =  long long
+//    | | |+Name = int64
+//    | | `+Attrs = 
+//    | `-FuncDecl
+//    |  |+Qua = map[]
+//    |  |+Spec = map[]
+//    |  |+Name = get
+//    |  |+Type =  int ( void)
+//    |  |+Decl = 
+//    |  |+Body = CompoundStmt
+//    |  | |+Lbrace = testdata/gnuscan-dialect.c:3:15
+//    |  | |+Stmts = 
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[]
+//    |  | | |  |+Type =  long long
+//    |  | | |  |+Name = v
+//    |  | | |  |+Init = ExtensionExpr
+//    |  | | |  | |+Extension = testdata/gnuscan-dialect.c:4:15
+//    |  | | |  | `+X = 1LL
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | `-ReturnStmt
+//    |  | |  |+Return = testdata/gnuscan-dialect.c:5:5
+//    |  | |  |+X = TypeCastExpr
+//    |  | |  | |+Lparen = testdata/gnuscan-dialect.c:5:12
+//    |  | |  | |+Type =  int
+//    |  | |  | |+Rparen = testdata/gnuscan-dialect.c:5:16
+//    |  | |  | `+X = v
+//    |  | |  `+Semicolon = testdata/gnuscan-dialect.c:5:19
+//    |  | `+Rbrace = testdata/gnuscan-dialect.c:6:1
+//    |  |+InlineDef = false
+//    |  `+Attrs = 
+//    `+Unresolved = 
+// ===========================
+//
+// `-Error
+//  |+Pos = testdata/gnuscan-dialect.c:1:1
+//  |+Typ = 1
+//  `+Msg = 在 testdata/gnuscan-dialect.c 文件的第1行1列: 扫描器方言与解析选项不一致，__extension__ 被扫描为关键字，解析选项 GNU 为 false
+// ===========================
@@ -0,0 +1,117 @@
+struct node {
+    char tag;
+    long value;
+    long long id;
+    struct node *next;
+};
+
+_Static_assert(sizeof(long) == 4 && sizeof(void *) == 4, "ilp32");
+_Static_assert(sizeof(struct node) == 20 && _Alignof(long long) == 4, "node");
+_Static_assert((-1L < 0u) == 0 && 0xFFFFFFFFu + 1 == 0, "long is as wide as unsigned int");
+_Static_assert(sizeof(long) == 8, "lp64 only");
+// ===========================
+// TranslationUnit
+//  `+Files = 
+//   `-File
+//    |+Name = testdata/ilp32-layout.c
+//    |+Decl = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  struct node
+//    | | |+Name = <nil>
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/ilp32-layout.c:8:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = BinaryExpr
+//    | | |  | |+X = SizeOfExpr
+//    | | |  | | |+Range = Range
+//    | | |  | | | |+Begin = testdata/ilp32-layout.c:8:16
+//    | | |  | | | `+End = testdata/ilp32-layout.c:8:27
+//    | | |  | | `+Type =  long
+//    | | |  | |+Op = "=="<PUNCTUATOR@testdata/ilp32-layout.c:8:29>
+//    | | |  | `+Y = 4
+//    | | |  |+Op = "&&"<PUNCTUATOR@testdata/ilp32-layout.c:8:34>
+//    | | |  `+Y = BinaryExpr
+//    | | |   |+X = SizeOfExpr
+//    | | |   | |+Range = Range
+//    | | |   | | |+Begin = testdata/ilp32-layout.c:8:37
+//    | | |   | | `+End = testdata/ilp32-layout.c:8:50
+//    | | |   | `+Type =  void *
+//    | | |   |+Op = "=="<PUNCTUATOR@testdata/ilp32-layout.c:8:52>
+//    | | |   `+Y = 4
+//    | | |+Msg = "ilp32"
+//    | | `+Semicolon = testdata/ilp32-layout.c:8:66
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/ilp32-layout.c:9:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = BinaryExpr
+//    | | |  | |+X = SizeOfExpr
+//    | | |  | | |+Range = Range
+//    | | |  | | | |+Begin = testdata/ilp32-layout.c:9:16
+//    | | |  | | | `+End = testdata/ilp32-layout.c:9:34
+//    | | |  | | `+Type =  struct node
+//    | | |  | |+Op = "=="<PUNCTUATOR@testdata/ilp32-layout.c:9:36>
+//    | | |  | `+Y = 20
+//    | | |  |+Op = "&&"<PUNCTUATOR@testdata/ilp32-layout.c:9:42>
+//    | | |  `+Y = BinaryExpr
+//    | | |   |+X = AlignOfExpr
+//    | | |   | |+Range = Range
+//    | | |   | | |+Begin = testdata/ilp32-layout.c:9:45
+//    | | |   | | `+End = testdata/ilp32-layout.c:9:63
+//    | | |   | `+Type =  long long
+//    | | |   |+Op = "=="<PUNCTUATOR@testdata/ilp32-layout.c:9:65>
+//    | | |   `+Y = 4
+//    | | |+Msg = "node"
+//    | | `+Semicolon = testdata/ilp32-layout.c:9:78
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/ilp32-layout.c:10:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = BinaryExpr
+//    | | |  | |+X = ParenExpr
+//    | | |  | | |+Lparen = testdata/ilp32-layout.c:10:16
+//    | | |  | | |+X = BinaryExpr
+//    | | |  | | | |+X = UnaryExpr
+//    | | |  | | | | |+Op = "-"<PUNCTUATOR@testdata/ilp32-layout.c:10:17>
+//    | | |  | | | | `+X = 1L
+//    | | |  | | | |+Op = "<"<PUNCTUATOR@testdata/ilp32-layout.c:10:21>
+//    | | |  | | | `+Y = 0u
+//    | | |  | | `+Rparen = testdata/ilp32-layout.c:10:25
+//    | | |  | |+Op = "=="<PUNCTUATOR@testdata/ilp32-layout.c:10:27>
+//    | | |  | `+Y = 0
+//    | | |  |+Op = "&&"<PUNCTUATOR@testdata/ilp32-layout.c:10:32>
+//    | | |  `+Y = BinaryExpr
+//    | | |   |+X = BinaryExpr
+//    | | |   | |+X = 0xFFFFFFFFu
+//    | | |   | |+Op = "+"<PUNCTUATOR@testdata/ilp32-layout.c:10:47>
+//    | | |   | `+Y = 1
+//    | | |   |+Op = "=="<PUNCTUATOR@testdata/ilp32-layout.c:10:51>
+//    | | |   `+Y = 0
+//    | | |+Msg = "long is as wide as unsigned int"
+//    | | `+Semicolon = testdata/ilp32-layout.c:10:91
+//    | `-StaticAssertDecl
+//    |  |+StaticAssert = testdata/ilp32-layout.c:11:1
+//    |  |+Cond = ConstantExpr
+//    |  | `+X = BinaryExpr
+//    |  |  |+X = SizeOfExpr
+//    |  |  | |+Range = Range
+//    |  |  | | |+Begin = testdata/ilp32-layout.c:11:16
+//    |  |  | | `+End = testdata/ilp32-layout.c:11:27
+//    |  |  | `+Type =  long
+//    |  |  |+Op = "=="<PUNCTUATOR@testdata/ilp32-layout.c:11:29>
+//    |  |  `+Y = 8
+//    |  |+Msg = "lp64 only"
+//    |  `+Semicolon = testdata/ilp32-layout.c:11:47
+//    `+Unresolved = 
+// ===========================
+//
+// `-Error
+//  |+Pos = testdata/ilp32-layout.c:11:1
+//  |+Typ = 0
+//  `+Msg = 在 testdata/ilp32-layout.c 文件的第11行1列: 静态断言失败："lp64 only"
+// ===========================
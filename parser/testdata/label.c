@@ -17,63 +17,73 @@ int main() {
 //   | |+Name = testdata/printf.h
 //   | |+Decl = 
 //   | | `-FuncDecl
+//   | |  |+Qua = map[]
+//   | |  |+Spec = map[]
 //   | |  |+Name = printf
 //   | |  |+Type =  int (const char *,...)
 //   | |  |+Decl = 
-//   | |  `+Body = <nil>
+//   | |  |+Body = <nil>
+//   | |  |+InlineDef = false
+//   | |  `+Attrs = 
 //   | `+Unresolved = 
 //   `-File
-//    |+Name = testdata\label.c
+//    |+Name = testdata/label.c
 //    |+Decl = 
 //    | `-FuncDecl
+//    |  |+Qua = map[]
+//    |  |+Spec = map[]
 //    |  |+Name = main
 //    |  |+Type =  int ()
 //    |  |+Decl = 
-//    |  `+Body = CompoundStmt
-//    |   |+Lbrace = testdata\label.c:3:12
-//    |   |+Stmts = 
-//    |   | |-GotoStmt
-//    |   | | |+Goto = testdata\label.c:4:5
-//    |   | | |+Id = test
-//    |   | | `+Semicolon = testdata\label.c:4:14
-//    |   | |-ExprStmt
-//    |   | | |+Expr = CallExpr
-//    |   | | | |+Func = printf
-//    |   | | | |+Lparen = testdata\label.c:5:11
-//    |   | | | |+Args = 
-//    |   | | | | `-"demo"
-//    |   | | | `+Rparen = testdata\label.c:5:18
-//    |   | | `+Semicolon = testdata\label.c:5:19
-//    |   | |-GotoStmt
-//    |   | | |+Goto = testdata\label.c:6:5
-//    |   | | |+Id = test1
-//    |   | | `+Semicolon = testdata\label.c:6:15
-//    |   | |-LabelStmt
-//    |   | | |+Id = test
-//    |   | | `+Stmt = ExprStmt
-//    |   | |  |+Expr = CallExpr
-//    |   | |  | |+Func = printf
-//    |   | |  | |+Lparen = testdata\label.c:8:15
-//    |   | |  | |+Args = 
-//    |   | |  | | `-"test"
-//    |   | |  | `+Rparen = testdata\label.c:8:22
-//    |   | |  `+Semicolon = testdata\label.c:8:23
-//    |   | `-LabelStmt
-//    |   |  |+Id = test2
-//    |   |  `+Stmt = ExprStmt
-//    |   |   |+Expr = CallExpr
-//    |   |   | |+Func = printf
-//    |   |   | |+Lparen = testdata\label.c:10:15
-//    |   |   | |+Args = 
-//    |   |   | | `-"test2"
-//    |   |   | `+Rparen = testdata\label.c:10:23
-//    |   |   `+Semicolon = testdata\label.c:10:24
-//    |   `+Rbrace = testdata\label.c:11:1
+//    |  |+Body = CompoundStmt
+//    |  | |+Lbrace = testdata/label.c:3:12
+//    |  | |+Stmts = 
+//    |  | | |-GotoStmt
+//    |  | | | |+Goto = testdata/label.c:4:5
+//    |  | | | |+Id = test
+//    |  | | | `+Semicolon = testdata/label.c:4:14
+//    |  | | |-ExprStmt
+//    |  | | | |+Expr = CallExpr
+//    |  | | | | |+Func = printf
+//    |  | | | | |+Lparen = testdata/label.c:5:11
+//    |  | | | | |+Args = 
+//    |  | | | | | `-"demo"
+//    |  | | | | `+Rparen = testdata/label.c:5:18
+//    |  | | | `+Semicolon = testdata/label.c:5:19
+//    |  | | |-GotoStmt
+//    |  | | | |+Goto = testdata/label.c:6:5
+//    |  | | | |+Id = test1
+//    |  | | | `+Semicolon = testdata/label.c:6:15
+//    |  | | |-LabelStmt
+//    |  | | | |+Id = test
+//    |  | | | |+Attrs = 
+//    |  | | | `+Stmt = ExprStmt
+//    |  | | |  |+Expr = CallExpr
+//    |  | | |  | |+Func = printf
+//    |  | | |  | |+Lparen = testdata/label.c:8:15
+//    |  | | |  | |+Args = 
+//    |  | | |  | | `-"test"
+//    |  | | |  | `+Rparen = testdata/label.c:8:22
+//    |  | | |  `+Semicolon = testdata/label.c:8:23
+//    |  | | `-LabelStmt
+//    |  | |  |+Id = test2
+//    |  | |  |+Attrs = 
+//    |  | |  `+Stmt = ExprStmt
+//    |  | |   |+Expr = CallExpr
+//    |  | |   | |+Func = printf
+//    |  | |   | |+Lparen = testdata/label.c:10:15
+//    |  | |   | |+Args = 
+//    |  | |   | | `-"test2"
+//    |  | |   | `+Rparen = testdata/label.c:10:23
+//    |  | |   `+Semicolon = testdata/label.c:10:24
+//    |  | `+Rbrace = testdata/label.c:11:1
+//    |  |+InlineDef = false
+//    |  `+Attrs = 
 //    `+Unresolved = 
 // ===========================
 //
 // `-Error
-//  |+Pos = testdata\label.c:6:10
+//  |+Pos = testdata/label.c:6:10
 //  |+Typ = 0
-//  `+Msg = 在 testdata\label.c 文件的第6行10列: 未定义的标签 test1
+//  `+Msg = 在 testdata/label.c 文件的第6行10列: 未定义的标签 test1
 // ===========================
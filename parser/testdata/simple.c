@@ -6,20 +6,24 @@ int main() {
 // TranslationUnit
 //  `+Files = 
 //   `-File
-//    |+Name = testdata\simple.c
+//    |+Name = testdata/simple.c
 //    |+Decl = 
 //    | `-FuncDecl
+//    |  |+Qua = map[]
+//    |  |+Spec = map[]
 //    |  |+Name = main
 //    |  |+Type =  int ()
 //    |  |+Decl = 
-//    |  `+Body = CompoundStmt
-//    |   |+Lbrace = testdata\simple.c:1:12
-//    |   |+Stmts = 
-//    |   | `-ReturnStmt
-//    |   |  |+Return = testdata\simple.c:2:5
-//    |   |  |+X = 0
-//    |   |  `+Semicolon = testdata\simple.c:2:13
-//    |   `+Rbrace = testdata\simple.c:3:1
+//    |  |+Body = CompoundStmt
+//    |  | |+Lbrace = testdata/simple.c:1:12
+//    |  | |+Stmts = 
+//    |  | | `-ReturnStmt
+//    |  | |  |+Return = testdata/simple.c:2:5
+//    |  | |  |+X = 0
+//    |  | |  `+Semicolon = testdata/simple.c:2:13
+//    |  | `+Rbrace = testdata/simple.c:3:1
+//    |  |+InlineDef = false
+//    |  `+Attrs = 
 //    `+Unresolved = 
 // ===========================
 //
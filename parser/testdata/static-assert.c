@@ -0,0 +1,579 @@
+enum Size {
+    SMALL = 2,
+    MEDIUM = SMALL * 4,
+    LARGE,
+};
+
+struct header {
+    char tag;
+    int len;
+    short flags[3];
+    unsigned int kind : 4, mode : 4;
+    _Static_assert(MEDIUM == 8, "medium");
+};
+
+union value {
+    char c;
+    long long l;
+};
+
+_Static_assert(sizeof(struct header) == 16, "header size");
+_Static_assert(sizeof(union value) == sizeof(long long) && LARGE == 9, "value size");
+_Static_assert((unsigned char) 257 == 1 && -1 < 0 && (1 ? 'a' : 0) == 97, "cast");
+_Static_assert(-1u == 0xFFFFFFFFu, "");
+_Static_assert(0xFFFFFFFFu + 1 == 0, "");
+_Static_assert((-1 < 0u) == 0 && -1L < 0u && (unsigned char) 255 + 1 == 256 && ~0u == 4294967295, "conversion");
+_Static_assert((1u << 31 << 1) == 0 && (unsigned short) 65535 * 2 == 131070, "shift");
+_Static_assert(sizeof(int *) == 8);
+_Static_assert(sizeof(struct header) == 12, "header must be 12 bytes");
+_Static_assert(LARGE / (SMALL - 2), "zero");
+
+int arr[5];
+struct header *hp;
+struct header hv;
+_Static_assert(sizeof arr / sizeof arr[0] == 5 && sizeof 2[arr] == 4, "array size");
+_Static_assert(sizeof *hp == 16 && sizeof hp->flags == 6 && sizeof hv.len == 4, "member");
+_Static_assert(sizeof 1.0 == 8 && sizeof(1.0f) == 4 && sizeof(1 ? 1 : 2.0) == 8, "float");
+_Static_assert(sizeof(1 + 1L) == 8 && sizeof('a' + (char) 1) == 4 && sizeof(hp + 1) == 8, "binary");
+_Static_assert(sizeof(1.0f + 1) == 4 && sizeof(hp - hp) == 8 && sizeof(arr[0] < 1) == 4, "conversion");
+
+int main() {
+    int n = 1;
+    _Static_assert(sizeof(n) == 4, "int");
+    _Static_assert(n == 1, "not constant");
+    _Static_assert(0);
+}
+
+// ===========================
+// TranslationUnit
+//  `+Files = 
+//   `-File
+//    |+Name = testdata/static-assert.c
+//    |+Decl = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  enum Size
+//    | | |+Name = <nil>
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  struct header
+//    | | |+Name = <nil>
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  struct value
+//    | | |+Name = <nil>
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/static-assert.c:20:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = SizeOfExpr
+//    | | |  | |+Range = Range
+//    | | |  | | |+Begin = testdata/static-assert.c:20:16
+//    | | |  | | `+End = testdata/static-assert.c:20:36
+//    | | |  | `+Type =  struct header
+//    | | |  |+Op = "=="<PUNCTUATOR@testdata/static-assert.c:20:38>
+//    | | |  `+Y = 16
+//    | | |+Msg = "header size"
+//    | | `+Semicolon = testdata/static-assert.c:20:59
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/static-assert.c:21:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = BinaryExpr
+//    | | |  | |+X = SizeOfExpr
+//    | | |  | | |+Range = Range
+//    | | |  | | | |+Begin = testdata/static-assert.c:21:16
+//    | | |  | | | `+End = testdata/static-assert.c:21:34
+//    | | |  | | `+Type =  struct value
+//    | | |  | |+Op = "=="<PUNCTUATOR@testdata/static-assert.c:21:36>
+//    | | |  | `+Y = SizeOfExpr
+//    | | |  |  |+Range = Range
+//    | | |  |  | |+Begin = testdata/static-assert.c:21:39
+//    | | |  |  | `+End = testdata/static-assert.c:21:55
+//    | | |  |  `+Type =  long long
+//    | | |  |+Op = "&&"<PUNCTUATOR@testdata/static-assert.c:21:57>
+//    | | |  `+Y = BinaryExpr
+//    | | |   |+X = LARGE
+//    | | |   |+Op = "=="<PUNCTUATOR@testdata/static-assert.c:21:66>
+//    | | |   `+Y = 9
+//    | | |+Msg = "value size"
+//    | | `+Semicolon = testdata/static-assert.c:21:85
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/static-assert.c:22:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = BinaryExpr
+//    | | |  | |+X = BinaryExpr
+//    | | |  | | |+X = TypeCastExpr
+//    | | |  | | | |+Lparen = testdata/static-assert.c:22:16
+//    | | |  | | | |+Type =  unsigned char
+//    | | |  | | | |+Rparen = testdata/static-assert.c:22:30
+//    | | |  | | | `+X = 257
+//    | | |  | | |+Op = "=="<PUNCTUATOR@testdata/static-assert.c:22:36>
+//    | | |  | | `+Y = 1
+//    | | |  | |+Op = "&&"<PUNCTUATOR@testdata/static-assert.c:22:41>
+//    | | |  | `+Y = BinaryExpr
+//    | | |  |  |+X = UnaryExpr
+//    | | |  |  | |+Op = "-"<PUNCTUATOR@testdata/static-assert.c:22:44>
+//    | | |  |  | `+X = 1
+//    | | |  |  |+Op = "<"<PUNCTUATOR@testdata/static-assert.c:22:47>
+//    | | |  |  `+Y = 0
+//    | | |  |+Op = "&&"<PUNCTUATOR@testdata/static-assert.c:22:51>
+//    | | |  `+Y = BinaryExpr
+//    | | |   |+X = ParenExpr
+//    | | |   | |+Lparen = testdata/static-assert.c:22:54
+//    | | |   | |+X = CondExpr
+//    | | |   | | |+X = 1
+//    | | |   | | |+Op = "?"<PUNCTUATOR@testdata/static-assert.c:22:57>
+//    | | |   | | |+Then = 'a'
+//    | | |   | | `+Else = 0
+//    | | |   | `+Rparen = testdata/static-assert.c:22:66
+//    | | |   |+Op = "=="<PUNCTUATOR@testdata/static-assert.c:22:68>
+//    | | |   `+Y = 97
+//    | | |+Msg = "cast"
+//    | | `+Semicolon = testdata/static-assert.c:22:82
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/static-assert.c:23:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = UnaryExpr
+//    | | |  | |+Op = "-"<PUNCTUATOR@testdata/static-assert.c:23:16>
+//    | | |  | `+X = 1u
+//    | | |  |+Op = "=="<PUNCTUATOR@testdata/static-assert.c:23:20>
+//    | | |  `+Y = 0xFFFFFFFFu
+//    | | |+Msg = ""
+//    | | `+Semicolon = testdata/static-assert.c:23:39
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/static-assert.c:24:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = BinaryExpr
+//    | | |  | |+X = 0xFFFFFFFFu
+//    | | |  | |+Op = "+"<PUNCTUATOR@testdata/static-assert.c:24:28>
+//    | | |  | `+Y = 1
+//    | | |  |+Op = "=="<PUNCTUATOR@testdata/static-assert.c:24:32>
+//    | | |  `+Y = 0
+//    | | |+Msg = ""
+//    | | `+Semicolon = testdata/static-assert.c:24:41
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/static-assert.c:25:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = BinaryExpr
+//    | | |  | |+X = BinaryExpr
+//    | | |  | | |+X = ParenExpr
+//    | | |  | | | |+Lparen = testdata/static-assert.c:25:16
+//    | | |  | | | |+X = BinaryExpr
+//    | | |  | | | | |+X = UnaryExpr
+//    | | |  | | | | | |+Op = "-"<PUNCTUATOR@testdata/static-assert.c:25:17>
+//    | | |  | | | | | `+X = 1
+//    | | |  | | | | |+Op = "<"<PUNCTUATOR@testdata/static-assert.c:25:20>
+//    | | |  | | | | `+Y = 0u
+//    | | |  | | | `+Rparen = testdata/static-assert.c:25:24
+//    | | |  | | |+Op = "=="<PUNCTUATOR@testdata/static-assert.c:25:26>
+//    | | |  | | `+Y = 0
+//    | | |  | |+Op = "&&"<PUNCTUATOR@testdata/static-assert.c:25:31>
+//    | | |  | `+Y = BinaryExpr
+//    | | |  |  |+X = UnaryExpr
+//    | | |  |  | |+Op = "-"<PUNCTUATOR@testdata/static-assert.c:25:34>
+//    | | |  |  | `+X = 1L
+//    | | |  |  |+Op = "<"<PUNCTUATOR@testdata/static-assert.c:25:38>
+//    | | |  |  `+Y = 0u
+//    | | |  |+Op = "&&"<PUNCTUATOR@testdata/static-assert.c:25:43>
+//    | | |  `+Y = BinaryExpr
+//    | | |   |+X = BinaryExpr
+//    | | |   | |+X = BinaryExpr
+//    | | |   | | |+X = TypeCastExpr
+//    | | |   | | | |+Lparen = testdata/static-assert.c:25:46
+//    | | |   | | | |+Type =  unsigned char
+//    | | |   | | | |+Rparen = testdata/static-assert.c:25:60
+//    | | |   | | | `+X = 255
+//    | | |   | | |+Op = "+"<PUNCTUATOR@testdata/static-assert.c:25:66>
+//    | | |   | | `+Y = 1
+//    | | |   | |+Op = "=="<PUNCTUATOR@testdata/static-assert.c:25:70>
+//    | | |   | `+Y = 256
+//    | | |   |+Op = "&&"<PUNCTUATOR@testdata/static-assert.c:25:77>
+//    | | |   `+Y = BinaryExpr
+//    | | |    |+X = UnaryExpr
+//    | | |    | |+Op = "~"<PUNCTUATOR@testdata/static-assert.c:25:80>
+//    | | |    | `+X = 0u
+//    | | |    |+Op = "=="<PUNCTUATOR@testdata/static-assert.c:25:84>
+//    | | |    `+Y = 4294967295
+//    | | |+Msg = "conversion"
+//    | | `+Semicolon = testdata/static-assert.c:25:112
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/static-assert.c:26:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = BinaryExpr
+//    | | |  | |+X = ParenExpr
+//    | | |  | | |+Lparen = testdata/static-assert.c:26:16
+//    | | |  | | |+X = BinaryExpr
+//    | | |  | | | |+X = BinaryExpr
+//    | | |  | | | | |+X = 1u
+//    | | |  | | | | |+Op = "<<"<PUNCTUATOR@testdata/static-assert.c:26:20>
+//    | | |  | | | | `+Y = 31
+//    | | |  | | | |+Op = "<<"<PUNCTUATOR@testdata/static-assert.c:26:26>
+//    | | |  | | | `+Y = 1
+//    | | |  | | `+Rparen = testdata/static-assert.c:26:30
+//    | | |  | |+Op = "=="<PUNCTUATOR@testdata/static-assert.c:26:32>
+//    | | |  | `+Y = 0
+//    | | |  |+Op = "&&"<PUNCTUATOR@testdata/static-assert.c:26:37>
+//    | | |  `+Y = BinaryExpr
+//    | | |   |+X = BinaryExpr
+//    | | |   | |+X = TypeCastExpr
+//    | | |   | | |+Lparen = testdata/static-assert.c:26:40
+//    | | |   | | |+Type =  unsigned short
+//    | | |   | | |+Rparen = testdata/static-assert.c:26:55
+//    | | |   | | `+X = 65535
+//    | | |   | |+Op = "*"<PUNCTUATOR@testdata/static-assert.c:26:63>
+//    | | |   | `+Y = 2
+//    | | |   |+Op = "=="<PUNCTUATOR@testdata/static-assert.c:26:67>
+//    | | |   `+Y = 131070
+//    | | |+Msg = "shift"
+//    | | `+Semicolon = testdata/static-assert.c:26:86
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/static-assert.c:27:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = SizeOfExpr
+//    | | |  | |+Range = Range
+//    | | |  | | |+Begin = testdata/static-assert.c:27:16
+//    | | |  | | `+End = testdata/static-assert.c:27:28
+//    | | |  | `+Type =  int *
+//    | | |  |+Op = "=="<PUNCTUATOR@testdata/static-assert.c:27:30>
+//    | | |  `+Y = 8
+//    | | |+Msg = <nil>
+//    | | `+Semicolon = testdata/static-assert.c:27:35
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/static-assert.c:28:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = SizeOfExpr
+//    | | |  | |+Range = Range
+//    | | |  | | |+Begin = testdata/static-assert.c:28:16
+//    | | |  | | `+End = testdata/static-assert.c:28:36
+//    | | |  | `+Type =  struct header
+//    | | |  |+Op = "=="<PUNCTUATOR@testdata/static-assert.c:28:38>
+//    | | |  `+Y = 12
+//    | | |+Msg = "header must be 12 bytes"
+//    | | `+Semicolon = testdata/static-assert.c:28:71
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/static-assert.c:29:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = LARGE
+//    | | |  |+Op = "/"<PUNCTUATOR@testdata/static-assert.c:29:22>
+//    | | |  `+Y = ParenExpr
+//    | | |   |+Lparen = testdata/static-assert.c:29:24
+//    | | |   |+X = BinaryExpr
+//    | | |   | |+X = SMALL
+//    | | |   | |+Op = "-"<PUNCTUATOR@testdata/static-assert.c:29:31>
+//    | | |   | `+Y = 2
+//    | | |   `+Rparen = testdata/static-assert.c:29:34
+//    | | |+Msg = "zero"
+//    | | `+Semicolon = testdata/static-assert.c:29:44
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =   int[5]
+//    | | |+Name = arr
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  struct header *
+//    | | |+Name = hp
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  struct header
+//    | | |+Name = hv
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/static-assert.c:34:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = BinaryExpr
+//    | | |  | |+X = BinaryExpr
+//    | | |  | | |+X = UnaryExpr
+//    | | |  | | | |+Op = "sizeof"<KEYWORD@testdata/static-assert.c:34:16>
+//    | | |  | | | `+X = arr
+//    | | |  | | |+Op = "/"<PUNCTUATOR@testdata/static-assert.c:34:27>
+//    | | |  | | `+Y = UnaryExpr
+//    | | |  | |  |+Op = "sizeof"<KEYWORD@testdata/static-assert.c:34:29>
+//    | | |  | |  `+X = IndexExpr
+//    | | |  | |   |+Arr = arr
+//    | | |  | |   |+Lbrack = testdata/static-assert.c:34:39
+//    | | |  | |   |+Index = 0
+//    | | |  | |   `+Rbrack = testdata/static-assert.c:34:41
+//    | | |  | |+Op = "=="<PUNCTUATOR@testdata/static-assert.c:34:43>
+//    | | |  | `+Y = 5
+//    | | |  |+Op = "&&"<PUNCTUATOR@testdata/static-assert.c:34:48>
+//    | | |  `+Y = BinaryExpr
+//    | | |   |+X = UnaryExpr
+//    | | |   | |+Op = "sizeof"<KEYWORD@testdata/static-assert.c:34:51>
+//    | | |   | `+X = IndexExpr
+//    | | |   |  |+Arr = 2
+//    | | |   |  |+Lbrack = testdata/static-assert.c:34:59
+//    | | |   |  |+Index = arr
+//    | | |   |  `+Rbrack = testdata/static-assert.c:34:63
+//    | | |   |+Op = "=="<PUNCTUATOR@testdata/static-assert.c:34:65>
+//    | | |   `+Y = 4
+//    | | |+Msg = "array size"
+//    | | `+Semicolon = testdata/static-assert.c:34:84
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/static-assert.c:35:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = BinaryExpr
+//    | | |  | |+X = BinaryExpr
+//    | | |  | | |+X = UnaryExpr
+//    | | |  | | | |+Op = "sizeof"<KEYWORD@testdata/static-assert.c:35:16>
+//    | | |  | | | `+X = UnaryExpr
+//    | | |  | | |  |+Op = "*"<PUNCTUATOR@testdata/static-assert.c:35:23>
+//    | | |  | | |  `+X = hp
+//    | | |  | | |+Op = "=="<PUNCTUATOR@testdata/static-assert.c:35:27>
+//    | | |  | | `+Y = 16
+//    | | |  | |+Op = "&&"<PUNCTUATOR@testdata/static-assert.c:35:33>
+//    | | |  | `+Y = BinaryExpr
+//    | | |  |  |+X = UnaryExpr
+//    | | |  |  | |+Op = "sizeof"<KEYWORD@testdata/static-assert.c:35:36>
+//    | | |  |  | `+X = SelectorExpr
+//    | | |  |  |  |+X = hp
+//    | | |  |  |  |+Op = "->"<PUNCTUATOR@testdata/static-assert.c:35:45>
+//    | | |  |  |  `+Name = flags
+//    | | |  |  |+Op = "=="<PUNCTUATOR@testdata/static-assert.c:35:53>
+//    | | |  |  `+Y = 6
+//    | | |  |+Op = "&&"<PUNCTUATOR@testdata/static-assert.c:35:58>
+//    | | |  `+Y = BinaryExpr
+//    | | |   |+X = UnaryExpr
+//    | | |   | |+Op = "sizeof"<KEYWORD@testdata/static-assert.c:35:61>
+//    | | |   | `+X = SelectorExpr
+//    | | |   |  |+X = hv
+//    | | |   |  |+Op = "."<PUNCTUATOR@testdata/static-assert.c:35:70>
+//    | | |   |  `+Name = len
+//    | | |   |+Op = "=="<PUNCTUATOR@testdata/static-assert.c:35:75>
+//    | | |   `+Y = 4
+//    | | |+Msg = "member"
+//    | | `+Semicolon = testdata/static-assert.c:35:90
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/static-assert.c:36:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = BinaryExpr
+//    | | |  | |+X = BinaryExpr
+//    | | |  | | |+X = UnaryExpr
+//    | | |  | | | |+Op = "sizeof"<KEYWORD@testdata/static-assert.c:36:16>
+//    | | |  | | | `+X = 1.0
+//    | | |  | | |+Op = "=="<PUNCTUATOR@testdata/static-assert.c:36:27>
+//    | | |  | | `+Y = 8
+//    | | |  | |+Op = "&&"<PUNCTUATOR@testdata/static-assert.c:36:32>
+//    | | |  | `+Y = BinaryExpr
+//    | | |  |  |+X = UnaryExpr
+//    | | |  |  | |+Op = "sizeof"<KEYWORD@testdata/static-assert.c:36:35>
+//    | | |  |  | `+X = ParenExpr
+//    | | |  |  |  |+Lparen = testdata/static-assert.c:36:41
+//    | | |  |  |  |+X = 1.0f
+//    | | |  |  |  `+Rparen = testdata/static-assert.c:36:46
+//    | | |  |  |+Op = "=="<PUNCTUATOR@testdata/static-assert.c:36:48>
+//    | | |  |  `+Y = 4
+//    | | |  |+Op = "&&"<PUNCTUATOR@testdata/static-assert.c:36:53>
+//    | | |  `+Y = BinaryExpr
+//    | | |   |+X = UnaryExpr
+//    | | |   | |+Op = "sizeof"<KEYWORD@testdata/static-assert.c:36:56>
+//    | | |   | `+X = ParenExpr
+//    | | |   |  |+Lparen = testdata/static-assert.c:36:62
+//    | | |   |  |+X = CondExpr
+//    | | |   |  | |+X = 1
+//    | | |   |  | |+Op = "?"<PUNCTUATOR@testdata/static-assert.c:36:65>
+//    | | |   |  | |+Then = 1
+//    | | |   |  | `+Else = 2.0
+//    | | |   |  `+Rparen = testdata/static-assert.c:36:74
+//    | | |   |+Op = "=="<PUNCTUATOR@testdata/static-assert.c:36:76>
+//    | | |   `+Y = 8
+//    | | |+Msg = "float"
+//    | | `+Semicolon = testdata/static-assert.c:36:90
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/static-assert.c:37:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = BinaryExpr
+//    | | |  | |+X = BinaryExpr
+//    | | |  | | |+X = UnaryExpr
+//    | | |  | | | |+Op = "sizeof"<KEYWORD@testdata/static-assert.c:37:16>
+//    | | |  | | | `+X = ParenExpr
+//    | | |  | | |  |+Lparen = testdata/static-assert.c:37:22
+//    | | |  | | |  |+X = BinaryExpr
+//    | | |  | | |  | |+X = 1
+//    | | |  | | |  | |+Op = "+"<PUNCTUATOR@testdata/static-assert.c:37:25>
+//    | | |  | | |  | `+Y = 1L
+//    | | |  | | |  `+Rparen = testdata/static-assert.c:37:29
+//    | | |  | | |+Op = "=="<PUNCTUATOR@testdata/static-assert.c:37:31>
+//    | | |  | | `+Y = 8
+//    | | |  | |+Op = "&&"<PUNCTUATOR@testdata/static-assert.c:37:36>
+//    | | |  | `+Y = BinaryExpr
+//    | | |  |  |+X = UnaryExpr
+//    | | |  |  | |+Op = "sizeof"<KEYWORD@testdata/static-assert.c:37:39>
+//    | | |  |  | `+X = ParenExpr
+//    | | |  |  |  |+Lparen = testdata/static-assert.c:37:45
+//    | | |  |  |  |+X = BinaryExpr
+//    | | |  |  |  | |+X = 'a'
+//    | | |  |  |  | |+Op = "+"<PUNCTUATOR@testdata/static-assert.c:37:50>
+//    | | |  |  |  | `+Y = TypeCastExpr
+//    | | |  |  |  |  |+Lparen = testdata/static-assert.c:37:52
+//    | | |  |  |  |  |+Type =  char
+//    | | |  |  |  |  |+Rparen = testdata/static-assert.c:37:57
+//    | | |  |  |  |  `+X = 1
+//    | | |  |  |  `+Rparen = testdata/static-assert.c:37:60
+//    | | |  |  |+Op = "=="<PUNCTUATOR@testdata/static-assert.c:37:62>
+//    | | |  |  `+Y = 4
+//    | | |  |+Op = "&&"<PUNCTUATOR@testdata/static-assert.c:37:67>
+//    | | |  `+Y = BinaryExpr
+//    | | |   |+X = UnaryExpr
+//    | | |   | |+Op = "sizeof"<KEYWORD@testdata/static-assert.c:37:70>
+//    | | |   | `+X = ParenExpr
+//    | | |   |  |+Lparen = testdata/static-assert.c:37:76
+//    | | |   |  |+X = BinaryExpr
+//    | | |   |  | |+X = hp
+//    | | |   |  | |+Op = "+"<PUNCTUATOR@testdata/static-assert.c:37:80>
+//    | | |   |  | `+Y = 1
+//    | | |   |  `+Rparen = testdata/static-assert.c:37:83
+//    | | |   |+Op = "=="<PUNCTUATOR@testdata/static-assert.c:37:85>
+//    | | |   `+Y = 8
+//    | | |+Msg = "binary"
+//    | | `+Semicolon = testdata/static-assert.c:37:100
+//    | |-StaticAssertDecl
+//    | | |+StaticAssert = testdata/static-assert.c:38:1
+//    | | |+Cond = ConstantExpr
+//    | | | `+X = BinaryExpr
+//    | | |  |+X = BinaryExpr
+//    | | |  | |+X = BinaryExpr
+//    | | |  | | |+X = UnaryExpr
+//    | | |  | | | |+Op = "sizeof"<KEYWORD@testdata/static-assert.c:38:16>
+//    | | |  | | | `+X = ParenExpr
+//    | | |  | | |  |+Lparen = testdata/static-assert.c:38:22
+//    | | |  | | |  |+X = BinaryExpr
+//    | | |  | | |  | |+X = 1.0f
+//    | | |  | | |  | |+Op = "+"<PUNCTUATOR@testdata/static-assert.c:38:28>
+//    | | |  | | |  | `+Y = 1
+//    | | |  | | |  `+Rparen = testdata/static-assert.c:38:31
+//    | | |  | | |+Op = "=="<PUNCTUATOR@testdata/static-assert.c:38:33>
+//    | | |  | | `+Y = 4
+//    | | |  | |+Op = "&&"<PUNCTUATOR@testdata/static-assert.c:38:38>
+//    | | |  | `+Y = BinaryExpr
+//    | | |  |  |+X = UnaryExpr
+//    | | |  |  | |+Op = "sizeof"<KEYWORD@testdata/static-assert.c:38:41>
+//    | | |  |  | `+X = ParenExpr
+//    | | |  |  |  |+Lparen = testdata/static-assert.c:38:47
+//    | | |  |  |  |+X = BinaryExpr
+//    | | |  |  |  | |+X = hp
+//    | | |  |  |  | |+Op = "-"<PUNCTUATOR@testdata/static-assert.c:38:51>
+//    | | |  |  |  | `+Y = hp
+//    | | |  |  |  `+Rparen = testdata/static-assert.c:38:55
+//    | | |  |  |+Op = "=="<PUNCTUATOR@testdata/static-assert.c:38:57>
+//    | | |  |  `+Y = 8
+//    | | |  |+Op = "&&"<PUNCTUATOR@testdata/static-assert.c:38:62>
+//    | | |  `+Y = BinaryExpr
+//    | | |   |+X = UnaryExpr
+//    | | |   | |+Op = "sizeof"<KEYWORD@testdata/static-assert.c:38:65>
+//    | | |   | `+X = ParenExpr
+//    | | |   |  |+Lparen = testdata/static-assert.c:38:71
+//    | | |   |  |+X = BinaryExpr
+//    | | |   |  | |+X = IndexExpr
+//    | | |   |  | | |+Arr = arr
+//    | | |   |  | | |+Lbrack = testdata/static-assert.c:38:75
+//    | | |   |  | | |+Index = 0
+//    | | |   |  | | `+Rbrack = testdata/static-assert.c:38:77
+//    | | |   |  | |+Op = "<"<PUNCTUATOR@testdata/static-assert.c:38:79>
+//    | | |   |  | `+Y = 1
+//    | | |   |  `+Rparen = testdata/static-assert.c:38:82
+//    | | |   |+Op = "=="<PUNCTUATOR@testdata/static-assert.c:38:84>
+//    | | |   `+Y = 4
+//    | | |+Msg = "conversion"
+//    | | `+Semicolon = testdata/static-assert.c:38:103
+//    | `-FuncDecl
+//    |  |+Qua = map[]
+//    |  |+Spec = map[]
+//    |  |+Name = main
+//    |  |+Type =  int ()
+//    |  |+Decl = 
+//    |  |+Body = CompoundStmt
+//    |  | |+Lbrace = testdata/static-assert.c:40:12
+//    |  | |+Stmts = 
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[]
+//    |  | | |  |+Type =  int
+//    |  | | |  |+Name = n
+//    |  | | |  |+Init = 1
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | |-DeclStmt
+//    |  | | | `-StaticAssertDecl
+//    |  | | |  |+StaticAssert = testdata/static-assert.c:42:5
+//    |  | | |  |+Cond = ConstantExpr
+//    |  | | |  | `+X = BinaryExpr
+//    |  | | |  |  |+X = UnaryExpr
+//    |  | | |  |  | |+Op = "sizeof"<KEYWORD@testdata/static-assert.c:42:20>
+//    |  | | |  |  | `+X = ParenExpr
+//    |  | | |  |  |  |+Lparen = testdata/static-assert.c:42:26
+//    |  | | |  |  |  |+X = n
+//    |  | | |  |  |  `+Rparen = testdata/static-assert.c:42:28
+//    |  | | |  |  |+Op = "=="<PUNCTUATOR@testdata/static-assert.c:42:30>
+//    |  | | |  |  `+Y = 4
+//    |  | | |  |+Msg = "int"
+//    |  | | |  `+Semicolon = testdata/static-assert.c:42:42
+//    |  | | |-DeclStmt
+//    |  | | | `-StaticAssertDecl
+//    |  | | |  |+StaticAssert = testdata/static-assert.c:43:5
+//    |  | | |  |+Cond = ConstantExpr
+//    |  | | |  | `+X = BinaryExpr
+//    |  | | |  |  |+X = n
+//    |  | | |  |  |+Op = "=="<PUNCTUATOR@testdata/static-assert.c:43:22>
+//    |  | | |  |  `+Y = 1
+//    |  | | |  |+Msg = "not constant"
+//    |  | | |  `+Semicolon = testdata/static-assert.c:43:43
+//    |  | | `-DeclStmt
+//    |  | |  `-StaticAssertDecl
+//    |  | |   |+StaticAssert = testdata/static-assert.c:44:5
+//    |  | |   |+Cond = ConstantExpr
+//    |  | |   | `+X = 0
+//    |  | |   |+Msg = <nil>
+//    |  | |   `+Semicolon = testdata/static-assert.c:44:22
+//    |  | `+Rbrace = testdata/static-assert.c:45:1
+//    |  |+InlineDef = false
+//    |  `+Attrs = 
+//    `+Unresolved = 
+// ===========================
+//
+// |-Error
+// | |+Pos = testdata/static-assert.c:28:1
+// | |+Typ = 0
+// | `+Msg = 在 testdata/static-assert.c 文件的第28行1列: 静态断言失败："header must be 12 bytes"
+// |-Error
+// | |+Pos = testdata/static-assert.c:29:22
+// | |+Typ = 0
+// | `+Msg = 在 testdata/static-assert.c 文件的第29行22列: 常量表达式中除数为零
+// |-Error
+// | |+Pos = testdata/static-assert.c:43:20
+// | |+Typ = 0
+// | `+Msg = 在 testdata/static-assert.c 文件的第43行20列: 这里应该是一个整数常量表达式
+// `-Error
+//  |+Pos = testdata/static-assert.c:44:5
+//  |+Typ = 0
+//  `+Msg = 在 testdata/static-assert.c 文件的第44行5列: 静态断言失败
+// ===========================
@@ -0,0 +1,479 @@
+[[deprecated("use new_api")]] int old_api(void);
+[[nodiscard]] int compute(int x);
+[[gnu::warn_unused_result]] int checked(void);
+[[noreturn]] void fatal(void);
+[[maybe_unused]] static int spare;
+static int hidden;
+static void helper(void) {}
+[[gnu::unused]] static void quiet(void) {}
+struct [[gnu::packed]] packet {
+    char tag;
+    int value [[deprecated]];
+};
+struct [[deprecated]] legacy { int x; };
+enum [[deprecated("use mode")]] old_mode { OLD_A };
+struct legacy stale;
+enum old_mode stale_mode;
+int arr [[maybe_unused]] [3];
+int * [[maybe_unused]] ptr;
+int (f [[maybe_unused]])(int);
+[[vendor::magic(1, +, "x")]] int tagged;
+[[unknown_attr]] int plain;
+[[nodiscard(1, 2)]] int bad_args(void);
+
+int compute(int x) {
+    [[maybe_unused]] int unused_ok;
+    int unused_bad;
+    compute(1);
+    (void)compute(2);
+    checked();
+    int v = old_api();
+    switch (x) {
+    case 1:
+        v++;
+        [[fallthrough]];
+    case 2:
+        v++;
+        [[fallthrough]];
+        v++;
+    case 3:
+        [[fallthrough]] v++;
+        break;
+    default:
+        break;
+    }
+    [[fallthrough]];
+    return v;
+}
+// ===========================
+// TranslationUnit
+//  `+Files = 
+//   `-File
+//    |+Name = testdata/std-attribute.c
+//    |+Decl = 
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[]
+//    | | |+Name = old_api
+//    | | |+Type =  int ( void)
+//    | | |+Decl = 
+//    | | |+Body = <nil>
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |  `-Attribute
+//    | |   |+Prefix = <nil>
+//    | |   |+Name = "deprecated"<IDENT@testdata/std-attribute.c:1:3>
+//    | |   |+Args = 
+//    | |   | `-"use new_api"
+//    | |   `+Std = true
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[]
+//    | | |+Name = compute
+//    | | |+Type =  int ( int)
+//    | | |+Decl = 
+//    | | |+Body = <nil>
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |  `-Attribute
+//    | |   |+Prefix = <nil>
+//    | |   |+Name = "nodiscard"<IDENT@testdata/std-attribute.c:2:3>
+//    | |   |+Args = 
+//    | |   `+Std = true
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[]
+//    | | |+Name = checked
+//    | | |+Type =  int ( void)
+//    | | |+Decl = 
+//    | | |+Body = <nil>
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |  `-Attribute
+//    | |   |+Prefix = "gnu"<IDENT@testdata/std-attribute.c:3:3>
+//    | |   |+Name = "warn_unused_result"<IDENT@testdata/std-attribute.c:3:8>
+//    | |   |+Args = 
+//    | |   `+Std = true
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[_Noreturn:testdata/std-attribute.c:4:3]
+//    | | |+Name = fatal
+//    | | |+Type =  void ( void)
+//    | | |+Decl = 
+//    | | |+Body = <nil>
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |  `-Attribute
+//    | |   |+Prefix = <nil>
+//    | |   |+Name = "noreturn"<IDENT@testdata/std-attribute.c:4:3>
+//    | |   |+Args = 
+//    | |   `+Std = true
+//    | |-VarDecl
+//    | | |+Qua = map[static:testdata/std-attribute.c:5:18]
+//    | | |+Type =  int
+//    | | |+Name = spare
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |  `-Attribute
+//    | |   |+Prefix = <nil>
+//    | |   |+Name = "maybe_unused"<IDENT@testdata/std-attribute.c:5:3>
+//    | |   |+Args = 
+//    | |   `+Std = true
+//    | |-VarDecl
+//    | | |+Qua = map[static:testdata/std-attribute.c:6:1]
+//    | | |+Type =  int
+//    | | |+Name = hidden
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-FuncDecl
+//    | | |+Qua = map[static:testdata/std-attribute.c:7:1]
+//    | | |+Spec = map[]
+//    | | |+Name = helper
+//    | | |+Type =  void ( void)
+//    | | |+Decl = 
+//    | | |+Body = CompoundStmt
+//    | | | |+Lbrace = testdata/std-attribute.c:7:26
+//    | | | |+Stmts = 
+//    | | | `+Rbrace = testdata/std-attribute.c:7:27
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |-FuncDecl
+//    | | |+Qua = map[static:testdata/std-attribute.c:8:17]
+//    | | |+Spec = map[]
+//    | | |+Name = quiet
+//    | | |+Type =  void ( void)
+//    | | |+Decl = 
+//    | | |+Body = CompoundStmt
+//    | | | |+Lbrace = testdata/std-attribute.c:8:41
+//    | | | |+Stmts = 
+//    | | | `+Rbrace = testdata/std-attribute.c:8:42
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |  `-Attribute
+//    | |   |+Prefix = "gnu"<IDENT@testdata/std-attribute.c:8:3>
+//    | |   |+Name = "unused"<IDENT@testdata/std-attribute.c:8:8>
+//    | |   |+Args = 
+//    | |   `+Std = true
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  struct packet
+//    | | |+Name = <nil>
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  struct legacy
+//    | | |+Name = <nil>
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  enum old_mode
+//    | | |+Name = <nil>
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  struct legacy
+//    | | |+Name = stale
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  enum old_mode
+//    | | |+Name = stale_mode
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =   int[3]
+//    | | |+Name = arr
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |  `-Attribute
+//    | |   |+Prefix = <nil>
+//    | |   |+Name = "maybe_unused"<IDENT@testdata/std-attribute.c:17:11>
+//    | |   |+Args = 
+//    | |   `+Std = true
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  int *
+//    | | |+Name = ptr
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[]
+//    | | |+Name = f
+//    | | |+Type = ( int) ( int)
+//    | | |+Decl = 
+//    | | |+Body = <nil>
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |  `-Attribute
+//    | |   |+Prefix = <nil>
+//    | |   |+Name = "maybe_unused"<IDENT@testdata/std-attribute.c:19:10>
+//    | |   |+Args = 
+//    | |   `+Std = true
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  int
+//    | | |+Name = tagged
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |  `-Attribute
+//    | |   |+Prefix = "vendor"<IDENT@testdata/std-attribute.c:20:3>
+//    | |   |+Name = "magic"<IDENT@testdata/std-attribute.c:20:11>
+//    | |   |+Args = 
+//    | |   `+Std = true
+//    | |-VarDecl
+//    | | |+Qua = map[]
+//    | | |+Type =  int
+//    | | |+Name = plain
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
+//    | |  `-Attribute
+//    | |   |+Prefix = <nil>
+//    | |   |+Name = "unknown_attr"<IDENT@testdata/std-attribute.c:21:3>
+//    | |   |+Args = 
+//    | |   `+Std = true
+//    | |-FuncDecl
+//    | | |+Qua = map[]
+//    | | |+Spec = map[]
+//    | | |+Name = bad_args
+//    | | |+Type =  int ( void)
+//    | | |+Decl = 
+//    | | |+Body = <nil>
+//    | | |+InlineDef = false
+//    | | `+Attrs = 
+//    | |  `-Attribute
+//    | |   |+Prefix = <nil>
+//    | |   |+Name = "nodiscard"<IDENT@testdata/std-attribute.c:22:3>
+//    | |   |+Args = 
+//    | |   | |-1
+//    | |   | `-2
+//    | |   `+Std = true
+//    | `-FuncDecl
+//    |  |+Qua = map[]
+//    |  |+Spec = map[]
+//    |  |+Name = compute
+//    |  |+Type =  int ( int)
+//    |  |+Decl = 
+//    |  |+Body = CompoundStmt
+//    |  | |+Lbrace = testdata/std-attribute.c:24:20
+//    |  | |+Stmts = 
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[]
+//    |  | | |  |+Type =  int
+//    |  | | |  |+Name = unused_ok
+//    |  | | |  |+Init = <nil>
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | |   `-Attribute
+//    |  | | |    |+Prefix = <nil>
+//    |  | | |    |+Name = "maybe_unused"<IDENT@testdata/std-attribute.c:25:7>
+//    |  | | |    |+Args = 
+//    |  | | |    `+Std = true
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[]
+//    |  | | |  |+Type =  int
+//    |  | | |  |+Name = unused_bad
+//    |  | | |  |+Init = <nil>
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | |-ExprStmt
+//    |  | | | |+Expr = CallExpr
+//    |  | | | | |+Func = compute
+//    |  | | | | |+Lparen = testdata/std-attribute.c:27:12
+//    |  | | | | |+Args = 
+//    |  | | | | | `-1
+//    |  | | | | `+Rparen = testdata/std-attribute.c:27:14
+//    |  | | | `+Semicolon = testdata/std-attribute.c:27:15
+//    |  | | |-ExprStmt
+//    |  | | | |+Expr = TypeCastExpr
+//    |  | | | | |+Lparen = testdata/std-attribute.c:28:5
+//    |  | | | | |+Type =  void
+//    |  | | | | |+Rparen = testdata/std-attribute.c:28:10
+//    |  | | | | `+X = CallExpr
+//    |  | | | |  |+Func = compute
+//    |  | | | |  |+Lparen = testdata/std-attribute.c:28:18
+//    |  | | | |  |+Args = 
+//    |  | | | |  | `-2
+//    |  | | | |  `+Rparen = testdata/std-attribute.c:28:20
+//    |  | | | `+Semicolon = testdata/std-attribute.c:28:21
+//    |  | | |-ExprStmt
+//    |  | | | |+Expr = CallExpr
+//    |  | | | | |+Func = checked
+//    |  | | | | |+Lparen = testdata/std-attribute.c:29:12
+//    |  | | | | |+Args = 
+//    |  | | | | `+Rparen = testdata/std-attribute.c:29:13
+//    |  | | | `+Semicolon = testdata/std-attribute.c:29:14
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[]
+//    |  | | |  |+Type =  int
+//    |  | | |  |+Name = v
+//    |  | | |  |+Init = CallExpr
+//    |  | | |  | |+Func = old_api
+//    |  | | |  | |+Lparen = testdata/std-attribute.c:30:20
+//    |  | | |  | |+Args = 
+//    |  | | |  | `+Rparen = testdata/std-attribute.c:30:21
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | |-SwitchStmt
+//    |  | | | |+Switch = testdata/std-attribute.c:31:5
+//    |  | | | |+X = x
+//    |  | | | `+Stmt = CompoundStmt
+//    |  | | |  |+Lbrace = testdata/std-attribute.c:31:16
+//    |  | | |  |+Stmts = 
+//    |  | | |  | |-CaseStmt
+//    |  | | |  | | |+Case = testdata/std-attribute.c:32:5
+//    |  | | |  | | |+Expr = ConstantExpr
+//    |  | | |  | | | `+X = 1
+//    |  | | |  | | `+Stmt = ExprStmt
+//    |  | | |  | |  |+Expr = UnaryExpr
+//    |  | | |  | |  | |+Op = "++"<PUNCTUATOR@testdata/std-attribute.c:33:10>
+//    |  | | |  | |  | `+X = v
+//    |  | | |  | |  `+Semicolon = testdata/std-attribute.c:33:12
+//    |  | | |  | |-AttributedStmt
+//    |  | | |  | | |+Attrs = 
+//    |  | | |  | | | `-Attribute
+//    |  | | |  | | |  |+Prefix = <nil>
+//    |  | | |  | | |  |+Name = "fallthrough"<IDENT@testdata/std-attribute.c:34:11>
+//    |  | | |  | | |  |+Args = 
+//    |  | | |  | | |  `+Std = true
+//    |  | | |  | | `+Stmt = EmptyStmt
+//    |  | | |  | |  `+Semicolon = testdata/std-attribute.c:34:24
+//    |  | | |  | |-CaseStmt
+//    |  | | |  | | |+Case = testdata/std-attribute.c:35:5
+//    |  | | |  | | |+Expr = ConstantExpr
+//    |  | | |  | | | `+X = 2
+//    |  | | |  | | `+Stmt = ExprStmt
+//    |  | | |  | |  |+Expr = UnaryExpr
+//    |  | | |  | |  | |+Op = "++"<PUNCTUATOR@testdata/std-attribute.c:36:10>
+//    |  | | |  | |  | `+X = v
+//    |  | | |  | |  `+Semicolon = testdata/std-attribute.c:36:12
+//    |  | | |  | |-AttributedStmt
+//    |  | | |  | | |+Attrs = 
+//    |  | | |  | | | `-Attribute
+//    |  | | |  | | |  |+Prefix = <nil>
+//    |  | | |  | | |  |+Name = "fallthrough"<IDENT@testdata/std-attribute.c:37:11>
+//    |  | | |  | | |  |+Args = 
+//    |  | | |  | | |  `+Std = true
+//    |  | | |  | | `+Stmt = EmptyStmt
+//    |  | | |  | |  `+Semicolon = testdata/std-attribute.c:37:24
+//    |  | | |  | |-ExprStmt
+//    |  | | |  | | |+Expr = UnaryExpr
+//    |  | | |  | | | |+Op = "++"<PUNCTUATOR@testdata/std-attribute.c:38:10>
+//    |  | | |  | | | `+X = v
+//    |  | | |  | | `+Semicolon = testdata/std-attribute.c:38:12
+//    |  | | |  | |-CaseStmt
+//    |  | | |  | | |+Case = testdata/std-attribute.c:39:5
+//    |  | | |  | | |+Expr = ConstantExpr
+//    |  | | |  | | | `+X = 3
+//    |  | | |  | | `+Stmt = AttributedStmt
+//    |  | | |  | |  |+Attrs = 
+//    |  | | |  | |  | `-Attribute
+//    |  | | |  | |  |  |+Prefix = <nil>
+//    |  | | |  | |  |  |+Name = "fallthrough"<IDENT@testdata/std-attribute.c:40:11>
+//    |  | | |  | |  |  |+Args = 
+//    |  | | |  | |  |  `+Std = true
+//    |  | | |  | |  `+Stmt = ExprStmt
+//    |  | | |  | |   |+Expr = UnaryExpr
+//    |  | | |  | |   | |+Op = "++"<PUNCTUATOR@testdata/std-attribute.c:40:26>
+//    |  | | |  | |   | `+X = v
+//    |  | | |  | |   `+Semicolon = testdata/std-attribute.c:40:28
+//    |  | | |  | |-BreakStmt
+//    |  | | |  | | |+Break = testdata/std-attribute.c:41:9
+//    |  | | |  | | `+Semicolon = testdata/std-attribute.c:41:14
+//    |  | | |  | `-DefaultStmt
+//    |  | | |  |  |+Default = testdata/std-attribute.c:42:5
+//    |  | | |  |  `+Stmt = BreakStmt
+//    |  | | |  |   |+Break = testdata/std-attribute.c:43:9
+//    |  | | |  |   `+Semicolon = testdata/std-attribute.c:43:14
+//    |  | | |  `+Rbrace = testdata/std-attribute.c:44:5
+//    |  | | |-AttributedStmt
+//    |  | | | |+Attrs = 
+//    |  | | | | `-Attribute
+//    |  | | | |  |+Prefix = <nil>
+//    |  | | | |  |+Name = "fallthrough"<IDENT@testdata/std-attribute.c:45:7>
+//    |  | | | |  |+Args = 
+//    |  | | | |  `+Std = true
+//    |  | | | `+Stmt = EmptyStmt
+//    |  | | |  `+Semicolon = testdata/std-attribute.c:45:20
+//    |  | | `-ReturnStmt
+//    |  | |  |+Return = testdata/std-attribute.c:46:5
+//    |  | |  |+X = v
+//    |  | |  `+Semicolon = testdata/std-attribute.c:46:13
+//    |  | `+Rbrace = testdata/std-attribute.c:47:1
+//    |  |+InlineDef = false
+//    |  `+Attrs = 
+//    `+Unresolved = 
+// ===========================
+//
+// |-Error
+// | |+Pos = testdata/std-attribute.c:15:8
+// | |+Typ = 1
+// | `+Msg = 在 testdata/std-attribute.c 文件的第15行8列: struct legacy 已弃用
+// |-Error
+// | |+Pos = testdata/std-attribute.c:16:6
+// | |+Typ = 1
+// | `+Msg = 在 testdata/std-attribute.c 文件的第16行6列: enum old_mode 已弃用，"use mode"
+// |-Error
+// | |+Pos = testdata/std-attribute.c:21:3
+// | |+Typ = 1
+// | `+Msg = 在 testdata/std-attribute.c 文件的第21行3列: 未知的属性 unknown_attr，已忽略
+// |-Error
+// | |+Pos = testdata/std-attribute.c:22:3
+// | |+Typ = 0
+// | `+Msg = 在 testdata/std-attribute.c 文件的第22行3列: 属性 nodiscard 的参数数量应为 0 到 1，使用了 2 个参数
+// |-Error
+// | |+Pos = testdata/std-attribute.c:27:5
+// | |+Typ = 1
+// | `+Msg = 在 testdata/std-attribute.c 文件的第27行5列: 忽略了 nodiscard 函数 compute 的返回值
+// |-Error
+// | |+Pos = testdata/std-attribute.c:29:5
+// | |+Typ = 1
+// | `+Msg = 在 testdata/std-attribute.c 文件的第29行5列: 忽略了 nodiscard 函数 checked 的返回值
+// |-Error
+// | |+Pos = testdata/std-attribute.c:30:13
+// | |+Typ = 1
+// | `+Msg = 在 testdata/std-attribute.c 文件的第30行13列: old_api 已弃用，"use new_api"
+// |-Error
+// | |+Pos = testdata/std-attribute.c:37:11
+// | |+Typ = 0
+// | `+Msg = 在 testdata/std-attribute.c 文件的第37行11列: fallthrough 属性只能用于 case 或 default 标签之前的空语句
+// |-Error
+// | |+Pos = testdata/std-attribute.c:40:11
+// | |+Typ = 0
+// | `+Msg = 在 testdata/std-attribute.c 文件的第40行11列: fallthrough 属性只能用于 case 或 default 标签之前的空语句
+// |-Error
+// | |+Pos = testdata/std-attribute.c:45:7
+// | |+Typ = 0
+// | `+Msg = 在 testdata/std-attribute.c 文件的第45行7列: fallthrough 属性只能用于 case 或 default 标签之前的空语句
+// |-Error
+// | |+Pos = testdata/std-attribute.c:26:9
+// | |+Typ = 1
+// | `+Msg = 在 testdata/std-attribute.c 文件的第26行9列: 变量 unused_bad 未使用
+// |-Error
+// | |+Pos = testdata/std-attribute.c:6:12
+// | |+Typ = 1
+// | `+Msg = 在 testdata/std-attribute.c 文件的第6行12列: 变量 hidden 未使用
+// `-Error
+//  |+Pos = testdata/std-attribute.c:7:13
+//  |+Typ = 1
+//  `+Msg = 在 testdata/std-attribute.c 文件的第7行13列: 静态函数 helper 已定义但未使用
+// ===========================
@@ -0,0 +1,114 @@
+int classify(int c) {
+    int max = ({ c; });
+    switch (max) {
+    case 0 ... 9:
+        return 1;
+    }
+    void *next = &&done;
+    goto *next;
+done:
+    return c ?: max;
+}
+// ===========================
+// TranslationUnit
+//  `+Files = 
+//   `-File
+//    |+Name = testdata/std-gnu-extension.c
+//    |+Decl = 
+//    | `-FuncDecl
+//    |  |+Qua = map[]
+//    |  |+Spec = map[]
+//    |  |+Name = classify
+//    |  |+Type =  int ( int)
+//    |  |+Decl = 
+//    |  |+Body = CompoundStmt
+//    |  | |+Lbrace = testdata/std-gnu-extension.c:1:21
+//    |  | |+Stmts = 
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[]
+//    |  | | |  |+Type =  int
+//    |  | | |  |+Name = max
+//    |  | | |  |+Init = StmtExpr
+//    |  | | |  | |+Lparen = testdata/std-gnu-extension.c:2:15
+//    |  | | |  | |+Body = CompoundStmt
+//    |  | | |  | | |+Lbrace = testdata/std-gnu-extension.c:2:16
+//    |  | | |  | | |+Stmts = 
+//    |  | | |  | | | `-ExprStmt
+//    |  | | |  | | |  |+Expr = c
+//    |  | | |  | | |  `+Semicolon = testdata/std-gnu-extension.c:2:19
+//    |  | | |  | | `+Rbrace = testdata/std-gnu-extension.c:2:21
+//    |  | | |  | `+Rparen = testdata/std-gnu-extension.c:2:22
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | |-SwitchStmt
+//    |  | | | |+Switch = testdata/std-gnu-extension.c:3:5
+//    |  | | | |+X = max
+//    |  | | | `+Stmt = CompoundStmt
+//    |  | | |  |+Lbrace = testdata/std-gnu-extension.c:3:18
+//    |  | | |  |+Stmts = 
+//    |  | | |  | `-CaseRangeStmt
+//    |  | | |  |  |+Case = testdata/std-gnu-extension.c:4:5
+//    |  | | |  |  |+Low = ConstantExpr
+//    |  | | |  |  | `+X = 0
+//    |  | | |  |  |+Ellipsis = testdata/std-gnu-extension.c:4:12
+//    |  | | |  |  |+High = ConstantExpr
+//    |  | | |  |  | `+X = 9
+//    |  | | |  |  `+Stmt = ReturnStmt
+//    |  | | |  |   |+Return = testdata/std-gnu-extension.c:5:9
+//    |  | | |  |   |+X = 1
+//    |  | | |  |   `+Semicolon = testdata/std-gnu-extension.c:5:17
+//    |  | | |  `+Rbrace = testdata/std-gnu-extension.c:6:5
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[]
+//    |  | | |  |+Type =  void *
+//    |  | | |  |+Name = next
+//    |  | | |  |+Init = LabelAddrExpr
+//    |  | | |  | |+AndAnd = testdata/std-gnu-extension.c:7:18
+//    |  | | |  | `+Label = done
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | |-IndirectGotoStmt
+//    |  | | | |+Goto = testdata/std-gnu-extension.c:8:5
+//    |  | | | |+Star = testdata/std-gnu-extension.c:8:10
+//    |  | | | |+X = next
+//    |  | | | `+Semicolon = testdata/std-gnu-extension.c:8:15
+//    |  | | `-LabelStmt
+//    |  | |  |+Id = done
+//    |  | |  |+Attrs = 
+//    |  | |  `+Stmt = ReturnStmt
+//    |  | |   |+Return = testdata/std-gnu-extension.c:10:5
+//    |  | |   |+X = BinaryCondExpr
+//    |  | |   | |+X = c
+//    |  | |   | |+Op = "?"<PUNCTUATOR@testdata/std-gnu-extension.c:10:14>
+//    |  | |   | |+Colon = testdata/std-gnu-extension.c:10:15
+//    |  | |   | `+Else = max
+//    |  | |   `+Semicolon = testdata/std-gnu-extension.c:10:20
+//    |  | `+Rbrace = testdata/std-gnu-extension.c:11:1
+//    |  |+InlineDef = false
+//    |  `+Attrs = 
+//    `+Unresolved = 
+// ===========================
+//
+// |-Error
+// | |+Pos = testdata/std-gnu-extension.c:2:15
+// | |+Typ = 0
+// | `+Msg = 在 testdata/std-gnu-extension.c 文件的第2行15列: 语句表达式 是 GNU 扩展，需要启用 GNU 模式
+// |-Error
+// | |+Pos = testdata/std-gnu-extension.c:4:12
+// | |+Typ = 0
+// | `+Msg = 在 testdata/std-gnu-extension.c 文件的第4行12列: case 范围 是 GNU 扩展，需要启用 GNU 模式
+// |-Error
+// | |+Pos = testdata/std-gnu-extension.c:7:18
+// | |+Typ = 0
+// | `+Msg = 在 testdata/std-gnu-extension.c 文件的第7行18列: 标签地址 是 GNU 扩展，需要启用 GNU 模式
+// |-Error
+// | |+Pos = testdata/std-gnu-extension.c:8:10
+// | |+Typ = 0
+// | `+Msg = 在 testdata/std-gnu-extension.c 文件的第8行10列: 计算跳转 是 GNU 扩展，需要启用 GNU 模式
+// `-Error
+//  |+Pos = testdata/std-gnu-extension.c:10:14
+//  |+Typ = 0
+//  `+Msg = 在 testdata/std-gnu-extension.c 文件的第10行14列: 省略中间操作数的条件表达式 是 GNU 扩展，需要启用 GNU 模式
+// ===========================
@@ -0,0 +1,71 @@
+#include "printf.h"
+
+int main() {
+    printf("hello, " "world" "\n");
+    printf(u8"a" "b");
+    printf(u8"a" L"b");
+}
+
+// ===========================
+// TranslationUnit
+//  `+Files = 
+//   |-File
+//   | |+Name = testdata/printf.h
+//   | |+Decl = 
+//   | | `-FuncDecl
+//   | |  |+Qua = map[]
+//   | |  |+Spec = map[]
+//   | |  |+Name = printf
+//   | |  |+Type =  int (const char *,...)
+//   | |  |+Decl = 
+//   | |  |+Body = <nil>
+//   | |  |+InlineDef = false
+//   | |  `+Attrs = 
+//   | `+Unresolved = 
+//   `-File
+//    |+Name = testdata/string.c
+//    |+Decl = 
+//    | `-FuncDecl
+//    |  |+Qua = map[]
+//    |  |+Spec = map[]
+//    |  |+Name = main
+//    |  |+Type =  int ()
+//    |  |+Decl = 
+//    |  |+Body = CompoundStmt
+//    |  | |+Lbrace = testdata/string.c:3:12
+//    |  | |+Stmts = 
+//    |  | | |-ExprStmt
+//    |  | | | |+Expr = CallExpr
+//    |  | | | | |+Func = printf
+//    |  | | | | |+Lparen = testdata/string.c:4:11
+//    |  | | | | |+Args = 
+//    |  | | | | | `-"hello, " "world" "\n"
+//    |  | | | | `+Rparen = testdata/string.c:4:34
+//    |  | | | `+Semicolon = testdata/string.c:4:35
+//    |  | | |-ExprStmt
+//    |  | | | |+Expr = CallExpr
+//    |  | | | | |+Func = printf
+//    |  | | | | |+Lparen = testdata/string.c:5:11
+//    |  | | | | |+Args = 
+//    |  | | | | | `-u8"a" "b"
+//    |  | | | | `+Rparen = testdata/string.c:5:21
+//    |  | | | `+Semicolon = testdata/string.c:5:22
+//    |  | | `-ExprStmt
+//    |  | |  |+Expr = CallExpr
+//    |  | |  | |+Func = printf
+//    |  | |  | |+Lparen = testdata/string.c:6:11
+//    |  | |  | |+Args = 
+//    |  | |  | | `-u8"a" L"b"
+//    |  | |  | `+Rparen = testdata/string.c:6:22
+//    |  | |  `+Semicolon = testdata/string.c:6:23
+//    |  | `+Rbrace = testdata/string.c:7:1
+//    |  |+InlineDef = false
+//    |  `+Attrs = 
+//    `+Unresolved = 
+// ===========================
+//
+// `-Error
+//  |+Pos = testdata/string.c:6:18
+//  |+Typ = 0
+//  `+Msg = 在 testdata/string.c 文件的第6行18列: 不能连接不同编码的字符串 u8"a" 和 L"b"
+// ===========================
@@ -8,35 +8,49 @@ int main() {
 // TranslationUnit
 //  `+Files = 
 //   `-File
-//    |+Name = testdata\type-cast.c
+//    |+Name = testdata/type-cast.c
 //    |+Decl = 
 //    | `-FuncDecl
+//    |  |+Qua = map[]
+//    |  |+Spec = map[]
 //    |  |+Name = main
 //    |  |+Type =  int ()
 //    |  |+Decl = 
-//    |  `+Body = CompoundStmt
-//    |   |+Lbrace = testdata\type-cast.c:1:12
-//    |   |+Stmts = 
-//    |   | |-DeclStmt
-//    |   | | `-VarDecl
-//    |   | |  |+Type =  int
-//    |   | |  |+Name = a
-//    |   | |  `+Init = <nil>
-//    |   | |-DeclStmt
-//    |   | | `-VarDecl
-//    |   | |  |+Type =  char
-//    |   | |  |+Name = b
-//    |   | |  `+Init = TypeCastExpr
-//    |   | |   |+Lparen = testdata\type-cast.c:3:14
-//    |   | |   |+Type =  char
-//    |   | |   |+Rparen = testdata\type-cast.c:3:19
-//    |   | |   `+X = a
-//    |   | `-ReturnStmt
-//    |   |  |+Return = testdata\type-cast.c:4:5
-//    |   |  |+X = 0
-//    |   |  `+Semicolon = testdata\type-cast.c:4:13
-//    |   `+Rbrace = testdata\type-cast.c:5:1
+//    |  |+Body = CompoundStmt
+//    |  | |+Lbrace = testdata/type-cast.c:1:12
+//    |  | |+Stmts = 
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[]
+//    |  | | |  |+Type =  int
+//    |  | | |  |+Name = a
+//    |  | | |  |+Init = <nil>
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[]
+//    |  | | |  |+Type =  char
+//    |  | | |  |+Name = b
+//    |  | | |  |+Init = TypeCastExpr
+//    |  | | |  | |+Lparen = testdata/type-cast.c:3:14
+//    |  | | |  | |+Type =  char
+//    |  | | |  | |+Rparen = testdata/type-cast.c:3:19
+//    |  | | |  | `+X = a
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | `-ReturnStmt
+//    |  | |  |+Return = testdata/type-cast.c:4:5
+//    |  | |  |+X = 0
+//    |  | |  `+Semicolon = testdata/type-cast.c:4:13
+//    |  | `+Rbrace = testdata/type-cast.c:5:1
+//    |  |+InlineDef = false
+//    |  `+Attrs = 
 //    `+Unresolved = 
 // ===========================
 //
+// `-Error
+//  |+Pos = testdata/type-cast.c:3:10
+//  |+Typ = 1
+//  `+Msg = 在 testdata/type-cast.c 文件的第3行10列: 变量 b 未使用
 // ===========================
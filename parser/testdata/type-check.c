@@ -13,49 +13,78 @@ int main() {
 // TranslationUnit
 //  `+Files = 
 //   `-File
-//    |+Name = testdata\type-check.c
+//    |+Name = testdata/type-check.c
 //    |+Decl = 
 //    | |-TypedefDecl
-//    | | |+Typedef = testdata\type-check.c:1:1
+//    | | |+Typedef = testdata/type-check.c:1:1
 //    | | |+Type =  struct tree
-//    | | `+Name = tree
+//    | | |+Name = tree
+//    | | `+Attrs = 
 //    | `-FuncDecl
+//    |  |+Qua = map[]
+//    |  |+Spec = map[]
 //    |  |+Name = main
 //    |  |+Type =  int ()
 //    |  |+Decl = 
-//    |  `+Body = CompoundStmt
-//    |   |+Lbrace = testdata\type-check.c:5:12
-//    |   |+Stmts = 
-//    |   | |-DeclStmt
-//    |   | | `-VarDecl
-//    |   | |  |+Type =  int
-//    |   | |  |+Name = a
-//    |   | |  `+Init = <nil>
-//    |   | |-DeclStmt
-//    |   | | `-VarDecl
-//    |   | |  |+Type =  char
-//    |   | |  |+Name = b
-//    |   | |  `+Init = <nil>
-//    |   | |-DeclStmt
-//    |   | | `-VarDecl
-//    |   | |  |+Type =  struct tree *
-//    |   | |  |+Name = tree
-//    |   | |  `+Init = <nil>
-//    |   | `-DeclStmt
-//    |   |  `-VarDecl
-//    |   |   |+Type =  struct tree *
-//    |   |   |+Name = tree
-//    |   |   `+Init = <nil>
-//    |   `+Rbrace = testdata\type-check.c:10:1
+//    |  |+Body = CompoundStmt
+//    |  | |+Lbrace = testdata/type-check.c:5:12
+//    |  | |+Stmts = 
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[]
+//    |  | | |  |+Type =  int
+//    |  | | |  |+Name = a
+//    |  | | |  |+Init = <nil>
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[]
+//    |  | | |  |+Type =  char
+//    |  | | |  |+Name = b
+//    |  | | |  |+Init = <nil>
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[]
+//    |  | | |  |+Type =  struct tree *
+//    |  | | |  |+Name = tree
+//    |  | | |  |+Init = <nil>
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | `-DeclStmt
+//    |  | |  `-VarDecl
+//    |  | |   |+Qua = map[]
+//    |  | |   |+Type =  struct tree *
+//    |  | |   |+Name = tree
+//    |  | |   |+Init = <nil>
+//    |  | |   |+Align = 
+//    |  | |   `+Attrs = 
+//    |  | `+Rbrace = testdata/type-check.c:10:1
+//    |  |+InlineDef = false
+//    |  `+Attrs = 
 //    `+Unresolved = 
 // ===========================
 //
 // |-Error
-// | |+Pos = testdata\type-check.c:2:12
+// | |+Pos = testdata/type-check.c:2:12
 // | |+Typ = 0
-// | `+Msg = 在 testdata\type-check.c 文件的第2行12列: 不完全的结构体类型 tree
+// | `+Msg = 在 testdata/type-check.c 文件的第2行12列: 不完全的结构体类型 tree
+// |-Error
+// | |+Pos = testdata/type-check.c:9:18
+// | |+Typ = 0
+// | `+Msg = 在 testdata/type-check.c 文件的第9行18列: 重复声明的变量名 tree，上次声明的位置 testdata/type-check.c:8:12
+// |-Error
+// | |+Pos = testdata/type-check.c:6:9
+// | |+Typ = 1
+// | `+Msg = 在 testdata/type-check.c 文件的第6行9列: 变量 a 未使用
+// |-Error
+// | |+Pos = testdata/type-check.c:7:10
+// | |+Typ = 1
+// | `+Msg = 在 testdata/type-check.c 文件的第7行10列: 变量 b 未使用
 // `-Error
-//  |+Pos = testdata\type-check.c:9:18
-//  |+Typ = 0
-//  `+Msg = 在 testdata\type-check.c 文件的第9行18列: 重复声明的变量名 tree，上次声明的位置 testdata\type-check.c:8:12
+//  |+Pos = testdata/type-check.c:8:12
+//  |+Typ = 1
+//  `+Msg = 在 testdata/type-check.c 文件的第8行12列: 变量 tree 未使用
 // ===========================
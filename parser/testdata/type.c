@@ -23,122 +23,173 @@ int main() {
 // TranslationUnit
 //  `+Files = 
 //   `-File
-//    |+Name = testdata\type.c
+//    |+Name = testdata/type.c
 //    |+Decl = 
 //    | |-VarDecl
+//    | | |+Qua = map[]
 //    | | |+Type =  int
 //    | | |+Name = i
-//    | | `+Init = <nil>
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
 //    | |-VarDecl
+//    | | |+Qua = map[]
 //    | | |+Type = const int
 //    | | |+Name = ci
-//    | | `+Init = <nil>
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
 //    | |-VarDecl
+//    | | |+Qua = map[]
 //    | | |+Type = const int
 //    | | |+Name = clli
-//    | | `+Init = 10
+//    | | |+Init = 10
+//    | | |+Align = 
+//    | | `+Attrs = 
 //    | |-VarDecl
+//    | | |+Qua = map[]
 //    | | |+Type = const double
 //    | | |+Name = cd
-//    | | `+Init = <nil>
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
 //    | |-VarDecl
-//    | | |+Type =  const double[]
+//    | | |+Qua = map[]
+//    | | |+Type =  const long double[]
 //    | | |+Name = cld
-//    | | `+Init = InitializerExpr
-//    | |  |+Lbrace = testdata\type.c:5:27
-//    | |  |+List = 
-//    | |  | |-1
-//    | |  | |-2
-//    | |  | |-3
-//    | |  | `-4
-//    | |  `+Rbrace = testdata\type.c:5:35
+//    | | |+Init = InitializerExpr
+//    | | | |+Lbrace = testdata/type.c:5:27
+//    | | | |+List = 
+//    | | | | |-1
+//    | | | | |-2
+//    | | | | |-3
+//    | | | | `-4
+//    | | | `+Rbrace = testdata/type.c:5:35
+//    | | |+Align = 
+//    | | `+Attrs = 
 //    | |-VarDecl
+//    | | |+Qua = map[]
 //    | | |+Type = const float
 //    | | |+Name = cf
-//    | | `+Init = <nil>
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
 //    | |-VarDecl
-//    | | |+Type = const int
+//    | | |+Qua = map[]
+//    | | |+Type = const short
 //    | | |+Name = is_err
-//    | | `+Init = <nil>
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
 //    | |-VarDecl
+//    | | |+Qua = map[extern:testdata/type.c:8:1]
 //    | | |+Type =  int *
 //    | | |+Name = ei_v
-//    | | `+Init = <nil>
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
 //    | |-VarDecl
+//    | | |+Qua = map[static:testdata/type.c:9:1]
 //    | | |+Type =  int *const
 //    | | |+Name = si
-//    | | `+Init = <nil>
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
 //    | |-VarDecl
+//    | | |+Qua = map[]
 //    | | |+Type = ( int)
 //    | | |+Name = a
-//    | | `+Init = <nil>
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
 //    | |-VarDecl
+//    | | |+Qua = map[]
 //    | | |+Type =  struct tree
 //    | | |+Name = abc
-//    | | `+Init = <nil>
+//    | | |+Init = <nil>
+//    | | |+Align = 
+//    | | `+Attrs = 
 //    | `-FuncDecl
+//    |  |+Qua = map[]
+//    |  |+Spec = map[]
 //    |  |+Name = main
 //    |  |+Type =  int ()
 //    |  |+Decl = 
-//    |  `+Body = CompoundStmt
-//    |   |+Lbrace = testdata\type.c:16:12
-//    |   |+Stmts = 
-//    |   | |-DeclStmt
-//    |   | | `-VarDecl
-//    |   | |  |+Type =  int
-//    |   | |  |+Name = a
-//    |   | |  `+Init = <nil>
-//    |   | |-DeclStmt
-//    |   | | `-VarDecl
-//    |   | |  |+Type =  char
-//    |   | |  |+Name = b
-//    |   | |  `+Init = <nil>
-//    |   | `-ReturnStmt
-//    |   |  |+Return = testdata\type.c:19:5
-//    |   |  |+X = 0
-//    |   |  `+Semicolon = testdata\type.c:19:13
-//    |   `+Rbrace = testdata\type.c:20:1
+//    |  |+Body = CompoundStmt
+//    |  | |+Lbrace = testdata/type.c:16:12
+//    |  | |+Stmts = 
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[]
+//    |  | | |  |+Type =  int
+//    |  | | |  |+Name = a
+//    |  | | |  |+Init = <nil>
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | |-DeclStmt
+//    |  | | | `-VarDecl
+//    |  | | |  |+Qua = map[]
+//    |  | | |  |+Type =  char
+//    |  | | |  |+Name = b
+//    |  | | |  |+Init = <nil>
+//    |  | | |  |+Align = 
+//    |  | | |  `+Attrs = 
+//    |  | | `-ReturnStmt
+//    |  | |  |+Return = testdata/type.c:19:5
+//    |  | |  |+X = 0
+//    |  | |  `+Semicolon = testdata/type.c:19:13
+//    |  | `+Rbrace = testdata/type.c:20:1
+//    |  |+InlineDef = false
+//    |  `+Attrs = 
 //    `+Unresolved = 
 // ===========================
 //
 // |-Error
-// | |+Pos = testdata\type.c:3:17
+// | |+Pos = testdata/type.c:3:17
 // | |+Typ = 0
-// | `+Msg = 在 testdata\type.c 文件的第3行17列: 非预期的类型定义符号 long
+// | `+Msg = 在 testdata/type.c 文件的第3行17列: 类型说明符中的 long 过多
 // |-Error
-// | |+Pos = testdata\type.c:7:11
+// | |+Pos = testdata/type.c:10:7
 // | |+Typ = 0
-// | `+Msg = 在 testdata\type.c 文件的第7行11列: 非预期的类型定义符号 short
+// | `+Msg = 在 testdata/type.c 文件的第10行7列: 这里应该是一个 ) ，不应该出现 *
 // |-Error
-// | |+Pos = testdata\type.c:10:7
+// | |+Pos = testdata/type.c:10:7
 // | |+Typ = 0
-// | `+Msg = 在 testdata\type.c 文件的第10行7列: 这里应该是一个 ) ，不应该出现 *
+// | `+Msg = 在 testdata/type.c 文件的第10行7列: 这里应该是一个 ; ，不应该出现 *
 // |-Error
-// | |+Pos = testdata\type.c:10:7
+// | |+Pos = testdata/type.c:10:7
 // | |+Typ = 0
-// | `+Msg = 在 testdata\type.c 文件的第10行7列: 这里应该是一个 ; ，不应该出现 *
+// | `+Msg = 在 testdata/type.c 文件的第10行7列: 这里应该是一个 定义语句 ，不应该出现 *
 // |-Error
-// | |+Pos = testdata\type.c:10:7
+// | |+Pos = testdata/type.c:10:8
 // | |+Typ = 0
-// | `+Msg = 在 testdata\type.c 文件的第10行7列: 这里应该是一个 定义语句 ，不应该出现 *
+// | `+Msg = 在 testdata/type.c 文件的第10行8列: 这里应该是一个 定义语句 ，不应该出现 )
 // |-Error
-// | |+Pos = testdata\type.c:10:8
+// | |+Pos = testdata/type.c:10:9
 // | |+Typ = 0
-// | `+Msg = 在 testdata\type.c 文件的第10行8列: 这里应该是一个 定义语句 ，不应该出现 )
+// | `+Msg = 在 testdata/type.c 文件的第10行9列: 这里应该是一个 定义语句 ，不应该出现 (
 // |-Error
-// | |+Pos = testdata\type.c:10:9
+// | |+Pos = testdata/type.c:10:10
 // | |+Typ = 0
-// | `+Msg = 在 testdata\type.c 文件的第10行9列: 这里应该是一个 定义语句 ，不应该出现 (
+// | `+Msg = 在 testdata/type.c 文件的第10行10列: 这里应该是一个 定义语句 ，不应该出现 )
 // |-Error
-// | |+Pos = testdata\type.c:10:10
+// | |+Pos = testdata/type.c:10:11
 // | |+Typ = 0
-// | `+Msg = 在 testdata\type.c 文件的第10行10列: 这里应该是一个 定义语句 ，不应该出现 )
+// | `+Msg = 在 testdata/type.c 文件的第10行11列: 这里应该是一个 定义语句 ，不应该出现 ;
 // |-Error
-// | |+Pos = testdata\type.c:10:11
+// | |+Pos = testdata/type.c:13:12
 // | |+Typ = 0
-// | `+Msg = 在 testdata\type.c 文件的第10行11列: 这里应该是一个 定义语句 ，不应该出现 ;
+// | `+Msg = 在 testdata/type.c 文件的第13行12列: 不完全的结构体类型 tree
+// |-Error
+// | |+Pos = testdata/type.c:17:9
+// | |+Typ = 1
+// | `+Msg = 在 testdata/type.c 文件的第17行9列: 变量 a 未使用
+// |-Error
+// | |+Pos = testdata/type.c:18:10
+// | |+Typ = 1
+// | `+Msg = 在 testdata/type.c 文件的第18行10列: 变量 b 未使用
 // `-Error
-//  |+Pos = testdata\type.c:13:12
-//  |+Typ = 0
-//  `+Msg = 在 testdata\type.c 文件的第13行12列: 不完全的结构体类型 tree
+//  |+Pos = testdata/type.c:9:19
+//  |+Typ = 1
+//  `+Msg = 在 testdata/type.c 文件的第9行19列: 变量 si 未使用
 // ===========================
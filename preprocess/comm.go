@@ -42,8 +42,15 @@ func tokenString(tks []token.Token) string {
 			}
 		}
 
-		col += utf8.RuneCountInString(tok.Literal())
-		str += tok.Literal()
+		lit := tok.Literal()
+		// 多行注释
+		if i := strings.LastIndexByte(lit, '\n'); i >= 0 {
+			line += strings.Count(lit, "\n")
+			col = 1 + utf8.RuneCountInString(lit[i+1:])
+		} else {
+			col += utf8.RuneCountInString(lit)
+		}
+		str += lit
 	}
 	return str
 }
@@ -106,10 +113,12 @@ func copyToken(t token.Token) token.Token {
 				Filename: t.Position().Filename,
 				Line:     t.Position().Line,
 				Column:   t.Position().Column,
+				Offset:   t.Position().Offset,
 			},
 			Typ:    t.Type(),
 			Lit:    t.Literal(),
 			Expand: copyToken(v.Expand),
+			Spell:  v.Spell,
 		}
 	default:
 		return &scanner.Token{
@@ -117,6 +126,7 @@ func copyToken(t token.Token) token.Token {
 				Filename: t.Position().Filename,
 				Line:     t.Position().Line,
 				Column:   t.Position().Column,
+				Offset:   t.Position().Offset,
 			},
 			Typ: t.Type(),
 			Lit: t.Literal(),
@@ -124,6 +134,32 @@ func copyToken(t token.Token) token.Token {
 	}
 }
 
+// 去掉首尾空白
+func trimWhitespace(tks []token.Token) []token.Token {
+	for len(tks) > 0 && tks[0].Type() == token.WHITESPACE {
+		tks = tks[1:]
+	}
+	for len(tks) > 0 && tks[len(tks)-1].Type() == token.WHITESPACE {
+		tks = tks[:len(tks)-1]
+	}
+	return tks
+}
+
+// 为没有位置信息的 token 按顺序分配列位置，以空格分隔
+func layoutTokens(tks []token.Token) []token.Token {
+	col := 1
+	for i := range tks {
+		pos := tks[i].Position()
+		if pos.Line == 0 {
+			pos.Line = 1
+			pos.Column = col
+			tks[i] = newTokenPos(tks[i], pos)
+		}
+		col = pos.Column + utf8.RuneCountInString(tks[i].Literal()) + 1
+	}
+	return tks
+}
+
 func copyTokenSlice(tks []token.Token) (cpy []token.Token) {
 	for i := range tks {
 		cpy = append(cpy, copyToken(tks[i]))
@@ -207,3 +243,8 @@ func exists(name string) bool {
 	}
 	return false
 }
+
+// 空白或注释
+func isSpace(tok token.Token) bool {
+	return tok.Type() == token.WHITESPACE || tok.Type() == token.COMMENT
+}
@@ -0,0 +1,301 @@
+package preprocess
+
+import (
+	"dxkite.cn/c/errors"
+	"dxkite.cn/c/scanner"
+	"dxkite.cn/c/token"
+	"io/ioutil"
+	"sort"
+)
+
+type (
+	// 条件编译块 #if ... #endif
+	CondBlock struct {
+		Pos      token.Position // #if #ifdef #ifndef 位置
+		End      token.Position // #endif 位置
+		Macros   []string       // 依赖的宏
+		Branches []*CondBranch  // 分支
+	}
+
+	// 条件分支 #if/#elif/#else 控制的区域
+	CondBranch struct {
+		Directive string         // 指令名称
+		Pos       token.Position // 指令位置
+		Cond      Formula        // 分支自身的条件
+		Presence  Formula        // 区域的存在条件
+		Begin     int            // 区域起始行
+		End       int            // 区域结束行
+		Dead      bool           // 永远不会被编译
+		Blocks    []*CondBlock   // 嵌套的条件块
+	}
+)
+
+// 配置空间分析
+// 分析条件编译的结构，给出每个区域的存在条件
+type ConfigSpace struct {
+	Blocks  []*CondBlock                // 顶层条件块
+	Defined map[string][]token.Position // 定义过的宏
+	Tested  map[string][]token.Position // 条件中测试过的宏
+	ctx     *Context
+	err     errors.ErrorList
+}
+
+// 创建配置空间分析，ctx 中的宏视为已定义
+func NewConfigSpace(ctx *Context) *ConfigSpace {
+	if ctx == nil {
+		ctx = NewContext()
+	}
+	return &ConfigSpace{
+		Defined: map[string][]token.Position{},
+		Tested:  map[string][]token.Position{},
+		ctx:     ctx,
+		err:     errors.ErrorList{},
+	}
+}
+
+// 分析文件
+func (c *ConfigSpace) AddFile(filename string) error {
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	c.AddSource(filename, string(b))
+	return nil
+}
+
+// 分析代码
+func (c *ConfigSpace) AddSource(filename, src string) {
+	lines := readLogicLines(src)
+	var stack []*CondBlock
+	addBranch := func(blk *CondBlock, l *logicLine, cond Formula) {
+		parent := c.outer(stack)
+		if n := len(blk.Branches); n > 0 {
+			blk.Branches[n-1].End = l.Line - 1
+		}
+		// 同级之前的分支都不成立
+		var rest []Formula
+		for _, v := range blk.Branches {
+			rest = append(rest, Not(v.Cond))
+		}
+		br := &CondBranch{
+			Directive: l.Directive,
+			Pos:       l.pos(filename),
+			Cond:      cond,
+			Presence:  And(parent, And(rest...), cond),
+			Begin:     l.Line + l.Lines,
+		}
+		br.Dead = !Satisfiable(br.Presence)
+		if br.Dead && Satisfiable(parent) {
+			c.err.AddErrMsg(br.Pos, errors.ErrMacroDeadCondition, br.Presence.String())
+		}
+		blk.Branches = append(blk.Branches, br)
+		blk.Macros = mergeMacros(blk.Macros, FormulaMacros(cond))
+	}
+
+	for _, l := range lines {
+		switch l.Directive {
+		case "if", "ifdef", "ifndef":
+			blk := &CondBlock{Pos: l.pos(filename)}
+			if n := len(stack); n > 0 {
+				br := stack[n-1].Branches
+				br[len(br)-1].Blocks = append(br[len(br)-1].Blocks, blk)
+			} else {
+				c.Blocks = append(c.Blocks, blk)
+			}
+			addBranch(blk, l, c.condition(filename, l))
+			stack = append(stack, blk)
+		case "elif", "else":
+			n := len(stack)
+			if n == 0 {
+				code := errors.ErrMacroUnexpectedElse
+				if l.Directive == "elif" {
+					code = errors.ErrMacroUnexpectedElseIf
+				}
+				c.err.AddErrMsg(l.pos(filename), code)
+				continue
+			}
+			cond := Formula(ConstFormula(true))
+			if l.Directive == "elif" {
+				cond = c.condition(filename, l)
+			}
+			blk := stack[n-1]
+			stack = stack[:n-1]
+			addBranch(blk, l, cond)
+			stack = append(stack, blk)
+		case "endif":
+			n := len(stack)
+			if n == 0 {
+				c.err.AddErrMsg(l.pos(filename), errors.ErrMacroUnexpectedEndIf)
+				continue
+			}
+			blk := stack[n-1]
+			blk.End = l.pos(filename)
+			blk.Branches[len(blk.Branches)-1].End = l.Line - 1
+			stack = stack[:n-1]
+		case "define":
+			if args := l.args(filename); len(args) > 0 && args[0].Type() == token.IDENT {
+				c.Defined[args[0].Literal()] = append(c.Defined[args[0].Literal()], args[0].Position())
+			}
+		}
+	}
+
+	for i := len(stack) - 1; i >= 0; i-- {
+		c.err.AddErrMsg(stack[i].Pos, errors.ErrMacroUnterminatedCondition, stack[i].Branches[0].Directive)
+	}
+}
+
+// 条件块所在区域的存在条件
+func (c *ConfigSpace) outer(stack []*CondBlock) Formula {
+	if n := len(stack); n > 0 {
+		b := stack[n-1].Branches
+		return b[len(b)-1].Presence
+	}
+	return ConstFormula(true)
+}
+
+// 解析指令条件
+func (c *ConfigSpace) condition(filename string, l *logicLine) Formula {
+	args := l.args(filename)
+	switch l.Directive {
+	case "ifdef", "ifndef":
+		if len(args) == 0 {
+			c.err.AddErrMsg(l.pos(filename), errors.ErrMacroExpectedTokenGotEof, token.IDENT)
+			return ConstFormula(false)
+		}
+		if args[0].Type() != token.IDENT {
+			c.err.AddErrMsg(args[0].Position(), errors.ErrMacroExpectedIdent, args[0].Literal())
+			return ConstFormula(false)
+		}
+		f := c.definedAtom(args[0])
+		if l.Directive == "ifndef" {
+			return Not(f)
+		}
+		return f
+	}
+	ctx := NewContext()
+	expr := NewParser(ctx, scanner.NewArrayScan(args)).ParseExpr()
+	c.err.Merge(ctx.Error())
+	return c.formula(expr)
+}
+
+func (c *ConfigSpace) definedAtom(tok token.Token) Formula {
+	name := tok.Literal()
+	c.Tested[name] = append(c.Tested[name], tok.Position())
+	return &AtomFormula{Text: "defined(" + name + ")", Macros: []string{name}}
+}
+
+// 表达式转换为公式
+func (c *ConfigSpace) formula(expr Expr) Formula {
+	switch v := expr.(type) {
+	case *ParenExpr:
+		return c.formula(v.X)
+	case *UnaryExpr:
+		switch v.Op.Literal() {
+		case "defined":
+			if id, ok := v.X.(*IdentLit); ok {
+				return c.definedAtom(id.Token)
+			}
+		case "!":
+			return Not(c.formula(v.X))
+		}
+	case *BinaryExpr:
+		switch v.Op.Literal() {
+		case "&&":
+			return And(c.formula(v.X), c.formula(v.Y))
+		case "||":
+			return Or(c.formula(v.X), c.formula(v.Y))
+		}
+	case *BadExpr:
+		return ConstFormula(false)
+	}
+	var macros []token.Token
+	walkIdent(expr, func(id *IdentLit) {
+		macros = append(macros, id.Token)
+	})
+	if len(macros) == 0 {
+		return ConstFormula(Eval(c.ctx, expr))
+	}
+	atom := &AtomFormula{Text: ExprString(expr)}
+	if id, ok := expr.(*IdentLit); ok {
+		atom.Text = id.Literal()
+	}
+	m := map[string]bool{}
+	for _, v := range macros {
+		c.Tested[v.Literal()] = append(c.Tested[v.Literal()], v.Position())
+		m[v.Literal()] = true
+	}
+	atom.Macros = sortedKeys(m)
+	return atom
+}
+
+func walkIdent(expr Expr, fn func(id *IdentLit)) {
+	switch v := expr.(type) {
+	case *IdentLit:
+		fn(v)
+	case *UnaryExpr:
+		walkIdent(v.X, fn)
+	case *BinaryExpr:
+		walkIdent(v.X, fn)
+		walkIdent(v.Y, fn)
+	case *CondExpr:
+		walkIdent(v.X, fn)
+		walkIdent(v.Then, fn)
+		walkIdent(v.Else, fn)
+	case *ParenExpr:
+		walkIdent(v.X, fn)
+	}
+}
+
+func mergeMacros(a, b []string) []string {
+	m := map[string]bool{}
+	for _, v := range a {
+		m[v] = true
+	}
+	for _, v := range b {
+		m[v] = true
+	}
+	return sortedKeys(m)
+}
+
+// 永远不会被编译的分支
+func (c *ConfigSpace) Dead() []*CondBranch {
+	var dead []*CondBranch
+	var walk func(blocks []*CondBlock)
+	walk = func(blocks []*CondBlock) {
+		for _, blk := range blocks {
+			for _, br := range blk.Branches {
+				if br.Dead {
+					dead = append(dead, br)
+				}
+				walk(br.Blocks)
+			}
+		}
+	}
+	walk(c.Blocks)
+	return dead
+}
+
+// 被测试但从未定义的宏
+func (c *ConfigSpace) Undefined() []string {
+	var names []string
+	for name := range c.Tested {
+		if _, ok := c.Defined[name]; ok {
+			continue
+		}
+		if c.ctx.IsDefined(name) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// 分析结果的诊断信息
+func (c *ConfigSpace) Error() errors.ErrorList {
+	err := append(errors.ErrorList{}, c.err...)
+	for _, name := range c.Undefined() {
+		err.AddErrMsg(c.Tested[name][0], errors.ErrMacroUndefinedTested, name)
+	}
+	return err
+}
@@ -0,0 +1,69 @@
+package preprocess
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfigSpace_AddSource(t *testing.T) {
+	code := `#define CONFIG_A 1
+#ifdef CONFIG_A
+int a;
+#if CONFIG_B > 1 && !defined(CONFIG_A)
+int b;
+#elif defined CONFIG_C
+int c;
+#else
+int d;
+#endif
+#elif 0
+int e;
+#endif
+#if defined(CONFIG_D) && !defined(CONFIG_D)
+int f;
+#endif
+`
+	c := NewConfigSpace(nil)
+	c.AddSource("config.c", code)
+
+	if n := len(c.Blocks); n != 2 {
+		t.Fatalf("want 2 blocks, got %d", n)
+	}
+	blk := c.Blocks[0]
+	if want := []string{"CONFIG_A"}; !reflect.DeepEqual(blk.Macros, want) {
+		t.Errorf("macros want %v got %v", want, blk.Macros)
+	}
+	inner := blk.Branches[0].Blocks[0]
+	if want := []string{"CONFIG_A", "CONFIG_B", "CONFIG_C"}; !reflect.DeepEqual(inner.Macros, want) {
+		t.Errorf("macros want %v got %v", want, inner.Macros)
+	}
+
+	presence := []string{
+		"defined(CONFIG_A) && (CONFIG_B > 1) && !defined(CONFIG_A)",
+		"defined(CONFIG_A) && !((CONFIG_B > 1) && !defined(CONFIG_A)) && defined(CONFIG_C)",
+		"defined(CONFIG_A) && !((CONFIG_B > 1) && !defined(CONFIG_A)) && !defined(CONFIG_C)",
+	}
+	for i, br := range inner.Branches {
+		if got := br.Presence.String(); got != presence[i] {
+			t.Errorf("presence %d want %s got %s", i, presence[i], got)
+		}
+		if br.Begin != 5+i*2 || br.End != 5+i*2 {
+			t.Errorf("branch %d range %d-%d", i, br.Begin, br.End)
+		}
+	}
+
+	var dead []int
+	for _, br := range c.Dead() {
+		dead = append(dead, br.Pos.Line)
+	}
+	if want := []int{4, 11, 14}; !reflect.DeepEqual(dead, want) {
+		t.Errorf("dead want %v got %v", want, dead)
+	}
+
+	if want := []string{"CONFIG_B", "CONFIG_C", "CONFIG_D"}; !reflect.DeepEqual(c.Undefined(), want) {
+		t.Errorf("undefined want %v got %v", want, c.Undefined())
+	}
+	if n := len(c.Error()); n != 6 {
+		t.Errorf("want 6 errors, got %v", c.Error())
+	}
+}
@@ -34,15 +34,27 @@ type MacroHandler struct {
 	Handler HandlerFn
 }
 
-func (m *MacroVal) decl()     {}
-func (m *MacroFunc) decl()    {}
-func (m *MacroHandler) decl() {}
+// 函数式宏处理函数，args 为调用参数，返回替换的 token
+type FuncHandlerFn func(tok token.Token, args [][]token.Token) ([]token.Token, error)
+
+// MacroFuncHandler 函数式宏 Handler
+type MacroFuncHandler struct {
+	Name    string
+	Expand  bool // 是否预先展开参数
+	Handler FuncHandlerFn
+}
+
+func (m *MacroVal) decl()         {}
+func (m *MacroFunc) decl()        {}
+func (m *MacroHandler) decl()     {}
+func (m *MacroFuncHandler) decl() {}
 
 type Token struct {
 	Pos    token.Position
 	Typ    token.Type
 	Lit    string
-	Expand token.Token // 父级展开
+	Expand token.Token    // 父级展开
+	Spell  token.Position // 拼写位置（宏定义中书写的位置）
 }
 
 func (t *Token) Position() token.Position {
@@ -156,6 +168,15 @@ func (c *Context) DefineHandler(name string, val HandlerFn) {
 	return
 }
 
+// DefineFuncHandler 定义函数式宏，expand 为真时参数在调用前展开
+func (c *Context) DefineFuncHandler(name string, expand bool, val FuncHandlerFn) {
+	c.Define(name, &MacroFuncHandler{
+		Name:    name,
+		Expand:  expand,
+		Handler: val,
+	})
+}
+
 func (c *Context) IsDefined(name string) bool {
 	_, ok := c.Val[name]
 	return ok
@@ -216,8 +237,8 @@ func (c *Context) Top() Condition {
 }
 
 // Push 压入栈
-func (c *Context) Push(cdt Condition) {
-	c.cdt.Push(cdt)
+func (c *Context) Push(cdt Condition, name string, pos token.Position) {
+	c.cdt.Push(cdt, name, pos)
 }
 
 // Pop 弹出栈
@@ -0,0 +1,58 @@
+package preprocess
+
+import (
+	"dxkite.cn/c/scanner"
+	"dxkite.cn/c/token"
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+func TestContext_DefineFuncHandler(t *testing.T) {
+	ctx := NewContext()
+	_ = ctx.DefineValStr("TYPE", "unsigned int")
+	// 参数数量
+	ctx.DefineFuncHandler("__COUNT_ARGS", false, func(tok token.Token, args [][]token.Token) ([]token.Token, error) {
+		return []token.Token{&Token{Typ: token.INT, Lit: strconv.Itoa(len(args))}}, nil
+	})
+	// 参数展开后的字符串
+	ctx.DefineFuncHandler("__STRINGIFY_TYPE", true, func(tok token.Token, args [][]token.Token) ([]token.Token, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("__STRINGIFY_TYPE need 1 argument")
+		}
+		return []token.Token{&Token{Typ: token.STRING, Lit: strconv.Quote(inlineTokenString(args[0]))}}, nil
+	})
+	ctx.DefineFuncHandler("__has_feature", false, func(tok token.Token, args [][]token.Token) ([]token.Token, error) {
+		v := "0"
+		if len(args) == 1 && args[0][0].Literal() == "c_static_assert" {
+			v = "1"
+		}
+		return []token.Token{&Token{Typ: token.INT, Lit: v}}, nil
+	})
+
+	code := `int a = __COUNT_ARGS(1, (2, 3), f(4));
+int b = __COUNT_ARGS();
+char *c = __STRINGIFY_TYPE(TYPE);
+#if __has_feature(c_static_assert)
+int d;
+#endif
+int e = __STRINGIFY_TYPE(1, 2);
+int __COUNT_ARGS;
+`
+	tks, _ := scanner.ScanToken(New(ctx, scanner.NewStringScan("handler.c", code, nil), nil))
+	want := `int a = 3;
+int b = 0;
+char *c = "unsigned int";
+
+int d;
+
+int e = ;
+int __COUNT_ARGS;
+`
+	if got := tokenString(tks); got != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, got)
+	}
+	if n := len(ctx.Error()); n != 1 || ctx.Error()[0].Pos.Line != 7 {
+		t.Errorf("unexpected error %v", ctx.Error())
+	}
+}
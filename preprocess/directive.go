@@ -0,0 +1,371 @@
+package preprocess
+
+import (
+	"dxkite.cn/c/errors"
+	"dxkite.cn/c/token"
+	"io"
+	"io/ioutil"
+	"strings"
+	"unicode/utf8"
+)
+
+// 指令树节点
+type DirectiveNode interface {
+	Beg() token.Position
+	End() token.Position
+	directiveNode()
+}
+
+type (
+	// 普通文本（连续的非指令行）
+	Text struct {
+		Text string // 原始文本
+		beg  token.Position
+		end  token.Position
+	}
+
+	// 预处理指令行
+	Directive struct {
+		Name string        // 指令名称
+		Text string        // 原始文本，包含续行符与换行符
+		Args []token.Token // 指令参数
+		beg  token.Position
+		end  token.Position
+	}
+
+	// #define
+	Define struct {
+		*Directive
+		Ident    string        // 宏名称
+		Func     bool          // 是否为函数宏
+		Params   []string      // 参数
+		Ellipsis bool          // ...
+		Body     []token.Token // 宏定义体
+	}
+
+	// #undef
+	Undef struct {
+		*Directive
+		Ident string
+	}
+
+	// #include
+	Include struct {
+		*Directive
+		Path   string // 包含的文件
+		System bool   // <file>
+	}
+
+	// #pragma
+	Pragma struct {
+		*Directive
+	}
+
+	// #line
+	Line struct {
+		*Directive
+	}
+
+	// #error
+	Error struct {
+		*Directive
+		Message string
+	}
+
+	// 条件分支 #if #ifdef #ifndef #elif #else
+	IfBranch struct {
+		*Directive
+		Body []DirectiveNode // 分支内容
+	}
+
+	// 条件编译块 #if ... #endif
+	IfGroup struct {
+		If    *IfBranch   // #if #ifdef #ifndef
+		Elif  []*IfBranch // #elif
+		Else  *IfBranch   // #else
+		Endif *Directive  // #endif，未闭合时为空
+	}
+)
+
+func (*Text) directiveNode()      {}
+func (*Directive) directiveNode() {}
+func (*IfBranch) directiveNode()  {}
+func (*IfGroup) directiveNode()   {}
+
+func (t *Text) Beg() token.Position      { return t.beg }
+func (t *Text) End() token.Position      { return t.end }
+func (d *Directive) Beg() token.Position { return d.beg }
+func (d *Directive) End() token.Position { return d.end }
+
+func (b *IfBranch) End() token.Position {
+	if n := len(b.Body); n > 0 {
+		return b.Body[n-1].End()
+	}
+	return b.Directive.End()
+}
+
+func (g *IfGroup) Beg() token.Position { return g.If.Beg() }
+func (g *IfGroup) End() token.Position {
+	if g.Endif != nil {
+		return g.Endif.End()
+	}
+	branches := g.Branches()
+	return branches[len(branches)-1].End()
+}
+
+// 按顺序返回所有分支
+func (g *IfGroup) Branches() []*IfBranch {
+	branches := append([]*IfBranch{g.If}, g.Elif...)
+	if g.Else != nil {
+		branches = append(branches, g.Else)
+	}
+	return branches
+}
+
+// 指令树
+type DirectiveFile struct {
+	Filename string
+	Nodes    []DirectiveNode
+}
+
+// 解析文件的指令结构
+func ParseDirectiveFile(filename string) (*DirectiveFile, errors.ErrorList) {
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, errors.ErrorList{errors.NewStd(token.Position{Filename: filename}, err)}
+	}
+	return ParseDirective(filename, string(b))
+}
+
+// 解析代码的指令结构，不展开宏
+func ParseDirective(filename, src string) (*DirectiveFile, errors.ErrorList) {
+	p := &directiveParser{filename: filename, err: errors.ErrorList{}}
+	f := &DirectiveFile{Filename: filename}
+	pos := token.Position{Filename: filename, Line: 1, Column: 1}
+	for _, l := range readLogicLines(src) {
+		end := advancePos(pos, l.Text)
+		if l.Hash {
+			p.directive(f, l, pos, end)
+		} else {
+			p.text(f, l.Text, pos, end)
+		}
+		pos = end
+	}
+	for i := len(p.stack) - 1; i >= 0; i-- {
+		p.err.AddErrMsg(p.stack[i].Beg(), errors.ErrMacroUnterminatedCondition, p.stack[i].If.Name)
+	}
+	return f, p.err
+}
+
+type directiveParser struct {
+	filename string
+	stack    []*IfGroup
+	err      errors.ErrorList
+}
+
+// 当前节点列表
+func (p *directiveParser) body(f *DirectiveFile) *[]DirectiveNode {
+	if n := len(p.stack); n > 0 {
+		branches := p.stack[n-1].Branches()
+		return &branches[len(branches)-1].Body
+	}
+	return &f.Nodes
+}
+
+func (p *directiveParser) add(f *DirectiveFile, node DirectiveNode) {
+	body := p.body(f)
+	*body = append(*body, node)
+}
+
+func (p *directiveParser) text(f *DirectiveFile, text string, beg, end token.Position) {
+	body := p.body(f)
+	if n := len(*body); n > 0 {
+		if t, ok := (*body)[n-1].(*Text); ok {
+			t.Text += text
+			t.end = end
+			return
+		}
+	}
+	*body = append(*body, &Text{Text: text, beg: beg, end: end})
+}
+
+func (p *directiveParser) directive(f *DirectiveFile, l *logicLine, beg, end token.Position) {
+	d := &Directive{
+		Name: l.Directive,
+		Text: l.Text,
+		Args: l.args(p.filename),
+		beg:  beg,
+		end:  end,
+	}
+	switch d.Name {
+	case "if", "ifdef", "ifndef":
+		g := &IfGroup{If: &IfBranch{Directive: d}}
+		p.add(f, g)
+		p.stack = append(p.stack, g)
+	case "elif", "else":
+		n := len(p.stack)
+		if n == 0 || p.stack[n-1].Else != nil {
+			code := errors.ErrMacroUnexpectedElse
+			if d.Name == "elif" {
+				code = errors.ErrMacroUnexpectedElseIf
+			}
+			p.err.AddErrMsg(beg, code)
+			p.add(f, d)
+			return
+		}
+		g := p.stack[n-1]
+		if d.Name == "elif" {
+			g.Elif = append(g.Elif, &IfBranch{Directive: d})
+		} else {
+			g.Else = &IfBranch{Directive: d}
+		}
+	case "endif":
+		n := len(p.stack)
+		if n == 0 {
+			p.err.AddErrMsg(beg, errors.ErrMacroUnexpectedEndIf)
+			p.add(f, d)
+			return
+		}
+		p.stack[n-1].Endif = d
+		p.stack = p.stack[:n-1]
+	case "define":
+		p.add(f, p.define(d))
+	case "undef":
+		u := &Undef{Directive: d}
+		if len(d.Args) > 0 && d.Args[0].Type() == token.IDENT {
+			u.Ident = d.Args[0].Literal()
+		} else {
+			p.err.AddErrMsg(beg, errors.ErrMacroExpectedTokenGotEof, token.IDENT)
+		}
+		p.add(f, u)
+	case "include":
+		inc := &Include{Directive: d}
+		rest := l.rest()
+		if strings.HasPrefix(rest, "<") {
+			if i := strings.IndexByte(rest, '>'); i > 0 {
+				inc.Path = rest[1:i]
+				inc.System = true
+			}
+		} else if len(d.Args) > 0 && d.Args[0].Type() == token.STRING {
+			inc.Path = strings.Trim(d.Args[0].Literal(), `"`)
+		}
+		p.add(f, inc)
+	case "pragma":
+		p.add(f, &Pragma{Directive: d})
+	case "line":
+		p.add(f, &Line{Directive: d})
+	case "error":
+		p.add(f, &Error{Directive: d, Message: l.rest()})
+	default:
+		p.add(f, d)
+	}
+}
+
+func (p *directiveParser) define(d *Directive) *Define {
+	def := &Define{Directive: d}
+	args := d.Args
+	if len(args) == 0 || args[0].Type() != token.IDENT {
+		p.err.AddErrMsg(d.beg, errors.ErrMacroExpectedTokenGotEof, token.IDENT)
+		return def
+	}
+	name := args[0]
+	def.Ident = name.Literal()
+	args = args[1:]
+	// 宏名称后紧跟 ( 为函数宏
+	if len(args) > 0 && args[0].Literal() == "(" &&
+		args[0].Position().Line == name.Position().Line &&
+		args[0].Position().Column == name.Position().Column+utf8.RuneCountInString(name.Literal()) {
+		def.Func = true
+		i := 1
+		for ; i < len(args) && args[i].Literal() != ")"; i++ {
+			switch {
+			case args[i].Literal() == "...":
+				def.Ellipsis = true
+			case args[i].Type() == token.IDENT:
+				def.Params = append(def.Params, args[i].Literal())
+			case args[i].Literal() != ",":
+				p.err.AddErrMsg(args[i].Position(), errors.ErrMacroExpectedGot, token.IDENT, args[i].Literal())
+			}
+		}
+		if i == len(args) {
+			p.err.AddErrMsg(d.beg, errors.ErrMacroExpectedTokenGotEof, ")")
+			return def
+		}
+		args = args[i+1:]
+	}
+	def.Body = args
+	return def
+}
+
+// 计算文本之后的位置
+func advancePos(pos token.Position, text string) token.Position {
+	for i := 0; i < len(text); {
+		r, w := utf8.DecodeRuneInString(text[i:])
+		switch {
+		case r == '\r' && i+1 < len(text) && text[i+1] == '\n':
+		case r == '\r' || r == '\n':
+			pos.Line++
+			pos.Column = 1
+		default:
+			pos.Column++
+		}
+		i += w
+	}
+	pos.Offset += len(text)
+	return pos
+}
+
+// 输出指令树，得到原始文本
+func PrintDirective(w io.Writer, nodes []DirectiveNode) error {
+	for _, node := range nodes {
+		if err := printDirectiveNode(w, node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printDirectiveNode(w io.Writer, node DirectiveNode) error {
+	switch v := node.(type) {
+	case *Text:
+		_, err := io.WriteString(w, v.Text)
+		return err
+	case *IfGroup:
+		for _, br := range v.Branches() {
+			if err := printDirectiveNode(w, br); err != nil {
+				return err
+			}
+		}
+		if v.Endif != nil {
+			return printDirectiveNode(w, v.Endif)
+		}
+		return nil
+	case *IfBranch:
+		if _, err := io.WriteString(w, v.Text); err != nil {
+			return err
+		}
+		return PrintDirective(w, v.Body)
+	case *Directive:
+		_, err := io.WriteString(w, v.Text)
+		return err
+	case *Define:
+		return printDirectiveNode(w, v.Directive)
+	case *Undef:
+		return printDirectiveNode(w, v.Directive)
+	case *Include:
+		return printDirectiveNode(w, v.Directive)
+	case *Pragma:
+		return printDirectiveNode(w, v.Directive)
+	case *Line:
+		return printDirectiveNode(w, v.Directive)
+	case *Error:
+		return printDirectiveNode(w, v.Directive)
+	}
+	return nil
+}
+
+func (f *DirectiveFile) String() string {
+	var b strings.Builder
+	_ = PrintDirective(&b, f.Nodes)
+	return b.String()
+}
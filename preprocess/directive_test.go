@@ -0,0 +1,92 @@
+package preprocess
+
+import (
+	"dxkite.cn/c/errors"
+	"dxkite.cn/c/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseDirective(t *testing.T) {
+	code := "// header\r\n#include <stdio.h>\n#include \"a.h\"\n#define MAX(a, b) \\\n  ((a) > (b) ? (a) : (b))\n" +
+		"#define EMPTY\n#ifdef A\nint a;\n#elif B\n#  pragma once\n#else\n#error not /* ok */\n#endif\n" +
+		"int main() {}\n#if X\n#line 10 \"x.c\"\n"
+	f, err := ParseDirective("dir.c", code)
+	if got := f.String(); got != code {
+		t.Errorf("print want %q got %q", code, got)
+	}
+	if len(err) != 1 || err[0].Code != errors.ErrMacroUnterminatedCondition || err[0].Pos.Line != 15 {
+		t.Errorf("unexpected error %v", err)
+	}
+	if n := len(f.Nodes); n != 8 {
+		t.Fatalf("want 8 nodes, got %d", n)
+	}
+
+	inc := f.Nodes[1].(*Include)
+	if inc.Path != "stdio.h" || !inc.System {
+		t.Errorf("include %s %v", inc.Path, inc.System)
+	}
+	if inc := f.Nodes[2].(*Include); inc.Path != "a.h" || inc.System {
+		t.Errorf("include %s %v", inc.Path, inc.System)
+	}
+
+	def := f.Nodes[3].(*Define)
+	if def.Ident != "MAX" || !def.Func || !reflect.DeepEqual(def.Params, []string{"a", "b"}) || len(def.Body) != 17 {
+		t.Errorf("define %s %v %v %d", def.Ident, def.Func, def.Params, len(def.Body))
+	}
+	if beg, end := def.Beg(), def.End(); beg.Line != 4 || beg.Column != 1 || end.Line != 6 || end.Column != 1 {
+		t.Errorf("define range %v %v", beg, end)
+	}
+	if def := f.Nodes[4].(*Define); def.Ident != "EMPTY" || def.Func || len(def.Body) != 0 {
+		t.Errorf("define %s %v %d", def.Ident, def.Func, len(def.Body))
+	}
+
+	g := f.Nodes[5].(*IfGroup)
+	if g.If.Name != "ifdef" || len(g.Elif) != 1 || g.Else == nil || g.Endif == nil {
+		t.Fatalf("if group %v", g)
+	}
+	if _, ok := g.Elif[0].Body[0].(*Pragma); !ok {
+		t.Errorf("want pragma got %T", g.Elif[0].Body[0])
+	}
+	if e, ok := g.Else.Body[0].(*Error); !ok || e.Message != "not /* ok */" {
+		t.Errorf("want error got %v", g.Else.Body[0])
+	}
+	if beg, end := g.Beg(), g.End(); beg.Line != 7 || end.Line != 14 || end.Column != 1 {
+		t.Errorf("if group range %v %v", beg, end)
+	}
+
+	open := f.Nodes[7].(*IfGroup)
+	if open.Endif != nil {
+		t.Errorf("want unterminated group")
+	}
+	if _, ok := open.If.Body[0].(*Line); !ok {
+		t.Errorf("want line got %T", open.If.Body[0])
+	}
+	if end := open.End(); end != (token.Position{Filename: "dir.c", Line: 17, Column: 1, Offset: len(code)}) {
+		t.Errorf("unterminated group end %v", end)
+	}
+}
+
+func TestParseDirective_RoundTrip(t *testing.T) {
+	if err := filepath.Walk(source+"/macro", func(p string, info os.FileInfo, err error) error {
+		if filepath.Ext(p) != ".c" {
+			return nil
+		}
+		t.Run(filepath.Base(p), func(t *testing.T) {
+			data, err := ioutil.ReadFile(p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			f, _ := ParseDirectiveFile(p)
+			if got := f.String(); got != string(data) {
+				t.Errorf("round trip want %q got %q", string(data), got)
+			}
+		})
+		return nil
+	}); err != nil {
+		t.Error(err)
+	}
+}
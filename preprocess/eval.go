@@ -2,177 +2,257 @@ package preprocess
 
 import (
 	"dxkite.cn/c/errors"
+	"dxkite.cn/c/literal"
+	"dxkite.cn/c/scanner"
+	"dxkite.cn/c/target"
 	"dxkite.cn/c/token"
-	"strconv"
 )
 
 func Eval(ctx *Context, expr Expr) bool {
-	e := &Evaluator{ctx: ctx, err: errors.ErrorList{}}
-	return e.eval(expr) > 0
+	e := NewEvaluator(ctx)
+	v := e.eval(expr)
+	ctx.err.Merge(e.Error())
+	return v > 0
 }
 
+// 三值逻辑
+type Tristate int
+
+const (
+	False   Tristate = iota // 不成立/未定义
+	True                    // 成立/已定义
+	Unknown                 // 未知
+)
+
+func (t Tristate) String() string {
+	switch t {
+	case False:
+		return "false"
+	case True:
+		return "true"
+	}
+	return "unknown"
+}
+
+// 宏查询，返回宏的值以及是否定义
+type LookupFn func(name string) ([]token.Token, Tristate)
+
 type Evaluator struct {
 	ctx *Context
 	err errors.ErrorList
+	// 三值求值使用的宏查询
+	lookup LookupFn
+	// 正在展开的宏，防止递归
+	visit map[string]bool
+}
+
+func NewEvaluator(ctx *Context) *Evaluator {
+	return &Evaluator{ctx: ctx, err: errors.ErrorList{}}
+}
+
+// 创建三值求值器，未知的宏使表达式结果未知
+func NewTristateEvaluator(ctx *Context, lookup LookupFn) *Evaluator {
+	e := NewEvaluator(ctx)
+	e.lookup = lookup
+	e.visit = map[string]bool{}
+	return e
+}
+
+// 三值求值
+func (e *Evaluator) EvalTristate(expr Expr) Tristate {
+	v, ok := e.value(expr)
+	if !ok {
+		return Unknown
+	}
+	if v != 0 {
+		return True
+	}
+	return False
+}
+
+func (e *Evaluator) Error() errors.ErrorList {
+	return e.err
 }
 
 // 获取数据类型
-func (e *Evaluator) valueOf(tok token.Token) int64 {
+func (e *Evaluator) valueOf(tok token.Token) (int64, bool) {
 	switch tok.Type() {
 	case token.INT:
-		return e.evalInt(tok)
+		return e.evalInt(tok), true
 	case token.CHAR:
-		return e.evalChar(tok)
+		return e.evalChar(tok), true
 	case token.IDENT:
+		if e.lookup != nil {
+			return e.lookupValue(tok.Literal())
+		}
 		if e.ctx.IsDefined(tok.Literal()) {
-			return 1
+			return 1, true
+		}
+	}
+	return 0, true
+}
+
+// 查询宏的值
+func (e *Evaluator) lookupValue(name string) (int64, bool) {
+	body, state := e.lookup(name)
+	switch state {
+	case False:
+		return 0, true
+	case Unknown:
+		return 0, false
+	}
+	if len(body) == 0 || e.visit[name] {
+		return 0, false
+	}
+	e.visit[name] = true
+	defer delete(e.visit, name)
+	ctx := NewContext()
+	expr := NewParser(ctx, scanner.NewArrayScan(body)).ParseExpr()
+	if len(ctx.Error()) > 0 {
+		return 0, false
+	}
+	return e.value(expr)
+}
+
+// 查询宏是否定义
+func (e *Evaluator) defined(x Expr) (int64, bool) {
+	id, ok := x.(*IdentLit)
+	if !ok {
+		return 0, true
+	}
+	if e.lookup != nil {
+		switch _, state := e.lookup(id.Literal()); state {
+		case True:
+			return 1, true
+		case Unknown:
+			return 0, false
 		}
+		return 0, true
+	}
+	if e.ctx.IsDefined(id.Literal()) {
+		return 1, true
+	}
+	return 0, true
+}
+
+func boolVal(b bool) int64 {
+	if b {
+		return 1
 	}
 	return 0
 }
 
 // 一元运算
-func (e *Evaluator) evalUnaryExpr(expr *UnaryExpr) int64 {
-	v := e.eval(expr.X)
+func (e *Evaluator) evalUnaryExpr(expr *UnaryExpr) (int64, bool) {
+	if expr.Op.Literal() == "defined" {
+		return e.defined(expr.X)
+	}
+	v, ok := e.value(expr.X)
+	if !ok {
+		return 0, false
+	}
 	switch expr.Op.Literal() {
 	case "~":
-		return ^v
+		return ^v, true
 	case "!":
-		if !(v > 0) {
-			return 1
-		}
+		return boolVal(!(v > 0)), true
 	case "-":
-		return -v
+		return -v, true
 	case "+":
-		return +v
-	case "defined":
-		return v
+		return +v, true
 	}
-	return 0
+	return 0, true
 }
 
-// 一元运算
-func (e *Evaluator) evalBinaryExpr(expr *BinaryExpr) int64 {
-	x, y := e.eval(expr.X), e.eval(expr.Y)
+// 二元运算
+func (e *Evaluator) evalBinaryExpr(expr *BinaryExpr) (int64, bool) {
+	x, xk := e.value(expr.X)
 	switch expr.Op.Literal() {
 	case "||":
-		if (x > 0) || (y > 0) {
-			return 1
+		// 左侧确定成立时不再计算右侧
+		if xk && x > 0 {
+			return 1, true
 		}
+		// 任意一侧确定成立即成立
+		y, yk := e.value(expr.Y)
+		if yk && y > 0 {
+			return 1, true
+		}
+		return 0, xk && yk
 	case "&&":
-		if (x > 0) && (y > 0) {
-			return 1
+		// 左侧确定不成立时不再计算右侧
+		if xk && !(x > 0) {
+			return 0, true
+		}
+		// 任意一侧确定不成立即不成立
+		y, yk := e.value(expr.Y)
+		if yk && !(y > 0) {
+			return 0, true
 		}
+		return boolVal(x > 0 && y > 0), xk && yk
+	}
+	y, yk := e.value(expr.Y)
+	if !xk || !yk {
+		return 0, false
+	}
+	switch expr.Op.Literal() {
 	case "|":
-		return x | y
+		return x | y, true
 	case "^":
-		return x ^ y
+		return x ^ y, true
 	case "&":
-		return x & y
+		return x & y, true
 	case "!=":
-		if x != y {
-			return 1
-		}
+		return boolVal(x != y), true
 	case "==":
-		if x == y {
-			return 1
-		}
+		return boolVal(x == y), true
 	case ">":
-		if x > y {
-			return 1
-		}
+		return boolVal(x > y), true
 	case "<":
-		if x < y {
-			return 1
-		}
+		return boolVal(x < y), true
 	case ">=":
-		if x >= y {
-			return 1
-		}
+		return boolVal(x >= y), true
 	case "<=":
-		if x <= y {
-			return 1
-		}
+		return boolVal(x <= y), true
 	case ">>":
-		return x >> y
+		return x >> y, true
 	case "<<":
-		return x << y
+		return x << y, true
 	case "+":
-		return x + y
+		return x + y, true
 	case "-":
-		return x - y
+		return x - y, true
 	case "*":
-		return x * y
-	case "/":
-		return x / y
-	case "%":
-		return x % y
+		return x * y, true
+	case "/", "%":
+		if y == 0 {
+			e.addErr(expr.Op.Position(), "division by zero")
+			return 0, true
+		}
+		if expr.Op.Literal() == "/" {
+			return x / y, true
+		}
+		return x % y, true
 	}
-	return 0
+	return 0, true
 }
 
 // 解析数字
 func (e *Evaluator) evalInt(tok token.Token) int64 {
-	v, err := strconv.ParseInt(tok.Literal(), 0, 64)
+	n, err := literal.ParseNumber(tok, target.Default)
 	if err != nil {
-		e.addErr(tok.Position(), "error parse int %s", err.Error())
+		e.err.AddErr(err)
 	}
-	return v
+	return int64(n.Int)
 }
 
-// 解析数字（浮点数）
+// 解析字符常量
 func (e *Evaluator) evalChar(tok token.Token) int64 {
-	if c, ok := parseChar(tok.Literal()); !ok {
-		e.addErr(tok.Position(), "error parse char %s", tok.Literal())
-	} else {
-		return int64(c)
-	}
-	return 0
-}
-
-func parseChar(ch string) (uint8, bool) {
-	l := len(ch)
-	if l <= 2 {
-		return 0, false
-	}
-	if ch[0] != '\'' && ch[l-1] != '\'' {
-		return 0, false
-	}
-	ch = ch[1 : l-1] // ''
-	// x
-	if len(ch) == 1 {
-		return ch[0], true
-	} else if ch[0] == '\\' {
-		// \000
-		// \xff
-		i := 1
-		base := 8
-		switch ch[1] {
-		case 'x':
-			i = 2
-			base = 16
-		case 'a', 'b', 'f', 'n', 'r', 't', 'v', '\\', '"', '\'':
-			return ch[1], true
-		}
-		x := 0
-		for i < len(ch) {
-			x = x*base + digitVal(rune(ch[i]))
-			i++
-		}
-		return uint8(x), true
-	}
-	return 0, false
-}
-
-func lower(ch rune) rune { return ('a' - 'A') | ch }
-func digitVal(ch rune) int {
-	switch {
-	case '0' <= ch && ch <= '9':
-		return int(ch - '0')
-	case 'a' <= lower(ch) && lower(ch) <= 'f':
-		return int(lower(ch) - 'a' + 10)
+	c, err := literal.ParseChar(tok, target.Default)
+	if err != nil {
+		e.err.AddErr(err)
 	}
-	return 16
+	return c.Value
 }
 
 func (e *Evaluator) addErr(pos token.Position, msg string, args ...interface{}) {
@@ -180,6 +260,12 @@ func (e *Evaluator) addErr(pos token.Position, msg string, args ...interface{})
 }
 
 func (e *Evaluator) eval(expr Expr) int64 {
+	v, _ := e.value(expr)
+	return v
+}
+
+// 求值，返回值以及结果是否确定
+func (e *Evaluator) value(expr Expr) (int64, bool) {
 	switch v := expr.(type) {
 	case *IdentLit:
 		return e.valueOf(v.Token)
@@ -190,13 +276,19 @@ func (e *Evaluator) eval(expr Expr) int64 {
 	case *BinaryExpr:
 		return e.evalBinaryExpr(v)
 	case *CondExpr:
-		x := e.eval(v.X)
-		if x > 0 {
-			return e.eval(v.Then)
+		x, ok := e.value(v.X)
+		if ok {
+			if x > 0 {
+				return e.value(v.Then)
+			}
+			return e.value(v.Else)
 		}
-		return e.eval(v.Else)
+		// 条件未知时两个分支相同也可以确定
+		t, tk := e.value(v.Then)
+		f, fk := e.value(v.Else)
+		return t, tk && fk && t == f
 	case *ParenExpr:
-		return e.eval(v.X)
+		return e.value(v.X)
 	}
-	return 0
+	return 0, true
 }
@@ -192,12 +192,12 @@ func (p *Parser) parseBinaryExpr(prec int) Expr {
 	return x
 }
 
-// 	( ("-" / "+" / "~" / "defined" ) parseTermExpr )
+// ( ("-" / "+" / "~" / "!" ) parseUnaryExpr ) | defined | parseTermExpr
 func (p *Parser) parseUnaryExpr() Expr {
 	if p.cur.Type() == token.PUNCTUATOR && litIn(p.cur.Literal(), []string{"+", "-", "~", "!"}) {
 		op := p.cur
 		p.next()
-		x := p.parseTermExpr()
+		x := p.parseUnaryExpr()
 		return &UnaryExpr{
 			Op: op,
 			X:  x,
@@ -118,3 +118,28 @@ func TestParser_ParseExpr(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestParser_ParseNestedUnaryExpr(t *testing.T) {
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{"- - 1", true},
+		{"!!1", true},
+		{"!!0", false},
+		{"~ - 1", false},
+		{"!defined(X)", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			ctx := NewContext()
+			expr := NewParser(ctx, scanner.NewStringScan("unary.c", tt.code, nil)).ParseExpr()
+			if ctx.Error().Len() > 0 {
+				t.Fatalf("parse %q error = %v", tt.code, ctx.Error())
+			}
+			if got := Eval(ctx, expr); got != tt.want {
+				t.Errorf("eval %q = %v, want %v", tt.code, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package preprocess
+
+import (
+	"sort"
+	"strings"
+)
+
+// 布尔公式，用于描述代码区域的存在条件
+type Formula interface {
+	String() string
+	formula()
+}
+
+type (
+	// 常量条件
+	ConstFormula bool
+
+	// 原子条件 defined(X)、X 或者无法进一步拆分的表达式
+	AtomFormula struct {
+		Text   string   // 条件文本
+		Macros []string // 依赖的宏
+	}
+
+	// 取反
+	NotFormula struct {
+		X Formula
+	}
+
+	// 合取
+	AndFormula []Formula
+
+	// 析取
+	OrFormula []Formula
+)
+
+func (ConstFormula) formula() {}
+func (*AtomFormula) formula() {}
+func (*NotFormula) formula()  {}
+func (AndFormula) formula()   {}
+func (OrFormula) formula()    {}
+
+func (f ConstFormula) String() string {
+	if f {
+		return "true"
+	}
+	return "false"
+}
+
+func (f *AtomFormula) String() string { return f.Text }
+
+func (f *NotFormula) String() string {
+	switch f.X.(type) {
+	case AndFormula, OrFormula:
+		return "!(" + f.X.String() + ")"
+	}
+	return "!" + f.X.String()
+}
+
+func (f AndFormula) String() string { return joinFormula(f, " && ") }
+func (f OrFormula) String() string  { return joinFormula(f, " || ") }
+
+func joinFormula(list []Formula, sep string) string {
+	var s []string
+	for _, v := range list {
+		switch v.(type) {
+		case AndFormula, OrFormula:
+			s = append(s, "("+v.String()+")")
+		default:
+			s = append(s, v.String())
+		}
+	}
+	return strings.Join(s, sep)
+}
+
+// 取反
+func Not(x Formula) Formula {
+	switch v := x.(type) {
+	case ConstFormula:
+		return !v
+	case *NotFormula:
+		return v.X
+	}
+	return &NotFormula{X: x}
+}
+
+// 合取
+func And(list ...Formula) Formula {
+	var and AndFormula
+	for _, v := range list {
+		switch f := v.(type) {
+		case ConstFormula:
+			if !f {
+				return f
+			}
+		case AndFormula:
+			and = append(and, f...)
+		default:
+			and = append(and, f)
+		}
+	}
+	switch len(and) {
+	case 0:
+		return ConstFormula(true)
+	case 1:
+		return and[0]
+	}
+	return and
+}
+
+// 析取
+func Or(list ...Formula) Formula {
+	var or OrFormula
+	for _, v := range list {
+		switch f := v.(type) {
+		case ConstFormula:
+			if f {
+				return f
+			}
+		case OrFormula:
+			or = append(or, f...)
+		default:
+			or = append(or, f)
+		}
+	}
+	switch len(or) {
+	case 0:
+		return ConstFormula(false)
+	case 1:
+		return or[0]
+	}
+	return or
+}
+
+// 公式依赖的宏
+func FormulaMacros(f Formula) []string {
+	m := map[string]bool{}
+	walkAtom(f, func(atom *AtomFormula) {
+		for _, v := range atom.Macros {
+			m[v] = true
+		}
+	})
+	return sortedKeys(m)
+}
+
+func walkAtom(f Formula, fn func(atom *AtomFormula)) {
+	switch v := f.(type) {
+	case *AtomFormula:
+		fn(v)
+	case *NotFormula:
+		walkAtom(v.X, fn)
+	case AndFormula:
+		for _, x := range v {
+			walkAtom(x, fn)
+		}
+	case OrFormula:
+		for _, x := range v {
+			walkAtom(x, fn)
+		}
+	}
+}
+
+// 参与穷举的最大原子条件数量
+const maxSatisfiableAtom = 20
+
+// 判断公式是否可满足，原子条件过多时视为可满足
+func Satisfiable(f Formula) bool {
+	var atoms []string
+	index := map[string]int{}
+	walkAtom(f, func(atom *AtomFormula) {
+		if _, ok := index[atom.Text]; !ok {
+			index[atom.Text] = len(atoms)
+			atoms = append(atoms, atom.Text)
+		}
+	})
+	if len(atoms) > maxSatisfiableAtom {
+		return true
+	}
+	// X 非零时 defined(X) 必定成立
+	var implies [][2]int
+	for name, i := range index {
+		if j, ok := index["defined("+name+")"]; ok {
+			implies = append(implies, [2]int{i, j})
+		}
+	}
+	val := make([]bool, len(atoms))
+	for mask := 0; mask < 1<<len(atoms); mask++ {
+		for i := range val {
+			val[i] = mask&(1<<i) != 0
+		}
+		consistent := true
+		for _, v := range implies {
+			if val[v[0]] && !val[v[1]] {
+				consistent = false
+				break
+			}
+		}
+		if consistent && evalFormula(f, index, val) {
+			return true
+		}
+	}
+	return false
+}
+
+func evalFormula(f Formula, index map[string]int, val []bool) bool {
+	switch v := f.(type) {
+	case ConstFormula:
+		return bool(v)
+	case *AtomFormula:
+		return val[index[v.Text]]
+	case *NotFormula:
+		return !evalFormula(v.X, index, val)
+	case AndFormula:
+		for _, x := range v {
+			if !evalFormula(x, index, val) {
+				return false
+			}
+		}
+		return true
+	case OrFormula:
+		for _, x := range v {
+			if evalFormula(x, index, val) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]bool) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,233 @@
+package preprocess
+
+import (
+	"dxkite.cn/c/scanner"
+	"dxkite.cn/c/token"
+	"strings"
+)
+
+// 逻辑行（合并续行后的一行代码）
+type logicLine struct {
+	Text      string // 原始文本，包含续行符与换行符
+	Line      int    // 起始行号
+	Offset    int    // 起始偏移
+	Lines     int    // 占用的物理行数
+	Directive string // 指令名称，非指令行为空
+	Hash      bool   // 是否为预处理指令行（可能为空指令 #）
+	name      int    // 指令名称在合并续行后文本中的偏移
+}
+
+// 读取逻辑行
+func readLogicLines(src string) []*logicLine {
+	var lines []*logicLine
+	line := 1
+	offset := 0
+	comment := false
+	for len(src) > 0 {
+		l := &logicLine{Line: line, Offset: offset}
+		n := 0
+		for n < len(src) {
+			i := strings.IndexAny(src[n:], "\r\n")
+			if i < 0 {
+				n = len(src)
+				l.Lines++
+				break
+			}
+			end := n + i
+			w := 1
+			if src[end] == '\r' && end+1 < len(src) && src[end+1] == '\n' {
+				w = 2
+			}
+			l.Lines++
+			n = end + w
+			// 续行
+			if !(end > 0 && src[end-1] == '\\') {
+				break
+			}
+		}
+		l.Text = src[:n]
+		src = src[n:]
+		line += l.Lines
+		offset += n
+		comment = l.parseDirective(comment)
+		lines = append(lines, l)
+	}
+	return lines
+}
+
+// 去掉续行符
+func spliceLine(text string) string {
+	if !strings.Contains(text, "\\") {
+		return text
+	}
+	text = strings.ReplaceAll(text, "\\\r\n", "")
+	text = strings.ReplaceAll(text, "\\\n", "")
+	return strings.ReplaceAll(text, "\\\r", "")
+}
+
+// 解析指令名称，返回行尾是否处于多行注释中
+func (l *logicLine) parseDirective(comment bool) bool {
+	s := spliceLine(l.Text)
+	i := 0
+	first := true
+	for i < len(s) {
+		if comment {
+			e := strings.Index(s[i:], "*/")
+			if e < 0 {
+				return true
+			}
+			i += e + 2
+			comment = false
+			continue
+		}
+		switch ch := s[i]; {
+		case ch == ' ' || ch == '\t' || ch == '\f' || ch == '\v':
+			i++
+		case strings.HasPrefix(s[i:], "/*"):
+			comment = true
+			i += 2
+		case strings.HasPrefix(s[i:], "//"):
+			return false
+		case first && (ch == '#' || strings.HasPrefix(s[i:], "%:")):
+			l.Hash = true
+			if ch == '#' {
+				i++
+			} else {
+				i += 2
+			}
+			first = false
+			for i < len(s) && (s[i] == ' ' || s[i] == '\t') {
+				i++
+			}
+			l.name = i
+			for i < len(s) && isIdentChar(s[i]) {
+				i++
+			}
+			l.Directive = s[l.name:i]
+		default:
+			first = false
+			return l.skipText(s[i:])
+		}
+	}
+	return comment
+}
+
+// 跳过普通文本，返回行尾是否处于多行注释中
+func (l *logicLine) skipText(s string) bool {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if quote != 0 {
+			if ch == '\\' {
+				i++
+			} else if ch == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch {
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case strings.HasPrefix(s[i:], "//"):
+			return false
+		case strings.HasPrefix(s[i:], "/*"):
+			e := strings.Index(s[i+2:], "*/")
+			if e < 0 {
+				return true
+			}
+			i += e + 3
+		}
+	}
+	return false
+}
+
+func isIdentChar(ch byte) bool {
+	return ch == '_' || 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || '0' <= ch && ch <= '9'
+}
+
+// 扫描指令参数（不包含 # 与指令名称）
+func (l *logicLine) args(filename string) []token.Token {
+	tks, _ := scanner.ScanString(filename, l.Text, nil)
+	var args []token.Token
+	skip := 2
+	for _, t := range tks {
+		if t.Type() == token.WHITESPACE || t.Type() == token.NEWLINE {
+			continue
+		}
+		if skip > 0 && l.Directive != "" {
+			skip--
+			continue
+		}
+		pos := t.Position()
+		pos.Line += l.Line - 1
+		pos.Offset += l.Offset
+		args = append(args, newTokenPos(t, pos))
+	}
+	return args
+}
+
+// 续行符的长度，不是续行符时返回 0
+func spliceLen(s string) int {
+	switch {
+	case strings.HasPrefix(s, "\\\r\n"):
+		return 3
+	case strings.HasPrefix(s, "\\\n"), strings.HasPrefix(s, "\\\r"):
+		return 2
+	}
+	return 0
+}
+
+// 合并续行后文本中的偏移对应的原始文本偏移
+func rawOffset(text string, n int) int {
+	i := 0
+	for i < len(text) {
+		if k := spliceLen(text[i:]); k > 0 {
+			i += k
+			continue
+		}
+		if n == 0 {
+			break
+		}
+		i++
+		n--
+	}
+	return i
+}
+
+// 替换指令名称，其余部分原样保留
+func (l *logicLine) rename(name string) string {
+	beg := rawOffset(l.Text, l.name)
+	end := rawOffset(l.Text, l.name+len(l.Directive))
+	return l.Text[:beg] + name + l.Text[end:]
+}
+
+// 替换整个指令，保留指令名称前的内容与换行符，删除部分中的续行符保留以保持后续行号不变
+func (l *logicLine) replace(name string) string {
+	s := l.Text
+	beg := rawOffset(s, l.name)
+	var splices strings.Builder
+	for i := rawOffset(s, l.name+len(l.Directive)); i < len(s); i++ {
+		if k := spliceLen(s[i:]); k > 0 {
+			splices.WriteString(s[i : i+k])
+			i += k - 1
+		}
+	}
+	end := ""
+	if strings.HasSuffix(s, "\r\n") {
+		end = "\r\n"
+	} else if strings.HasSuffix(s, "\n") {
+		end = "\n"
+	}
+	return s[:beg] + name + splices.String() + end
+}
+
+// 指令名称之后的文本，去掉首尾空白
+func (l *logicLine) rest() string {
+	s := spliceLine(l.Text)
+	return strings.TrimSpace(s[l.name+len(l.Directive):])
+}
+
+// 指令起始位置
+func (l *logicLine) pos(filename string) token.Position {
+	return token.Position{Filename: filename, Line: l.Line, Column: 1, Offset: l.Offset}
+}
@@ -0,0 +1,205 @@
+package preprocess
+
+import (
+	"dxkite.cn/c/errors"
+	"dxkite.cn/c/scanner"
+	"dxkite.cn/c/token"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+// 部分预处理（unifdef）
+// 只处理能够根据已知宏确定的条件编译，其余内容原样输出
+type Partial struct {
+	val map[string][]token.Token // 已定义的宏
+	und map[string]struct{}      // 未定义的宏
+	err errors.ErrorList
+}
+
+func NewPartial() *Partial {
+	return &Partial{
+		val: map[string][]token.Token{},
+		und: map[string]struct{}{},
+		err: errors.ErrorList{},
+	}
+}
+
+// 声明已定义的宏
+func (p *Partial) Define(name, value string) *errors.Error {
+	tks, err := scanner.ScanString("<build-in>", value, nil)
+	if err != nil {
+		return errors.NewStd(token.Position{}, err)
+	}
+	var body []token.Token
+	for _, t := range tks {
+		if t.Type() != token.WHITESPACE && t.Type() != token.NEWLINE {
+			body = append(body, t)
+		}
+	}
+	delete(p.und, name)
+	p.val[name] = body
+	return nil
+}
+
+// 声明未定义的宏
+func (p *Partial) Undefine(name string) {
+	delete(p.val, name)
+	p.und[name] = struct{}{}
+}
+
+func (p *Partial) lookup(name string) ([]token.Token, Tristate) {
+	if v, ok := p.val[name]; ok {
+		return v, True
+	}
+	if _, ok := p.und[name]; ok {
+		return nil, False
+	}
+	return nil, Unknown
+}
+
+func (p *Partial) Error() errors.ErrorList {
+	return p.err
+}
+
+// 条件编译状态
+type partialCdt struct {
+	name string
+	pos  token.Position
+	keep bool // 条件未知，保留指令
+	done bool // 已经有确定成立的分支
+	skip bool // 丢弃当前分支
+	dead bool // 整个条件块都被丢弃
+}
+
+// 处理文件
+func (p *Partial) ProcessFile(w io.Writer, filename string) error {
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	return p.Process(w, filename, string(b))
+}
+
+// 处理代码，结果写入 w
+func (p *Partial) Process(w io.Writer, filename, src string) error {
+	var stack []*partialCdt
+	visible := func() bool {
+		for _, v := range stack {
+			if v.skip || v.dead {
+				return false
+			}
+		}
+		return true
+	}
+	var out strings.Builder
+	for _, l := range readLogicLines(src) {
+		switch l.Directive {
+		case "if", "ifdef", "ifndef":
+			if !visible() {
+				stack = append(stack, &partialCdt{name: l.Directive, pos: l.pos(filename), dead: true})
+				continue
+			}
+			cdt := &partialCdt{name: l.Directive, pos: l.pos(filename)}
+			switch p.condition(filename, l) {
+			case True:
+				cdt.done = true
+			case False:
+				cdt.skip = true
+			default:
+				cdt.keep = true
+				out.WriteString(l.Text)
+			}
+			stack = append(stack, cdt)
+			continue
+		case "elif", "else", "endif":
+			n := len(stack)
+			if n == 0 {
+				code := errors.ErrMacroUnexpectedEndIf
+				switch l.Directive {
+				case "elif":
+					code = errors.ErrMacroUnexpectedElseIf
+				case "else":
+					code = errors.ErrMacroUnexpectedElse
+				}
+				p.err.AddErrMsg(l.pos(filename), code)
+				break
+			}
+			cdt := stack[n-1]
+			if l.Directive == "endif" {
+				stack = stack[:n-1]
+				if cdt.keep && !cdt.dead && visible() {
+					out.WriteString(l.Text)
+				}
+				continue
+			}
+			if cdt.dead {
+				continue
+			}
+			if cdt.done {
+				cdt.skip = true
+				continue
+			}
+			state := True
+			if l.Directive == "elif" {
+				state = p.condition(filename, l)
+			}
+			switch state {
+			case True:
+				cdt.skip = false
+				cdt.done = true
+				if cdt.keep {
+					// 之前的分支条件未知，成立的 #elif 成为 #else
+					out.WriteString(l.replace("else"))
+				}
+			case False:
+				cdt.skip = true
+			default:
+				cdt.skip = false
+				if cdt.keep {
+					out.WriteString(l.Text)
+				} else {
+					// 之前的分支均不成立，#elif 成为新的 #if
+					cdt.keep = true
+					out.WriteString(l.rename("if"))
+				}
+			}
+			continue
+		}
+		if visible() {
+			out.WriteString(l.Text)
+		}
+	}
+	for i := len(stack) - 1; i >= 0; i-- {
+		p.err.AddErrMsg(stack[i].pos, errors.ErrMacroUnterminatedCondition, stack[i].name)
+	}
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+// 计算指令条件
+func (p *Partial) condition(filename string, l *logicLine) Tristate {
+	args := l.args(filename)
+	switch l.Directive {
+	case "ifdef", "ifndef":
+		if len(args) == 0 || args[0].Type() != token.IDENT {
+			p.err.AddErrMsg(l.pos(filename), errors.ErrMacroExpectedTokenGotEof, token.IDENT)
+			return Unknown
+		}
+		_, state := p.lookup(args[0].Literal())
+		if l.Directive == "ifndef" && state != Unknown {
+			state = True - state
+		}
+		return state
+	}
+	ctx := NewContext()
+	expr := NewParser(ctx, scanner.NewArrayScan(args)).ParseExpr()
+	if len(ctx.Error()) > 0 {
+		p.err.Merge(ctx.Error())
+		return Unknown
+	}
+	e := NewTristateEvaluator(ctx, p.lookup)
+	state := e.EvalTristate(expr)
+	p.err.Merge(e.Error())
+	return state
+}
@@ -0,0 +1,113 @@
+package preprocess
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPartial_Process(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want string
+	}{
+		{
+			"known",
+			"#ifdef A\nint a;\n#else\nint b;\n#endif\n",
+			"int a;\n",
+		},
+		{
+			"unknown",
+			"#if X > 1\nint x;\n#endif\n",
+			"#if X > 1\nint x;\n#endif\n",
+		},
+		{
+			"and-unknown",
+			"#if defined(B) && X\nint x;\n#endif\nint y;\n",
+			"int y;\n",
+		},
+		{
+			"or-unknown",
+			"#if defined(A) || X\nint x;\n#endif\n",
+			"int x;\n",
+		},
+		{
+			"and-short-circuit",
+			"#if defined(B) && 10/B > 2\nint b;\n#endif\n#if defined(A) && 10/A > 2\nint a;\n#endif\n",
+			"int a;\n",
+		},
+		{
+			"zero-short-circuit",
+			"#if 0 && 1/0\nint x;\n#endif\n#if 1 || 1/0\nint y;\n#endif\n",
+			"int y;\n",
+		},
+		{
+			"elif-to-if",
+			"#ifdef B\nint b;\n#elif X\nint x;\n#else\nint y;\n#endif\n",
+			"#if X\nint x;\n#else\nint y;\n#endif\n",
+		},
+		{
+			"elif-to-else",
+			"#if X\nint x;\n#elif A == 2\nint a;\n#else\nint y;\n#endif\n",
+			"#if X\nint x;\n#else\nint a;\n#endif\n",
+		},
+		{
+			"elif-continuation",
+			"#ifdef B\nint b;\n#elif \\\n X\nint x;\n#endif\n",
+			"#if \\\n X\nint x;\n#endif\n",
+		},
+		{
+			"elif-to-else-continuation",
+			"#if X\nint x;\n#elif A \\\n == 2\nint a;\n#endif\n",
+			"#if X\nint x;\n#else\\\n\nint a;\n#endif\n",
+		},
+		{
+			"nested",
+			"#ifndef B\n#if X\n#ifdef A\nint a;\n#endif\n#endif\n#endif\n",
+			"#if X\nint a;\n#endif\n",
+		},
+		{
+			"dead",
+			"#ifdef B\n#if X\nint x;\n#endif\n#endif\n",
+			"",
+		},
+		{
+			"continuation",
+			"#if defined(A) \\\n && X\nint x;\n#endif\n",
+			"#if defined(A) \\\n && X\nint x;\n#endif\n",
+		},
+		{
+			"macro-value",
+			"#if A + 1 == 3\nint a;\n#endif\n",
+			"int a;\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPartial()
+			if err := p.Define("A", "2"); err != nil {
+				t.Fatal(err)
+			}
+			p.Undefine("B")
+			var out strings.Builder
+			if err := p.Process(&out, "partial.c", tt.code); err != nil {
+				t.Fatal(err)
+			}
+			if len(p.Error()) > 0 {
+				t.Errorf("unexpected error %v", p.Error())
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("want %q got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPartial_Error(t *testing.T) {
+	p := NewPartial()
+	var out strings.Builder
+	_ = p.Process(&out, "partial.c", "#if X\n#elif 1 / 0\n#endif\n#endif\n#ifdef Y\n")
+	if n := len(p.Error()); n != 3 {
+		t.Errorf("want 3 errors, got %d: %v", n, p.Error())
+	}
+}
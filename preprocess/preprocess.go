@@ -9,8 +9,19 @@ import (
 	"strings"
 )
 
+// 注释处理方式
+type CommentMode int
+
+const (
+	CommentDiscard     CommentMode = iota // 丢弃注释，注释作为空白处理
+	CommentKeep                           // 保留注释，指令中的注释丢弃（-C）
+	CommentKeepInMacro                    // 保留注释，包括宏定义中的注释（-CC）
+)
+
 type Option struct {
 	scanner.Option
+	// 注释处理方式，保留注释时输入的扫描器需要开启 KeepComment
+	Comment CommentMode
 }
 
 type processor struct {
@@ -18,6 +29,7 @@ type processor struct {
 	cur token.Token
 	r   scanner.Scanner
 	opt *Option
+	eof bool // 已经检查过文件结尾
 }
 
 // New 创建宏处理器
@@ -25,17 +37,22 @@ func New(ctx *Context, r scanner.Scanner, opt *Option) scanner.Scanner {
 	e := &processor{}
 	e.r = r
 	e.ctx = ctx
-	e.next()
+	e.ctx.cdt.enter()
 	if opt == nil {
 		opt = &Option{}
 	}
 	e.opt = opt
+	e.next()
 	return e
 }
 
 func (p *processor) Scan() (t token.Token) {
 	for t == nil {
 		if p.cur.Type() == token.EOF {
+			if !p.eof {
+				p.eof = true
+				p.leaveFile()
+			}
 			return p.cur
 		}
 		// 宏定义
@@ -96,6 +113,17 @@ func (p *processor) expand(tok token.Token) bool {
 				p.next()
 				return true
 			}
+		case *MacroFuncHandler:
+			if n := p.peekNext(); n.Literal() != "(" {
+				return false
+			}
+			if t, tks, ok := p.expandFuncHandler(tok, val); ok {
+				d := calcDelta(t, tks)
+				p.deltaLine(d)
+				p.push(tks)
+				p.next()
+				return true
+			}
 		}
 	}
 	return false
@@ -124,6 +152,66 @@ func (p *processor) expandFunc(tok token.Token, val *MacroFunc) ([]token.Token,
 	return total, body, true
 }
 
+// 展开 Go 实现的函数式宏
+func (p *processor) expandFuncHandler(tok token.Token, val *MacroFuncHandler) ([]token.Token, []token.Token, bool) {
+	c := p.startCache()
+	p.nextToken() // ident
+	args, ok := p.readArguments()
+	if !ok {
+		c.Restore()
+		return nil, nil, false
+	}
+	p.push([]token.Token{p.cur})
+	total := c.GetClear()
+	total = append([]token.Token{tok}, total[:len(total)-1]...)
+	if val.Expand {
+		for i := range args {
+			args[i], _ = scanner.ScanToken(New(p.ctx, newExpandMock(tok, scanner.NewArrayScan(args[i])), p.opt))
+		}
+	}
+	tks, err := val.Handler(tok, args)
+	if err != nil {
+		p.ctx.err.AddStdErr(tok.Position(), err)
+		return total, nil, true
+	}
+	return total, p.expandVal(tok, layoutTokens(tks)), true
+}
+
+// 读取不定数量的调用参数
+func (p *processor) readArguments() ([][]token.Token, bool) {
+	p.expectPunctuator("(")
+	var args [][]token.Token
+	var arg []token.Token
+	paren := 0
+	for !p.isMacroEnd() {
+		lit := p.cur.Literal()
+		if paren == 0 && (lit == "," || lit == ")") {
+			args = append(args, trimWhitespace(arg))
+			arg = nil
+			if lit == ")" {
+				p.next()
+				// 没有参数
+				if len(args) == 1 && len(args[0]) == 0 {
+					args = args[:0]
+				}
+				return args, true
+			}
+			p.next()
+			continue
+		}
+		switch lit {
+		case "(":
+			paren++
+		case ")":
+			paren--
+		}
+		arg = append(arg, p.cur)
+		p.next()
+	}
+	p.addErr(p.cur.Position(), errors.ErrMacroExpectedPunctuator, ")", p.cur.Literal())
+	return nil, false
+}
+
 // 展开token
 func (p *processor) expandMacroBodyToken(tok, ident token.Token, params map[string][]token.Token, afterHashHash, followHashHash bool) (exp []token.Token) {
 	// 调整展开位置
@@ -136,7 +224,7 @@ func (p *processor) expandMacroBodyToken(tok, ident token.Token, params map[stri
 	if cur, ok := params[ident.Literal()]; ok {
 		// fmt.Println("cur", ident.Literal(), "=>", inlineTokenString(cur))
 		if afterHashHash {
-			exp = append(exp, cur[0])
+			exp = append(exp, spellToken(cur[0]))
 			cur = cur[1:]
 		}
 		nc := len(cur)
@@ -159,10 +247,12 @@ func (p *processor) expandMacroBodyToken(tok, ident token.Token, params map[stri
 					Filename: tail.Position().Filename,
 					Line:     tail.Position().Line,
 					Column:   tail.Position().Column + delta,
+					Offset:   tail.Position().Offset,
 				},
 				Typ:    tail.Type(),
 				Lit:    tail.Literal(),
 				Expand: tok,
+				Spell:  Spelling(tail),
 			}
 			exp = append(exp, t)
 		}
@@ -184,21 +274,56 @@ func calcExpandPos(tok token.Token, tks []token.Token) {
 			Filename: pos.Filename,
 			Line:     pos.Line,
 			Column:   offset + pos.Column,
+			Offset:   pos.Offset,
 		})
 	}
 }
 
 func makeExpand(tok token.Token, tks []token.Token) {
 	for i := range tks {
+		exp := tok
+		// 参数中展开的宏保留完整的展开链
+		if expandBy(tks[i], tok) {
+			exp = tks[i].(*Token).Expand
+		}
 		tks[i] = &Token{
 			Pos:    tks[i].Position(),
 			Typ:    tks[i].Type(),
 			Lit:    tks[i].Literal(),
-			Expand: tok,
+			Expand: exp,
+			Spell:  Spelling(tks[i]),
+		}
+	}
+}
+
+// 判断 token 是否由 tok 展开得到
+func expandBy(t, tok token.Token) bool {
+	for {
+		v, ok := t.(*Token)
+		if !ok || v.Expand == nil {
+			return false
+		}
+		if v.Expand == tok {
+			return true
 		}
+		t = v.Expand
 	}
 }
 
+// 复制 token 并记录拼写位置
+func spellToken(t token.Token) *Token {
+	tt := &Token{
+		Pos:   t.Position(),
+		Typ:   t.Type(),
+		Lit:   t.Literal(),
+		Spell: Spelling(t),
+	}
+	if v, ok := t.(*Token); ok {
+		tt.Expand = v.Expand
+	}
+	return tt
+}
+
 // 展开参数
 func (p *processor) expandMacroBody(tok token.Token, body []token.Token, params map[string][]token.Token) []token.Token {
 	var exp []token.Token
@@ -207,7 +332,10 @@ func (p *processor) expandMacroBody(tok token.Token, body []token.Token, params
 		return exp
 	}
 
-	tks := copyTokenSlice(body)
+	tks := make([]token.Token, n)
+	for i := range body {
+		tks[i] = spellToken(body[i])
+	}
 	calcExpandPos(tok, tks)
 
 	// 展开处理
@@ -247,6 +375,7 @@ func (p *processor) expandMacroBody(tok token.Token, body []token.Token, params
 				Typ:    typ,
 				Lit:    lit,
 				Expand: tok,
+				Spell:  Spelling(exp[tail]),
 			}
 			exp = append(exp, tokens[1:]...)
 			beforeLen := tokenLen([]token.Token{beforeTok, afterTok})
@@ -271,6 +400,7 @@ func (p *processor) expandMacroBody(tok token.Token, body []token.Token, params
 				Typ:    typ,
 				Lit:    lit,
 				Expand: tok,
+				Spell:  Spelling(tks[i]),
 			}
 			exp = append(exp, t)
 			i++
@@ -282,6 +412,7 @@ func (p *processor) expandMacroBody(tok token.Token, body []token.Token, params
 			Typ:    typ,
 			Lit:    lit,
 			Expand: tok,
+			Spell:  Spelling(tks[i]),
 		})
 	}
 	return exp
@@ -351,9 +482,42 @@ func (p *processor) readEllipsisParameter() (tks []token.Token) {
 // 获取下一个
 func (p *processor) next() token.Token {
 	p.cur = p.r.Scan()
+	// 包含的文件结束
+	for {
+		end, ok := p.cur.(*fileEnd)
+		if !ok {
+			break
+		}
+		if !end.done {
+			end.done = true
+			p.leaveFile()
+		}
+		p.cur = p.r.Scan()
+	}
+	// 丢弃注释
+	if p.cur.Type() == token.COMMENT && p.opt.Comment == CommentDiscard {
+		p.cur = &Token{Pos: p.cur.Position(), Typ: token.WHITESPACE, Lit: " "}
+	}
 	return p.cur
 }
 
+// 离开文件，由内向外报告未闭合的条件编译
+func (p *processor) leaveFile() {
+	open := p.ctx.cdt.leave()
+	for i := len(open) - 1; i >= 0; i-- {
+		p.addErr(open[i].pos, errors.ErrMacroUnterminatedCondition, open[i].name)
+	}
+}
+
+// 检查是否结束了其他文件中打开的条件编译
+func (p *processor) unexpectedCdt(pos token.Position, code errors.ErrCode) {
+	if v, ok := p.ctx.cdt.outer(); ok {
+		p.addErr(pos, errors.ErrMacroConditionCrossFile, p.cur.Literal(), v.pos.String(), v.name)
+		return
+	}
+	p.addErr(pos, code)
+}
+
 func (p *processor) addErr(pos token.Position, code errors.ErrCode, args ...interface{}) {
 	p.ctx.AddErrorMsg(pos, code, args...)
 }
@@ -366,7 +530,7 @@ func (p *processor) err(err *errors.Error) {
 func (p *processor) nextToken() token.Token {
 	for {
 		p.next()
-		if p.cur.Type() != token.WHITESPACE {
+		if !isSpace(p.cur) {
 			break
 		}
 	}
@@ -376,7 +540,7 @@ func (p *processor) nextToken() token.Token {
 // 获取下一个非空token
 func (p *processor) skipWhitespace() token.Token {
 	for {
-		if p.cur.Type() != token.WHITESPACE {
+		if !isSpace(p.cur) {
 			break
 		}
 		p.next()
@@ -384,7 +548,24 @@ func (p *processor) skipWhitespace() token.Token {
 	return p.cur
 }
 
+// 宏定义体中是否保留token，-CC 时保留注释
+func (p *processor) isBodyToken(tok token.Token) bool {
+	if tok.Type() == token.COMMENT {
+		return p.opt.Comment == CommentKeepInMacro
+	}
+	return tok.Type() != token.WHITESPACE
+}
+
+// 跳过宏定义体中不保留的token
+func (p *processor) skipBodySpace() token.Token {
+	for !p.isMacroEnd() && !p.isBodyToken(p.cur) {
+		p.next()
+	}
+	return p.cur
+}
+
 func (p *processor) doMacro() {
+	hash := p.cur.Position()
 	p.nextToken()
 	switch p.cur.Literal() {
 	case "if":
@@ -392,54 +573,50 @@ func (p *processor) doMacro() {
 		cdt := p.evalConstExpr()
 		p.expectEndMacro()
 		if cdt {
-			p.ctx.Push(IN_THEN)
+			p.ctx.Push(IN_THEN, "if", hash)
 		} else {
 			// 跳到下一个分支
-			p.ctx.Push(IN_ELSE)
+			p.ctx.Push(IN_ELSE, "if", hash)
 			p.skipUtilElse()
 		}
 	case "ifdef":
-		p.doIfDefine(true)
+		p.doIfDefine(hash, true)
 	case "ifndef":
-		p.doIfDefine(false)
+		p.doIfDefine(hash, false)
 	case "elif":
-		p.next()
 		if p.ctx.Top() == IN_ELSE {
+			p.next()
 			cdt := p.evalConstExpr()
 			p.expectEndMacro()
 			if cdt {
-				p.ctx.Pop()
-				p.ctx.Push(IN_THEN)
+				p.ctx.cdt.Set(IN_THEN)
 			} else {
 				// 跳到下一个分支
 				p.skipUtilElse()
 			}
 		} else if p.ctx.Top() == IN_THEN {
 			// 直接跳到结尾
-			p.skipUtilCdt("endif")
-			p.next() // endif
-			p.expectEndMacro()
+			p.skipUtilEndIf()
 		} else {
-			p.addErr(p.cur.Position(), errors.ErrMacroUnexpectedElseIf)
+			p.unexpectedCdt(hash, errors.ErrMacroUnexpectedElseIf)
+			p.skipEndMacro()
 		}
 	case "else":
-		p.next()
-		p.expectEndMacro()
 		if p.ctx.Top() == IN_THEN {
-			p.skipUtilCdt("endif")
+			p.skipUtilEndIf()
+		} else {
+			p.unexpectedCdt(hash, errors.ErrMacroUnexpectedElse)
 			p.next()
 			p.expectEndMacro()
-		} else {
-			p.addErr(p.cur.Position(), errors.ErrMacroUnexpectedElse)
 		}
 	case "endif":
 		if p.ctx.Top() == IN_THEN || p.ctx.Top() == IN_ELSE {
 			p.ctx.Pop()
-			p.next() // endif
-			p.expectEndMacro()
 		} else {
-			p.addErr(p.cur.Position(), errors.ErrMacroUnexpectedEndIf)
+			p.unexpectedCdt(hash, errors.ErrMacroUnexpectedEndIf)
 		}
+		p.next() // endif
+		p.expectEndMacro()
 	case "define":
 		p.doDefine()
 	case "undef":
@@ -479,7 +656,15 @@ func (p *processor) deltaLine(delta int) {
 	c := p.startCache()
 	p.skipEndMacro()
 	arr := c.GetClear()
-	columnDelta(arr, delta)
+	// 跨行注释之后的token不在同一行
+	n := len(arr)
+	for i, tok := range arr {
+		if tok.Type() == token.COMMENT && strings.Contains(tok.Literal(), "\n") {
+			n = i + 1
+			break
+		}
+	}
+	columnDelta(arr[:n], delta)
 	p.push(arr)
 }
 
@@ -491,7 +676,7 @@ func (p *processor) peekNext() token.Token {
 		if len(v) < n {
 			break
 		}
-		if v[n-1].Type() != token.WHITESPACE {
+		if !isSpace(v[n-1]) {
 			return v[n-1]
 		}
 		n++
@@ -552,13 +737,14 @@ func (p *processor) isMacroEnd() bool {
 }
 
 // 跳过无法到达的代码
+// 遇到文件结尾时返回空，未闭合的条件在离开文件时报告
 func (p *processor) skipUtilCdt(names ...string) []token.Token {
 	cdt := 0
+	depth := p.ctx.cdt.depth()
 	tks := make([]token.Token, 2)
 	for {
 		p.next()
-		if p.cur.Type() == token.EOF {
-			p.addErr(p.cur.Position(), errors.ErrMacroExpectedTokenGotEof, strings.Join(names, ","))
+		if p.cur.Type() == token.EOF || p.ctx.cdt.depth() < depth {
 			break
 		}
 		if isMacroTok(p.cur) {
@@ -566,6 +752,8 @@ func (p *processor) skipUtilCdt(names ...string) []token.Token {
 			p.nextToken()
 			switch p.cur.Literal() {
 			case "if", "ifndef", "ifdef":
+				// 跳过的嵌套条件也要入栈，文件结束时才能报告
+				p.ctx.Push(IN_ELSE, p.cur.Literal(), tks[0].Position())
 				cdt++
 			default:
 				if cdt == 0 {
@@ -580,6 +768,7 @@ func (p *processor) skipUtilCdt(names ...string) []token.Token {
 					}
 				}
 				if p.cur.Literal() == "endif" {
+					p.ctx.Pop()
 					cdt--
 				}
 			}
@@ -589,14 +778,15 @@ func (p *processor) skipUtilCdt(names ...string) []token.Token {
 }
 
 // #ifdef #ifndef
-func (p *processor) doIfDefine(want bool) {
+func (p *processor) doIfDefine(hash token.Position, want bool) {
+	name := p.cur.Literal()
 	p.nextToken()
 	ident := p.expectIdent()
 	cdt := p.ctx.IsDefined(ident)
 	if cdt == want {
-		p.ctx.Push(IN_THEN)
+		p.ctx.Push(IN_THEN, name, hash)
 	} else {
-		p.ctx.Push(IN_ELSE)
+		p.ctx.Push(IN_ELSE, name, hash)
 		p.skipUtilElse()
 	}
 	p.expectEndMacro()
@@ -604,22 +794,42 @@ func (p *processor) doIfDefine(want bool) {
 
 // skip to #else/#elif
 func (p *processor) skipUtilElse() {
+	depth := p.ctx.cdt.depth()
 	m := p.skipUtilCdt("elif", "else")
-	if p.cur.Literal() == "elif" {
+	if p.cur.Type() == token.EOF || p.ctx.cdt.depth() < depth {
+		return
+	}
+	switch p.cur.Literal() {
+	case "elif":
 		p.next()  // elif
 		p.push(m) // push back
-	} else {
+	case "endif":
+		p.ctx.Pop()
+		p.next()
+	default:
 		p.next() // else
 	}
 }
 
+// skip to #endif
+func (p *processor) skipUtilEndIf() {
+	depth := p.ctx.cdt.depth()
+	p.skipUtilCdt("endif")
+	if p.cur.Type() == token.EOF || p.ctx.cdt.depth() < depth {
+		return
+	}
+	p.ctx.Pop()
+	p.next() // endif
+	p.expectEndMacro()
+}
+
 func (p *processor) evalConstExpr() bool {
 	var tks []token.Token
 	for {
 		if p.cur.Type() == token.EOF || p.cur.Type() == token.NEWLINE {
 			break
 		}
-		if p.cur.Type() != token.WHITESPACE {
+		if !isSpace(p.cur) {
 			tks = append(tks, p.cur)
 		}
 		p.next()
@@ -666,11 +876,12 @@ func (p *processor) doUndef() {
 
 func (p *processor) doDefineVal(ident string) {
 	var tks []token.Token
-	p.skipWhitespace()
+	p.skipBodySpace()
 
 	for !p.isMacroEnd() {
 		tks = append(tks, p.cur)
-		p.nextToken()
+		p.next()
+		p.skipBodySpace()
 	}
 
 	if err := p.ctx.DefineVal(ident, tks); err != nil {
@@ -700,11 +911,17 @@ func (p *processor) doDefineFunc(ident string) {
 		}
 	}
 
-	p.expectPunctuator(")")
+	if p.cur.Literal() == ")" {
+		p.next()
+		p.skipBodySpace()
+	} else {
+		p.expectPunctuator(")")
+	}
 
 	for !p.isMacroEnd() {
 		tks = append(tks, p.cur)
-		p.nextToken()
+		p.next()
+		p.skipBodySpace()
 	}
 	if err := p.ctx.DefineFunc(ident, params, elp, tks); err != nil {
 		p.err(err)
@@ -770,13 +987,16 @@ func (p *processor) includeFile(s string) {
 			p.expectEndMacro()
 			return
 		}
-		sc, err := scanner.NewFileScan(fn, &p.opt.Option)
+		opt := p.opt.Option
+		opt.KeepComment = opt.KeepComment || p.opt.Comment != CommentDiscard
+		sc, err := scanner.NewFileScan(fn, &opt)
 		if err != nil {
 			p.addErr(p.cur.Position(), errors.ErrMacroIncludeFileRead, fn, err.Error())
 			return
 		}
 		p.push([]token.Token{p.cur})
-		p.pushScanner(sc)
+		p.pushScanner(&includeScanner{Scanner: sc})
+		p.ctx.cdt.enter()
 		p.next()
 	} else {
 		p.addErr(p.cur.Position(), errors.ErrMacroIncludeFileNoFound, s)
@@ -880,9 +1100,35 @@ func (em *expandMock) Scan() token.Token {
 		Typ:    t.Type(),
 		Lit:    t.Literal(),
 		Expand: em.tok,
+		Spell:  Spelling(t),
 	}
 }
 
 func newExpandMock(tok token.Token, r scanner.Scanner) scanner.Scanner {
 	return &expandMock{r, tok}
 }
+
+// 包含文件扫描，文件结束时返回结束标记
+type includeScanner struct {
+	scanner.Scanner
+	end bool
+}
+
+func (s *includeScanner) Scan() token.Token {
+	t := s.Scanner.Scan()
+	if t.Type() == token.EOF && !s.end {
+		s.end = true
+		return &fileEnd{pos: t.Position()}
+	}
+	return t
+}
+
+// 包含文件结束标记
+type fileEnd struct {
+	pos  token.Position
+	done bool
+}
+
+func (t *fileEnd) Position() token.Position { return t.pos }
+func (t *fileEnd) Type() token.Type         { return token.NEWLINE }
+func (t *fileEnd) Literal() string          { return "" }
@@ -125,3 +125,37 @@ func Test_columnDelta(t *testing.T) {
 		t.Error("delta error")
 	}
 }
+
+func TestComment(t *testing.T) {
+	code := `// head
+#define A 1 /* in A */
+#define F(x) /* in F */ x
+int a = A; /* block
+ text */ int b = F(2);
+`
+	tests := []struct {
+		name string
+		mode CommentMode
+		want string
+	}{
+		{"discard", CommentDiscard, " \n\n\nint a = 1; \n         int b = 2;\n"},
+		{"keep", CommentKeep, "// head\n\n\nint a = 1; /* block\n text */ int b = 2;\n"},
+		{"keep-in-macro", CommentKeepInMacro, "// head\n\n\nint a = 1 /* in A */; /* block\n text */ int b = /* in F */ 2;\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := NewContext()
+			s := scanner.NewStringScan("comment.c", code, &scanner.Option{KeepComment: true})
+			tks, err := scanner.ScanToken(New(ctx, s, &Option{Comment: tt.mode}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := tokenString(tks); got != tt.want {
+				t.Errorf("want:\n%q\ngot:\n%q", tt.want, got)
+			}
+			if len(ctx.Error()) > 0 {
+				t.Error(ctx.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package preprocess
+
+import (
+	"dxkite.cn/c/token"
+)
+
+// 宏展开链中的一层位置信息
+type Location struct {
+	Macro     string         // 产生该 token 的宏名称，不是宏展开时为空
+	Expansion token.Position // 展开位置
+	Spelling  token.Position // 拼写位置
+}
+
+// 获取 token 的拼写位置
+// 宏展开得到的 token 为宏定义中书写的位置，参数为实参书写的位置
+func Spelling(tok token.Token) token.Position {
+	if t, ok := tok.(*Token); ok && t.Spell.Line > 0 {
+		return t.Spell
+	}
+	return tok.Position()
+}
+
+// 获取 token 的展开位置
+func Expansion(tok token.Token) token.Position {
+	return tok.Position()
+}
+
+// 获取 token 的完整展开链
+// 第一项为 token 自身，之后依次为展开它的宏调用，最后一项为最外层的宏调用
+func SourceMap(tok token.Token) []Location {
+	var locs []Location
+	for tok != nil {
+		loc := Location{
+			Expansion: Expansion(tok),
+			Spelling:  Spelling(tok),
+		}
+		var next token.Token
+		if t, ok := tok.(*Token); ok && t.Expand != nil {
+			next = t.Expand
+			loc.Macro = next.Literal()
+		}
+		locs = append(locs, loc)
+		tok = next
+	}
+	return locs
+}
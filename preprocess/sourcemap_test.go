@@ -0,0 +1,60 @@
+package preprocess
+
+import (
+	"dxkite.cn/c/scanner"
+	"dxkite.cn/c/token"
+	"fmt"
+	"testing"
+)
+
+func TestSourceMap(t *testing.T) {
+	code := `#define ONE 1
+#define ADD(a, b) (a + b)
+#define TWO ADD(ONE, x)
+int v = TWO;
+`
+	ctx := NewContext()
+	tks, _ := scanner.ScanToken(New(ctx, scanner.NewStringScan("map.c", code, nil), nil))
+	var got []token.Token
+	for _, tok := range tks {
+		if tok.Position().Line == 4 && tok.Type() != token.WHITESPACE && tok.Type() != token.NEWLINE {
+			got = append(got, tok)
+		}
+	}
+	pos := func(line, col int) token.Position {
+		return token.Position{Filename: "map.c", Line: line, Column: col}
+	}
+	tests := []struct {
+		lit  string
+		locs []Location
+	}{
+		{"int", []Location{{"", pos(4, 1), pos(4, 1)}}},
+		{"v", []Location{{"", pos(4, 5), pos(4, 5)}}},
+		{"=", []Location{{"", pos(4, 7), pos(4, 7)}}},
+		{"(", []Location{
+			{"ADD", pos(4, 9), pos(2, 19)},
+			{"TWO", pos(4, 9), pos(3, 13)},
+			{"", pos(4, 9), pos(4, 9)},
+		}},
+		{"1", []Location{
+			{"ONE", pos(4, 10), pos(1, 13)},
+			{"ADD", pos(4, 13), pos(3, 17)},
+			{"TWO", pos(4, 9), pos(3, 13)},
+			{"", pos(4, 9), pos(4, 9)},
+		}},
+	}
+	if len(got) < len(tests) {
+		t.Fatalf("want at least %d tokens, got %d", len(tests), len(got))
+	}
+	for i, tt := range tests {
+		tok := got[i]
+		if tok.Literal() != tt.lit {
+			t.Errorf("token %d want %s got %s", i, tt.lit, tok.Literal())
+			continue
+		}
+		// 只比较行列，展开位置的偏移指向宏调用处
+		if locs := SourceMap(tok); fmt.Sprint(locs) != fmt.Sprint(tt.locs) {
+			t.Errorf("token %s\nwant %v\ngot  %v", tt.lit, tt.locs, locs)
+		}
+	}
+}
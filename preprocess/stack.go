@@ -1,9 +1,19 @@
 package preprocess
 
+import "dxkite.cn/c/token"
+
 type Condition int
+
+// 条件编译状态
+type conditionItem struct {
+	cdt  Condition
+	name string         // 指令名称 if ifdef ifndef
+	pos  token.Position // 指令位置
+}
+
 type ConditionStack struct {
-	pos int
-	s   []Condition
+	s    []conditionItem
+	base []int // 每个文件开始时的栈深度
 }
 
 const (
@@ -13,37 +23,74 @@ const (
 )
 
 func NewConditionStack() *ConditionStack {
-	return &ConditionStack{
-		pos: 0,
-		s:   []Condition{GLOBAL},
+	return &ConditionStack{}
+}
+
+// 当前文件的栈底
+func (c *ConditionStack) start() int {
+	if n := len(c.base); n > 0 {
+		return c.base[n-1]
 	}
+	return 0
 }
 
-// 栈顶
+// 栈顶，不包含其他文件中的条件
 func (c *ConditionStack) Top() Condition {
-	if c.pos >= 0 && c.pos < len(c.s) {
-		return c.s[c.pos]
+	if n := len(c.s); n > c.start() {
+		return c.s[n-1].cdt
 	}
 	return GLOBAL
 }
 
 // 压入栈
-func (c *ConditionStack) Push(cdt Condition) {
-	if c.pos+1 < len(c.s) {
-		c.pos++
-		c.s[c.pos] = cdt
-		return
+func (c *ConditionStack) Push(cdt Condition, name string, pos token.Position) {
+	c.s = append(c.s, conditionItem{cdt: cdt, name: name, pos: pos})
+}
+
+// 修改栈顶
+func (c *ConditionStack) Set(cdt Condition) {
+	if n := len(c.s); n > c.start() {
+		c.s[n-1].cdt = cdt
 	}
-	c.pos++
-	c.s = append(c.s, cdt)
 }
 
 // 弹出栈
 func (c *ConditionStack) Pop() Condition {
-	if c.pos >= 0 && c.pos < len(c.s) {
-		p := c.s[c.pos]
-		c.pos--
-		return p
+	if n := len(c.s); n > c.start() {
+		p := c.s[n-1]
+		c.s = c.s[:n-1]
+		return p.cdt
 	}
 	return GLOBAL
 }
+
+// 进入文件
+func (c *ConditionStack) enter() {
+	c.base = append(c.base, len(c.s))
+}
+
+// 离开文件，返回文件中未闭合的条件
+func (c *ConditionStack) leave() []conditionItem {
+	n := len(c.base)
+	if n == 0 {
+		return nil
+	}
+	start := c.base[n-1]
+	c.base = c.base[:n-1]
+	open := append([]conditionItem{}, c.s[start:]...)
+	c.s = c.s[:start]
+	return open
+}
+
+// 文件深度
+func (c *ConditionStack) depth() int {
+	return len(c.base)
+}
+
+// 其他文件中打开的条件
+func (c *ConditionStack) outer() (conditionItem, bool) {
+	if n := len(c.s); n > 0 && n == c.start() {
+		return c.s[n-1], true
+	}
+	return conditionItem{}, false
+}
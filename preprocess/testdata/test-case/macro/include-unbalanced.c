@@ -0,0 +1,8 @@
+#if 1
+#include "include-unbalanced.h"
+int main;
+#include "include-unbalanced2.h"
+int after;
+#endif
+#ifndef END
+int end;
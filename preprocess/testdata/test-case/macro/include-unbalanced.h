@@ -0,0 +1,6 @@
+#endif
+#if 1
+int header;
+#else
+#if 0
+int skip;
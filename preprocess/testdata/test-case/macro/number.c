@@ -0,0 +1,6 @@
+#if (10L == 10 && 0x10u == 16) && (0b101 == 5 && 1'000 == 1000) && 017 == 15
+int ok = 10ul + 1.5f;
+#endif
+#if 09
+int bad;
+#endif
@@ -1,5 +1,5 @@
 int main() {
-    printf("file %s:%d count %d\n", "testdata\\test-case\\macro\\buildin.c", 2, 0);
-    printf("file %s:%d count %d\n", "testdata\\test-case\\macro\\buildin.c", 3, 1);
-    printf("file %s:%d count %d\n", "testdata\\test-case\\macro\\buildin.c", 4, 2);
+    printf("file %s:%d count %d\n", "testdata/test-case/macro/buildin.c", 2, 0);
+    printf("file %s:%d count %d\n", "testdata/test-case/macro/buildin.c", 3, 1);
+    printf("file %s:%d count %d\n", "testdata/test-case/macro/buildin.c", 4, 2);
 }
\ No newline at end of file
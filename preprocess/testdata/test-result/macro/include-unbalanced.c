@@ -0,0 +1,8 @@
+
+
+int header;
+
+int main;
+
+int after;
+int end;
@@ -0,0 +1,3 @@
+
+int ok = 10ul + 1.5f;
+
@@ -1,12 +1,12 @@
 package scanner
 
 import (
-	"bufio"
-	"bytes"
 	"dxkite.cn/c/errors"
 	"dxkite.cn/c/token"
 	"io"
-	"unicode"
+	"os"
+	"sort"
+	"strings"
 	"unicode/utf8"
 )
 
@@ -18,8 +18,46 @@ type Token struct {
 }
 
 type Option struct {
-	// 全角符号转半角符号
+	// 全角符号转半角符号，全角空格作为空白
 	PunctuatorFullWidthToHalfWidth bool
+	// 警告代码中的全角符号与输入法输入的中文符号，通过 ErrorHandler 报告
+	WarnFullWidth bool
+	// 文件集合，扫描的文件会添加到集合中
+	FileSet *token.FileSet
+	// 保留注释，输出 COMMENT 而不是空白
+	KeepComment bool
+	// 三字符组处理方式
+	Trigraph TrigraphMode
+	// 错误回调，用于报告警告
+	ErrorHandler errors.ErrorHandler
+	// 源码编码，默认自动检测
+	Encoding Encoding
+	// 语言标准
+	Std Standard
+	// 语言方言，决定额外的关键字
+	Dialect Dialect
+}
+
+// 三字符组处理方式
+type TrigraphMode int
+
+const (
+	TrigraphIgnore  TrigraphMode = iota // 不处理三字符组
+	TrigraphReplace                     // 替换三字符组
+	TrigraphWarn                        // 不替换，发现三字符组时警告
+)
+
+// 三字符组
+var trigraph = map[byte]rune{
+	'=':  '#',
+	'(':  '[',
+	'/':  '\\',
+	')':  ']',
+	'\'': '^',
+	'<':  '{',
+	'!':  '|',
+	'>':  '}',
+	'-':  '~',
 }
 
 // 非法token
@@ -33,6 +71,26 @@ type FullWidthPunctuatorToken struct {
 	*Token
 }
 
+// 关键字的别名写法，字面量为规范写法
+type KeywordToken struct {
+	*Token
+	Spelling string // 源码中的写法
+}
+
+// 注释类型
+type CommentKind int
+
+const (
+	LineComment  CommentKind = iota // 单行注释 //
+	BlockComment                    // 块注释 /* */
+)
+
+// 注释，字面量为包含注释符的完整文本
+type CommentToken struct {
+	*Token
+	Kind CommentKind
+}
+
 func (t *Token) Position() token.Position {
 	return t.Pos
 }
@@ -59,101 +117,336 @@ func NewScan(filename string, r io.Reader, option *Option) Scanner {
 }
 
 func NewStringScan(filename string, code string, option *Option) Scanner {
-	return NewScan(filename, bytes.NewBufferString(code), option)
+	return NewScan(filename, strings.NewReader(code), option)
 }
 
-type scanner struct {
-	filename  string
-	r         *bufio.Reader
-	ch        rune
-	offset    int
-	rdOffset  int
-	line, col int
-	rcd       bool
-	lit       string
-	err       error
-	opt       *Option
+// 紧凑的 token，不包含字面量
+// 源码内容为文件中 [Offset, Offset+Len) 的部分，可能包含续行符与三字符组
+type Compact struct {
+	Typ    token.Type
+	Offset int32
+	Len    int32
+}
+
+// 源码中的原始文本
+func (t Compact) Text(f *token.File) string {
+	return string(f.Content()[t.Offset : t.Offset+t.Len])
 }
 
+// 输出紧凑 token 的扫描器，NewScan 返回的扫描器均实现了该接口
+type CompactScanner interface {
+	// 获取下一个token，出错时类型为 ILLEGAL
+	ScanCompact() Compact
+	File() *token.File
+}
+
+type scanner struct {
+	filename   string
+	r          io.Reader // 转码后的输入
+	buf        []byte    // 已读取的内容，与 file 的内容一致
+	chunk      []byte    // 读取缓冲
+	eof        bool
+	ch         rune
+	offset     int
+	rdOffset   int
+	line, col  int
+	width      int  // 当前字符在源码中占的列数
+	size       int  // 当前字符在源码中占的字节数
+	lastEnd    int  // 上一个读取的字符的结束偏移
+	rcd        bool // 是否记录字面量
+	start, end int  // 记录的字面量在源码中的范围
+	dirty      bool // 记录的字面量经过转换，不能从源码中截取
+	lit        []byte
+	compact    bool              // 不需要字面量
+	names      map[string]string // 标识符缓存
+	keywords   *keywordTable     // 关键字表
+	spelling   string            // 关键字别名的原始写法
+	ext        bool              // 标识符包含扩展字符
+	fallbackAt int               // 自动检测编码时切换编码的偏移
+	win        string            // 已读取内容的字符串副本，字面量从中截取
+	winStart   int               // win 在文件中的起始偏移
+	slab       []Token           // 批量分配的 token
+	err        error
+	opt        *Option
+	file       *token.File
+}
+
+// 读取缓冲大小
+const chunkSize = 4096
+
+// 每次批量分配的 token 数量
+const slabSize = 1024
+
 // new
 func (s *scanner) new(filename string, r io.Reader) {
-	s.r = bufio.NewReader(r)
+	if s.opt == nil {
+		s.opt = &Option{}
+	}
+	if s.opt.FileSet != nil {
+		s.file = s.opt.FileSet.AddFile(filename)
+	} else {
+		s.file = token.NewFile(filename)
+	}
+	s.filename = filename
+	s.fallbackAt = -1
+	// 已知输入大小时预先分配
+	switch v := r.(type) {
+	case interface{ Len() int }:
+		s.file.Grow(v.Len())
+	case interface{ Stat() (os.FileInfo, error) }:
+		if fi, err := v.Stat(); err == nil {
+			s.file.Grow(int(fi.Size()))
+		}
+	}
+	s.r = s.decode(r)
+	s.chunk = make([]byte, chunkSize)
+	s.names = map[string]string{}
+	s.keywords = lookupKeywordTable(s.opt.Std, s.opt.Dialect)
 	s.ch = ' '
 	s.offset = 0
-	s.filename = filename
 	s.line = 1
 	s.col = 0
+	s.width = 1
 	s.nextRune()
 }
 
+// 读取输入，直到至少有 n 个未读取的字节或者输入结束
+func (s *scanner) fill(n int) {
+	for len(s.buf)-s.rdOffset < n && !s.eof {
+		m, err := s.r.Read(s.chunk)
+		if m > 0 {
+			_, _ = s.file.Write(s.chunk[:m])
+			s.buf = s.file.Content()
+		}
+		if err != nil {
+			if err != io.EOF {
+				s.markErr(errors.ErrReadFile, err)
+			}
+			s.eof = true
+		}
+	}
+}
+
+// 指定偏移的字节，超出范围时为 0
+func (s *scanner) at(offset int) byte {
+	s.fill(offset - s.rdOffset + 1)
+	if offset < len(s.buf) {
+		return s.buf[offset]
+	}
+	return 0
+}
+
+// 从当前字符开始记录字面量
 func (s *scanner) record() {
 	s.rcd = true
-	s.lit = ""
+	s.start, s.end = s.offset, s.offset
+	s.dirty = false
+}
+
+// 记录的字面量
+func (s *scanner) text() []byte {
+	s.rcd = false
+	if s.dirty {
+		return s.lit
+	}
+	return s.buf[s.start:s.end]
 }
 
 func (s *scanner) literal() string {
+	if s.dirty {
+		return string(s.text())
+	}
 	s.rcd = false
-	return s.lit
+	return s.slice(s.start, s.end)
 }
 
-// 获取下一个字符
-func (s *scanner) next() (rune, token.Position) {
-	cur := s.ch
-	p := s.curPos()
-	s.nextRune()
+// 源码中 [start, end) 的内容，窗口不包含时从 start 开始重新转换已读取的内容
+// 字面量共享窗口的内存，避免每个字面量单独分配
+func (s *scanner) slice(start, end int) string {
+	if start < s.winStart || end > s.winStart+len(s.win) {
+		n := len(s.buf)
+		if n > end+chunkSize {
+			n = end + chunkSize
+		}
+		s.win = string(s.buf[start:n])
+		s.winStart = start
+	}
+	return s.win[start-s.winStart : end-s.winStart]
+}
 
-	if s.ch == '\\' && (s.peekN(2) == "\r\n" || s.peek() == '\n') {
-		s.nextRune()
+// 从批量分配的内存中获取 token，避免每个 token 单独分配
+func (s *scanner) newToken() *Token {
+	if len(s.slab) == 0 {
+		s.slab = make([]Token, slabSize)
+	}
+	t := &s.slab[0]
+	s.slab = s.slab[1:]
+	return t
+}
+
+// 记录字符，字符连续且未经转换时直接使用源码
+func (s *scanner) add(ch rune, offset, size int) {
+	if !s.dirty && (offset != s.end || size != utf8.RuneLen(ch)) {
+		s.dirty = true
+		s.lit = append(s.lit[:0], s.buf[s.start:s.end]...)
+	}
+	s.end = offset + size
+	if s.dirty {
+		var b [utf8.UTFMax]byte
+		n := utf8.EncodeRune(b[:], ch)
+		s.lit = append(s.lit, b[:n]...)
+	}
+}
+
+// 获取下一个字符
+func (s *scanner) next() {
+	cur, offset, size := s.ch, s.offset, s.size
+	if i := s.rdOffset; cur != '\n' && i+utf8.UTFMax <= len(s.buf) && isPlain(s.buf[i]) && i != s.fallbackAt {
+		// 普通 ASCII 字符不需要转换
+		s.col += s.width
+		s.offset = i
+		s.rdOffset++
+		s.width = 1
+		s.size = 1
+		s.ch = rune(s.buf[i])
+	} else {
 		s.nextRune()
+		if s.ch == '\\' && (s.peekIs("\r\n") || s.peek() == '\n') {
+			s.nextRune()
+			s.nextRune()
+		}
 	}
 
+	s.lastEnd = offset + size
 	if cur != -1 && s.rcd {
-		s.lit += string(cur)
+		if !s.dirty && offset == s.end && size == 1 && cur < utf8.RuneSelf {
+			s.end++
+		} else {
+			s.add(cur, offset, size)
+		}
+	}
+}
+
+// 连续读取满足 fn 的普通 ASCII 字符，结束时当前字符为最后一个满足的字符
+// 效果与逐个调用 next 相同，只是不再逐个处理
+func (s *scanner) nextWhile(fn func(c byte) bool) {
+	if s.size != 1 || s.ch < 0 || s.ch >= utf8.RuneSelf || !isPlain(byte(s.ch)) || !fn(byte(s.ch)) {
+		return
+	}
+	i := s.rdOffset
+	for i+utf8.UTFMax <= len(s.buf) && isPlain(s.buf[i]) && fn(s.buf[i]) && i != s.fallbackAt {
+		i++
+	}
+	if i == s.rdOffset {
+		return
+	}
+	last := i - 1
+	if s.rcd {
+		s.add(s.ch, s.offset, 1)
+		if s.dirty {
+			s.lit = append(s.lit, s.buf[s.rdOffset:last]...)
+		}
+		s.end = last
 	}
-	return cur, p
+	s.col += s.width + last - s.rdOffset
+	s.lastEnd = last
+	s.offset = last
+	s.rdOffset = i
+	s.width = 1
+	s.size = 1
+	s.ch = rune(s.buf[last])
+}
+
+func isIdentByte(c byte) bool {
+	return 'a' <= c|0x20 && c|0x20 <= 'z' || c == '_' || '0' <= c && c <= '9'
+}
+
+func isBlank(c byte) bool { return c == ' ' }
+
+func notStar(c byte) bool { return c != '*' }
+
+func notQuote(c byte) bool { return c != '"' && c != '\'' }
+
+func anyByte(c byte) bool { return true }
+
+// 不需要转换的 ASCII 字符
+func isPlain(c byte) bool {
+	return c >= ' ' && c < utf8.RuneSelf && c != '?' && c != '\\'
 }
 
 func (s *scanner) nextRune() {
 	if s.ch == '\n' {
 		s.line++
 		s.col = 1
+		s.file.AddLine(s.rdOffset)
 	} else {
-		s.col++
+		s.col += s.width
 	}
 	s.offset = s.rdOffset
-
-	ch, w, err := s.r.ReadRune()
-	if err != nil {
-		if err != io.EOF {
-			s.markErr(errors.ErrReadFile, err)
+	s.width = 1
+	s.size = 0
+
+	if s.rdOffset+utf8.UTFMax > len(s.buf) {
+		s.fill(utf8.UTFMax)
+		if s.rdOffset >= len(s.buf) {
+			s.ch = -1
+			return
 		}
-		s.ch = -1
-		return
+	}
+	if s.offset == s.fallbackAt {
+		s.fallback()
+	}
+
+	ch, w := rune(s.buf[s.rdOffset]), 1
+	if ch >= utf8.RuneSelf {
+		ch, w = utf8.DecodeRune(s.buf[s.rdOffset:])
 	}
 
 	if ch == '\r' {
-		if s.peek() == '\n' {
+		if s.at(s.rdOffset+1) == '\n' {
 			w++
-			_, _ = s.r.ReadByte()
 		}
 		ch = '\n'
 	}
 
+	if ch == '?' && s.opt.Trigraph != TrigraphIgnore && s.at(s.rdOffset+1) == '?' {
+		c := s.at(s.rdOffset + 2)
+		if v, ok := trigraph[c]; ok {
+			s.trigraph(string(c), v)
+			if s.opt.Trigraph == TrigraphReplace {
+				w += 2
+				s.width = 3
+				ch = v
+			}
+		}
+	}
+
 	s.rdOffset += w
+	s.size = w
 	s.ch = ch
 	return
 }
 
-func (s *scanner) peek() byte {
-	buf, err := s.r.Peek(1)
-	if err != nil && err != io.EOF {
-		s.markErr(errors.ErrReadFile, err)
-		return 0
+// 报告三字符组
+func (s *scanner) trigraph(ch string, v rune) {
+	if s.opt.ErrorHandler == nil {
+		return
+	}
+	code := errors.ErrScanTrigraphIgnored
+	if s.opt.Trigraph == TrigraphReplace {
+		code = errors.ErrScanTrigraph
 	}
-	if err == io.EOF {
+	s.opt.ErrorHandler(s.curPos(), errors.ErrTypeWarning, code, "??"+ch, v)
+}
+
+func (s *scanner) peek() byte {
+	if s.opt.Trigraph == TrigraphReplace {
+		if buf := s.peekTrigraphN(1); len(buf) > 0 {
+			return buf[0]
+		}
 		return 0
 	}
-	return buf[0]
+	return s.at(s.rdOffset)
 }
 
 func (s *scanner) markErr(code errors.ErrCode, params ...interface{}) {
@@ -161,12 +454,45 @@ func (s *scanner) markErr(code errors.ErrCode, params ...interface{}) {
 }
 
 func (s *scanner) peekN(n int) string {
-	buf, err := s.r.Peek(n)
-	if err != nil && err != io.EOF {
-		s.markErr(errors.ErrReadFile, err)
-		return ""
+	if s.opt.Trigraph == TrigraphReplace {
+		return s.peekTrigraphN(n)
+	}
+	return string(s.peekBytes(n))
+}
+
+// 预读的内容是否为 str
+func (s *scanner) peekIs(str string) bool {
+	if s.opt.Trigraph == TrigraphReplace {
+		return s.peekTrigraphN(len(str)) == str
+	}
+	return string(s.peekBytes(len(str))) == str
+}
+
+// 预读至多 n 个字节，不处理三字符组
+func (s *scanner) peekBytes(n int) []byte {
+	s.fill(n)
+	end := s.rdOffset + n
+	if end > len(s.buf) {
+		end = len(s.buf)
 	}
-	return string(buf)
+	return s.buf[s.rdOffset:end]
+}
+
+// 预读替换三字符组后的 n 个字节
+func (s *scanner) peekTrigraphN(n int) string {
+	buf := s.peekBytes(n * 3)
+	b := make([]byte, 0, n)
+	for i := 0; i < len(buf) && len(b) < n; i++ {
+		if buf[i] == '?' && i+2 < len(buf) && buf[i+1] == '?' {
+			if v, ok := trigraph[buf[i+2]]; ok {
+				b = append(b, byte(v))
+				i += 2
+				continue
+			}
+		}
+		b = append(b, buf[i])
+	}
+	return string(b)
 }
 
 func (s *scanner) peekCN(ch string, n int) string {
@@ -178,75 +504,153 @@ func (s *scanner) curPos() token.Position {
 		Filename: s.filename,
 		Line:     s.line,
 		Column:   s.col,
+		Offset:   s.offset,
 	}
 }
 
+// 扫描的文件信息
+func (s *scanner) File() *token.File {
+	return s.file
+}
+
 func (s *scanner) Scan() token.Token {
-	t := &Token{}
+	t := s.newToken()
 	t.Pos = s.curPos()
-	t.Typ = token.ILLEGAL
+	typ, lit, fullWidth, comment := s.scan()
+	t.Typ = typ
+	t.Lit = lit
+	if s.err != nil {
+		return &IllegalToken{
+			Token: t,
+			Err:   s.err,
+		}
+	}
+	if fullWidth {
+		return &FullWidthPunctuatorToken{Token: t}
+	}
+	if s.spelling != "" {
+		return &KeywordToken{Token: t, Spelling: s.spelling}
+	}
+	if t.Typ == token.COMMENT {
+		return &CommentToken{Token: t, Kind: comment}
+	}
+	return t
+}
+
+func (s *scanner) ScanCompact() Compact {
+	offset := s.offset
+	s.compact = true
+	typ, _, _, _ := s.scan()
+	s.compact = false
+	if s.err != nil {
+		typ = token.ILLEGAL
+	}
+	return Compact{Typ: typ, Offset: int32(offset), Len: int32(s.lastEnd - offset)}
+}
+
+// 扫描下一个token，紧凑模式下不生成字面量
+func (s *scanner) scan() (typ token.Type, lit string, fullWidth bool, comment CommentKind) {
+	typ = token.ILLEGAL
 	s.err = nil
-	fullWidth := false
+	s.spelling = ""
+	comment = LineComment
 	switch ch := s.ch; {
-	case isWhitespace(ch):
-		t.Typ = token.WHITESPACE
-		t.Lit = " "
+	case s.isSpace(ch):
+		typ = token.WHITESPACE
+		lit = " "
 		s.skipWhitespace()
 	case ch == '/' && (s.peek() == '/' || s.peek() == '*'):
-		t.Typ = token.WHITESPACE
-		t.Lit = " "
-		s.skipComment()
+		typ = token.WHITESPACE
+		lit = " "
+		if s.peek() == '*' {
+			comment = BlockComment
+		}
+		if s.opt.KeepComment {
+			s.record()
+			s.skipComment()
+			typ = token.COMMENT
+			lit = s.value()
+		} else {
+			s.skipComment()
+		}
 	case s.nextIsChar(ch):
-		t.Typ = token.CHAR
-		t.Lit = s.scanChar()
+		typ = token.CHAR
+		s.scanChar()
+		lit = s.value()
 	case s.nextIsString(ch):
-		t.Typ = token.STRING
-		t.Lit = s.scanString()
-	case isLetter(ch):
-		t.Typ = token.IDENT
-		t.Lit = s.scanIdentifier()
-		switch t.Lit {
-		case "auto", "break", "case", "char", "const", "continue", "default", "do", "double", "else",
-			"enum", "extern", "float", "for", "goto", "if", "inline", "int", "long", "register",
-			"restrict", "return", "short", "signed", "sizeof", "static", "struct", "switch", "typedef", "union",
-			"unsigned", "void", "volatile", "while", "_Bool", "_Complex", "_Imaginary":
-			t.Typ = token.KEYWORD
-		}
+		typ = token.STRING
+		s.scanString()
+		lit = s.value()
+	case s.isIdentStart(ch), ch == '\\' && s.isUCN():
+		line, col, offset := s.line, s.col, s.offset
+		s.scanIdentifier()
+		lit, typ = s.ident(line, col, offset)
 	case s.nextIsNumber():
-		t.Typ, t.Lit = s.scanNumber()
+		typ = s.scanNumber()
+		lit = s.value()
 	default:
-		if lit, n, full, ok := s.nextIsPunctuator(); ok {
-			t.Lit = lit
-			t.Typ = token.PUNCTUATOR
+		if v, n, full, ok := s.nextIsPunctuator(); ok {
+			lit = v
+			typ = token.PUNCTUATOR
 			fullWidth = full
 			for n > 0 {
 				n--
+				s.warnFullWidth()
 				s.next()
 			}
 		} else {
+			s.warnFullWidth()
 			s.next()
 			switch ch {
 			case -1:
-				t.Typ = token.EOF
+				typ = token.EOF
 			case '\n':
-				t.Typ = token.NEWLINE
-				t.Lit = "\n"
+				typ = token.NEWLINE
+				lit = "\n"
 			default:
-				t.Lit = string(ch)
-				t.Typ = token.TEXT
+				typ = token.TEXT
+				if !s.compact {
+					lit = string(ch)
+				}
 			}
 		}
 	}
-	if s.err != nil {
-		return &IllegalToken{
-			Token: t,
-			Err:   s.err,
+	s.rcd = false
+	return
+}
+
+// 记录的字面量，紧凑模式下为空
+func (s *scanner) value() string {
+	if s.compact {
+		s.rcd = false
+		return ""
+	}
+	return s.literal()
+}
+
+// 标识符的字面量与类型，相同的标识符共享字符串
+func (s *scanner) ident(line, col, offset int) (string, token.Type) {
+	b := s.text()
+	if s.ext {
+		b = s.normIdent(b, line, col, offset)
+	} else if kw, ok := s.keywords.lookup(b); ok {
+		if kw != string(b) && !s.compact {
+			s.spelling = string(b)
 		}
+		return kw, token.KEYWORD
 	}
-	if fullWidth {
-		return &FullWidthPunctuatorToken{Token: t}
+	if s.compact {
+		return "", token.IDENT
 	}
-	return t
+	if v, ok := s.names[string(b)]; ok {
+		return v, token.IDENT
+	}
+	v := string(b)
+	if !s.ext && !s.dirty {
+		v = s.slice(s.start, s.end)
+	}
+	s.names[v] = v
+	return v, token.IDENT
 }
 
 func isWhitespace(ch rune) bool {
@@ -258,22 +662,40 @@ func isWhitespace(ch rune) bool {
 	}
 }
 
+// 空白字符，转换全角符号时包含全角空格
+func (s *scanner) isSpace(ch rune) bool {
+	return isWhitespace(ch) || ch == '\u3000' && s.opt.PunctuatorFullWidthToHalfWidth
+}
+
 func (s *scanner) skipWhitespace() bool {
 	c := 0
-	for isWhitespace(s.ch) {
+	for s.isSpace(s.ch) {
 		c++
+		s.nextWhile(isBlank)
+		s.warnFullWidth()
 		s.next()
 	}
 	return c > 0
 }
 
-// 扫描标识符
-func (s *scanner) scanIdentifier() string {
+// 扫描标识符，包含扩展字符与通用字符名
+func (s *scanner) scanIdentifier() {
 	s.record()
-	for isLetter(s.ch) || isDigit(s.ch) {
-		s.next()
+	s.ext = false
+	for first := true; ; first = false {
+		switch ch := s.ch; {
+		case ch < utf8.RuneSelf && (isLetter(ch) || !first && isDecimal(ch)):
+			s.nextWhile(isIdentByte)
+			s.next()
+		case ch == '\\' && s.isUCN():
+			s.scanIdentUCN(first)
+		case ch >= utf8.RuneSelf && s.isIdentExt(ch, first):
+			s.ext = true
+			s.next()
+		default:
+			return
+		}
 	}
-	return s.literal()
 }
 
 func lower(ch rune) rune { return ('a' - 'A') | ch }
@@ -285,16 +707,12 @@ func isHex(ch rune) bool { return '0' <= ch && ch <= '9' || 'a' <= lower(ch) &&
 func isOct(ch rune) bool { return '0' <= ch && ch <= '7' }
 
 func isLetter(ch rune) bool {
-	return 'a' <= lower(ch) && lower(ch) <= 'z' || ch == '_' || ch >= utf8.RuneSelf && unicode.IsLetter(ch)
-}
-
-func isDigit(ch rune) bool {
-	return isDecimal(ch) || ch >= utf8.RuneSelf && unicode.IsDigit(ch)
+	return 'a' <= lower(ch) && lower(ch) <= 'z' || ch == '_'
 }
 
 func (s *scanner) nextIsString(ch rune) bool {
 	// "u8" | "u" | "U" | "L"
-	if ch == 'u' && s.peekN(2) == `8"` {
+	if ch == 'u' && s.peekIs(`8"`) {
 		return true
 	}
 	switch ch {
@@ -305,7 +723,10 @@ func (s *scanner) nextIsString(ch rune) bool {
 }
 
 func (s *scanner) nextIsChar(ch rune) bool {
-	// [ "L" | "u" | "U" ] "'" c-char-sequence "'"
+	// [ "L" | "u" | "U" | "u8" ] "'" c-char-sequence "'"
+	if ch == 'u' && s.peekIs("8'") {
+		return true
+	}
 	switch ch {
 	case 'u', 'U', 'L':
 		return s.peek() == '\''
@@ -314,17 +735,17 @@ func (s *scanner) nextIsChar(ch rune) bool {
 }
 
 // 扫描字符串
-func (s *scanner) scanChar() string {
-	return s.scanQuote(errors.ErrScanUncloseChar, '\'')
+func (s *scanner) scanChar() {
+	s.scanQuote(errors.ErrScanUncloseChar, '\'')
 }
 
 // 扫描字符串
-func (s *scanner) scanString() string {
-	return s.scanQuote(errors.ErrScanUncloseString, '"')
+func (s *scanner) scanString() {
+	s.scanQuote(errors.ErrScanUncloseString, '"')
 }
 
 // 扫描字符串
-func (s *scanner) scanQuote(err errors.ErrCode, quote rune) string {
+func (s *scanner) scanQuote(err errors.ErrCode, quote rune) {
 	s.record()
 	for s.ch != quote {
 		s.next()
@@ -335,18 +756,15 @@ func (s *scanner) scanQuote(err errors.ErrCode, quote rune) string {
 			s.next()
 			s.scanEscape()
 		} else {
+			s.nextWhile(notQuote)
 			s.next()
 		}
-		if quote == '\'' {
-			break
-		}
 	}
 	if s.ch != quote {
 		s.markErr(err)
 	} else {
 		s.next()
 	}
-	return s.literal()
 }
 
 // 扫描字符串
@@ -402,6 +820,7 @@ func (s *scanner) skipComment() {
 	if s.ch == '/' {
 		s.next()
 		for s.ch != '\n' && s.ch >= 0 {
+			s.nextWhile(anyByte)
 			s.next()
 		}
 		return
@@ -409,15 +828,13 @@ func (s *scanner) skipComment() {
 
 	if s.ch == '*' {
 		s.next()
-		for {
+		for s.ch != '*' || s.peek() != '/' {
 			if s.ch < 0 {
 				s.markErr(errors.ErrScanUncloseComment)
 				return
 			}
+			s.nextWhile(notStar)
 			s.next()
-			if s.ch == '*' && s.peek() == '/' {
-				break
-			}
 		}
 		s.next() // *
 		s.next() // /
@@ -426,118 +843,103 @@ func (s *scanner) skipComment() {
 }
 
 func (s *scanner) nextIsNumber() bool {
-	if isDigit(s.ch) {
+	if isDecimal(s.ch) {
 		return true
 	}
-	if s.ch == '.' && isDigit(rune(s.peek())) {
+	if s.ch == '.' && isDecimal(rune(s.peek())) {
 		return true
 	}
 	return false
 }
 
-func (s *scanner) scanNumber() (token.Type, string) {
+// 扫描预处理数字，类型由字面量决定
+func (s *scanner) scanNumber() token.Type {
 	s.record()
-	s.next()
-	typ := token.INT
-
-	base := 10
-
-	switch s.ch {
-	case '0':
-		if lower(rune(s.peek())) == 'x' {
-			base = 16
-		} else {
-			base = 8
-		}
-	}
-
-	s.scanNumberBase(base)
-	if s.ch == '.' {
-		typ = token.FLOAT
-		s.next()
-		s.scanNumberBase(base)
+	// 0x 0b 前缀决定指数符号
+	prefix := rune(0)
+	if c := lower(rune(s.peek())); s.ch == '0' && (c == 'x' || c == 'b') {
+		prefix = c
 	}
-
-	if ch := lower(s.ch); ch == 'e' || ch == 'p' {
-		typ = token.FLOAT
-		s.next()
-		if s.ch == '+' || s.ch == '-' {
+	float := s.ch == '.'
+	s.next()
+	for {
+		ch := lower(s.ch)
+		exp := prefix == 0 && ch == 'e' || prefix == 'x' && ch == 'p'
+		switch {
+		case (ch == 'e' || ch == 'p') && (s.peek() == '+' || s.peek() == '-'):
 			s.next()
-		}
-		s.scanNumberBase(10)
-	}
-
-	if ch := lower(s.ch); ch == 'l' || ch == 'f' {
-		typ = token.FLOAT
-		s.next()
-	} else {
-		s.scanIntSuffix()
-	}
-
-	return typ, s.literal()
-}
-
-func (s *scanner) scanIntSuffix() {
-	// u
-	// ul
-	// ull
-	if lower(s.ch) == 'u' {
-		s.next()
-		n := 2
-		for n > 0 && lower(s.ch) == 'l' {
 			s.next()
-			n--
-		}
-	}
-
-	// ll l llu lu
-	if lower(s.ch) == 'l' {
-		if lower(s.ch) == 'l' {
+		case s.ch == '\'' && (isDecimal(rune(s.peek())) || isLetter(rune(s.peek()))):
 			s.next()
-		}
-		if lower(s.ch) == 'u' {
+		case s.isIdentChar(s.ch) || s.ch == '.':
 			s.next()
+		default:
+			if float && prefix != 'b' {
+				return token.FLOAT
+			}
+			return token.INT
 		}
+		float = float || exp || ch == '.'
 	}
-	return
 }
 
-func (s *scanner) scanNumberBase(base int) {
-	if base <= 10 {
-		for isDecimal(s.ch) {
-			s.next()
-		}
-	} else {
-		for isHex(s.ch) {
-			s.next()
-		}
-	}
-	return
+// 标点符号，值为替换双字符组后的符号
+var punctuators = map[string]string{
+	"...": "...", ".": ".", ",": ",", "?": "?", ":": ":", ";": ";",
+	"[": "[", "]": "]", "(": "(", ")": ")", "{": "{", "}": "}", "~": "~",
+	"->": "->", "--": "--", "-=": "-=", "-": "-",
+	"++": "++", "+=": "+=", "+": "+",
+	"&=": "&=", "&&": "&&", "&": "&",
+	"*=": "*=", "*": "*",
+	"!": "!", "!=": "!=",
+	"==": "==", "=": "=",
+	"^=": "^=", "^": "^",
+	"/=": "/=", "/": "/",
+	"%=": "%=", "%": "%",
+	"||": "||", "|=": "|=", "|": "|",
+	"<<=": "<<=", ">>=": ">>=", "<<": "<<", ">>": ">>", "<=": "<=", ">=": ">=", "<": "<", ">": ">",
+	"##": "##", "#": "#",
+	"<:": "[", ":>": "]", "<%": "{", "%>": "}", "%:": "#", "%:%:": "##",
 }
 
-var mp = map[string]string{
-	"<:":   "[",
-	":>":   "]",
-	"<%":   "{",
-	"%>":   "}",
-	"%:":   "#",
-	"%:%:": "##",
+// 按首字符分组的符号与规范写法，长的在前
+var punctuatorIndex [utf8.RuneSelf][][2]string
+
+func init() {
+	for p := range punctuators {
+		punctuatorIndex[p[0]] = append(punctuatorIndex[p[0]], [2]string{p, punctuators[p]})
+	}
+	for _, list := range punctuatorIndex {
+		sort.Slice(list, func(i, j int) bool { return len(list[i][0]) > len(list[j][0]) })
+	}
 }
 
 func toHalfWidthPunctuator(s string) (string, bool) {
-	r := make([]rune, len(s))
+	r := make([]rune, 0, len(s))
 	t := false
-	for i, v := range s {
-		if v >= 0xff01 && v <= 0xff5e {
-			v -= 0xff00 - 0x20
+	for _, v := range s {
+		if c, ok := halfWidth(v); ok {
+			v = c
 			t = true
 		}
-		r[i] = v
+		r = append(r, v)
 	}
 	return string(r), t
 }
 
 func (s *scanner) nextIsPunctuator() (string, int, bool, bool) {
+	if s.ch >= 0 && s.ch < utf8.RuneSelf && s.opt.Trigraph != TrigraphReplace {
+		// 直接从源码中匹配，包含全角字符时需要转换
+		end := s.rdOffset + len(s.peekBytes(3))
+		if buf := s.buf[s.offset:end]; !s.opt.PunctuatorFullWidthToHalfWidth || isASCII(buf) {
+			for _, p := range punctuatorIndex[buf[0]] {
+				if len(p[0]) <= len(buf) && string(buf[:len(p[0])]) == p[0] {
+					return p[1], len(p[0]), false, true
+				}
+			}
+			return "", 0, false, false
+		}
+	}
 	tok := s.peekCN(string(s.ch), 3)
 	trans := false
 	if s.opt.PunctuatorFullWidthToHalfWidth {
@@ -547,26 +949,18 @@ func (s *scanner) nextIsPunctuator() (string, int, bool, bool) {
 		}
 	}
 	for i := len(tok); i > 0; i-- {
-		switch ch := tok[:i]; ch {
-		case "...", ".", ",", "?", ":", ";",
-			"[", "]", "(", ")", "{", "}", "~",
-			"->", "--", "-=", "-",
-			"++", "+=", "+",
-			"&=", "&&", "&",
-			"*=", "*",
-			"!", "!=",
-			"==", "=",
-			"^=", "^",
-			"/=", "/",
-			"%=", "%:%:", "%:", "%",
-			"||", "|=", "|",
-			"<<=", ">>=", "<<", ">>", "<:", ":>", "<%", "%>", "<=", ">=", "<", ">",
-			"##", "#":
-			if v, ok := mp[ch]; ok {
-				return v, i, trans, true
-			}
-			return ch, i, trans, true
+		if v, ok := punctuators[tok[:i]]; ok {
+			return v, i, trans, true
 		}
 	}
 	return "", 0, trans, false
 }
+
+func isASCII(b []byte) bool {
+	for _, c := range b {
+		if c >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
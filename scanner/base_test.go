@@ -2,12 +2,15 @@ package scanner
 
 import (
 	"bytes"
+	"dxkite.cn/c/errors"
 	"dxkite.cn/c/token"
 	"encoding/json"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -57,21 +60,22 @@ func Test_scanner_next(t *testing.T) {
 		Lit rune
 		Pos token.Position
 	}{
-		{'a', token.Position{Filename: "", Line: 1, Column: 1}},
-		{'中', token.Position{Filename: "", Line: 1, Column: 2}},
-		{'♥', token.Position{Filename: "", Line: 1, Column: 3}},
-		{'\n', token.Position{Filename: "", Line: 1, Column: 4}},
-		{'\n', token.Position{Filename: "", Line: 2, Column: 1}},
-		{'a', token.Position{Filename: "", Line: 3, Column: 1}},
-		{'b', token.Position{Filename: "", Line: 4, Column: 1}},
-		{'\n', token.Position{Filename: "", Line: 4, Column: 2}},
-		{'a', token.Position{Filename: "", Line: 5, Column: 1}},
-		{'b', token.Position{Filename: "", Line: 6, Column: 1}},
+		{'a', token.Position{Filename: "", Line: 1, Column: 1, Offset: 0}},
+		{'中', token.Position{Filename: "", Line: 1, Column: 2, Offset: 1}},
+		{'♥', token.Position{Filename: "", Line: 1, Column: 3, Offset: 4}},
+		{'\n', token.Position{Filename: "", Line: 1, Column: 4, Offset: 7}},
+		{'\n', token.Position{Filename: "", Line: 2, Column: 1, Offset: 9}},
+		{'a', token.Position{Filename: "", Line: 3, Column: 1, Offset: 10}},
+		{'b', token.Position{Filename: "", Line: 4, Column: 1, Offset: 13}},
+		{'\n', token.Position{Filename: "", Line: 4, Column: 2, Offset: 14}},
+		{'a', token.Position{Filename: "", Line: 5, Column: 1, Offset: 15}},
+		{'b', token.Position{Filename: "", Line: 6, Column: 1, Offset: 19}},
 	}
 
 	for _, tt := range tests {
 		t.Run(string(tt.Lit), func(t *testing.T) {
-			ch, pos := s.next()
+			ch, pos := s.ch, s.curPos()
+			s.next()
 			if ch != tt.Lit {
 				t.Errorf("want %v got %v", tt.Lit, ch)
 			}
@@ -125,13 +129,13 @@ func Test_scanner_scanQuote(t *testing.T) {
 			"success", `'1' '\123' '\x12' '\u1234' '\U12345678'`, false,
 		},
 		{
-			"error", `'12'`, true,
+			"multi-char", `'12' u8'a'`, false,
 		},
 		{
 			"error", `'\u12'`, true,
 		},
 		{
-			"error", `'\1234'`, true,
+			"multi-char-escape", `'\1234'`, false,
 		},
 	}
 	for _, tt := range tests {
@@ -145,3 +149,422 @@ func Test_scanner_scanQuote(t *testing.T) {
 		})
 	}
 }
+
+func TestScanner_File(t *testing.T) {
+	fset := token.NewFileSet()
+	code := "int a;\r\nchar *s = \"中😀\";\n\tb\\\nc\n"
+	s := NewStringScan("file.c", code, &Option{FileSet: fset}).(FileScanner)
+	tks, err := ScanToken(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := s.File()
+	if f.Size() != len(code) || f.LineCount() != 5 {
+		t.Fatalf("size %d lines %d", f.Size(), f.LineCount())
+	}
+	for _, tok := range tks {
+		pos := tok.Position()
+		if got := f.Position(pos.Offset); got != pos {
+			t.Errorf("offset %d want %v got %+v", pos.Offset, pos, got)
+		}
+		if got := f.Offset(pos.Line, pos.Column); got != pos.Offset {
+			t.Errorf("position %v want offset %d got %d", pos, pos.Offset, got)
+		}
+		if got := fset.Position(f.Pos(pos.Offset)); got != pos {
+			t.Errorf("pos %v got %+v", pos, got)
+		}
+	}
+	// ; 在 UTF-16 编码下的列
+	semi := len("int a;\r\nchar *s = \"中😀\"")
+	if line, col := f.UTF16Position(semi); line != 2 || col != 16 {
+		t.Errorf("utf16 position want 2:16 got %d:%d", line, col)
+	}
+	if got := f.UTF16Offset(2, 16); got != semi {
+		t.Errorf("utf16 offset want %d got %d", semi, got)
+	}
+	if p := f.Pos(semi); fset.File(p) != f || p.Offset() != semi {
+		t.Errorf("pos %d", p)
+	}
+}
+
+func TestScanner_KeepComment(t *testing.T) {
+	code := "a // line\n/* block\n * text */b/**/c"
+	tks, err := ScanString("comment.c", code, &Option{KeepComment: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		Lit  string
+		Kind CommentKind
+		Pos  token.Position
+	}{
+		{"// line", LineComment, token.Position{Filename: "comment.c", Line: 1, Column: 3, Offset: 2}},
+		{"/* block\n * text */", BlockComment, token.Position{Filename: "comment.c", Line: 2, Column: 1, Offset: 10}},
+		{"/**/", BlockComment, token.Position{Filename: "comment.c", Line: 3, Column: 12, Offset: 30}},
+	}
+	var got []*CommentToken
+	for _, tok := range tks {
+		if v, ok := tok.(*CommentToken); ok {
+			got = append(got, v)
+		}
+	}
+	if len(got) != len(tests) {
+		t.Fatalf("want %d comments got %d", len(tests), len(got))
+	}
+	for i, tt := range tests {
+		if got[i].Typ != token.COMMENT || got[i].Lit != tt.Lit || got[i].Kind != tt.Kind || got[i].Pos != tt.Pos {
+			t.Errorf("want %q %v %v got %q %v %v", tt.Lit, tt.Kind, tt.Pos, got[i].Lit, got[i].Kind, got[i].Pos)
+		}
+	}
+	// 默认作为空白
+	tks, _ = ScanString("comment.c", code, nil)
+	for _, tok := range tks {
+		if tok.Type() == token.COMMENT {
+			t.Errorf("unexpected comment %v", tok)
+		}
+	}
+}
+
+func TestScanner_Trigraph(t *testing.T) {
+	code := "??=define A ??/\nx??(1??)\n\"??!\" ?? ???-"
+	var warns []string
+	opt := &Option{Trigraph: TrigraphReplace, ErrorHandler: func(pos token.Position, typ errors.ErrorType, code errors.ErrCode, params ...interface{}) {
+		if typ == errors.ErrTypeWarning {
+			warns = append(warns, errors.New(pos, code, params...).Error())
+		}
+	}}
+	tks, err := ScanString("trigraph.c", code, opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, tok := range tks {
+		if tok.Type() != token.WHITESPACE {
+			got = append(got, tok.Literal()+"@"+strconv.Itoa(tok.Position().Line)+":"+strconv.Itoa(tok.Position().Column))
+		}
+	}
+	want := []string{"#@1:1", "define@1:4", "A@1:11", "x@2:1", "[@2:2", "1@2:5", "]@2:6", "\n@2:9",
+		"\"|\"@3:1", "?@3:7", "?@3:8", "?@3:10", "~@3:11"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v got %v", want, got)
+	}
+	if len(warns) != 6 {
+		t.Errorf("want 6 warnings got %v", warns)
+	}
+
+	// 只警告不替换
+	warns = nil
+	opt.Trigraph = TrigraphWarn
+	tks, _ = ScanString("trigraph.c", code, opt)
+	if tks[0].Literal() != "?" || len(warns) != 6 {
+		t.Errorf("unexpected %v %v", tks[0], warns)
+	}
+	if len(warns) > 0 && warns[0] != "在 trigraph.c 文件的第1行1列: 忽略了三字符组 ??=，替换后为 #" {
+		t.Errorf("unexpected warning %s", warns[0])
+	}
+}
+
+func TestScanner_Encoding(t *testing.T) {
+	// "int 中文 = 1;" 的 GBK 编码
+	gbk := "int \xd6\xd0\xce\xc4 = 1;\n"
+	tests := []struct {
+		name string
+		code string
+		enc  Encoding
+		warn string
+	}{
+		{"utf8", "int 中文 = 1;\n", EncodingAuto, ""},
+		{"utf8-bom", "\xef\xbb\xbfint 中文 = 1;\n", EncodingAuto, ""},
+		{"utf16le-bom", "\xff\xfei\x00n\x00t\x00 \x00\x2d\x4e\x87\x65 \x00=\x00 \x001\x00;\x00\n\x00", EncodingAuto, ""},
+		{"utf16be", "\x00i\x00n\x00t\x00 \x4e\x2d\x65\x87\x00 \x00=\x00 \x001\x00;\x00\n", EncodingUTF16BE, ""},
+		{"gbk", gbk, EncodingGBK, ""},
+		{"gb18030", gbk, EncodingGB18030, ""},
+		{"fallback", gbk, EncodingAuto, "在 encoding.c 文件的第1行5列: 文件包含无效的 UTF-8 编码，之后的内容按 gb18030 编码读取"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var warns []string
+			opt := &Option{Encoding: tt.enc, ErrorHandler: func(pos token.Position, typ errors.ErrorType, code errors.ErrCode, params ...interface{}) {
+				warns = append(warns, errors.New(pos, code, params...).Error())
+			}}
+			tks, err := ScanString("encoding.c", tt.code, opt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, tok := range tks {
+				if tok.Type() != token.WHITESPACE && tok.Type() != token.NEWLINE {
+					pos := tok.Position()
+					got = append(got, tok.Literal()+"@"+strconv.Itoa(pos.Column)+":"+strconv.Itoa(pos.Offset))
+				}
+			}
+			want := []string{"int@1:0", "中文@5:4", "=@8:11", "1@10:13", ";@11:14"}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("want %v got %v", want, got)
+			}
+			if tt.warn == "" && len(warns) > 0 || tt.warn != "" && (len(warns) != 1 || warns[0] != tt.warn) {
+				t.Errorf("unexpected warnings %v", warns)
+			}
+		})
+	}
+	// 重置后清除切换编码的偏移
+	s := &scanner{fallbackAt: -1}
+	tr := &utf8Fallback{s: s}
+	if _, _, err := tr.Transform(make([]byte, 64), []byte(gbk), true); err != nil || s.fallbackAt != 4 {
+		t.Errorf("fallbackAt want 4 got %d %v", s.fallbackAt, err)
+	}
+	if tr.Reset(); s.fallbackAt != -1 {
+		t.Errorf("fallbackAt after Reset want -1 got %d", s.fallbackAt)
+	}
+	if e, ok := LookupEncoding("GBK"); !ok || e != EncodingGBK {
+		t.Errorf("LookupEncoding(GBK) = %v %v", e, ok)
+	}
+}
+
+func TestScanner_ScanCompact(t *testing.T) {
+	code := "int ab\\\ncd = \"x\"; /* c */"
+	s := NewStringScan("compact.c", code, nil).(CompactScanner)
+	var got []string
+	for tok := s.ScanCompact(); tok.Typ != token.EOF; tok = s.ScanCompact() {
+		got = append(got, tok.Typ.String()+":"+tok.Text(s.File()))
+	}
+	want := []string{"KEYWORD:int", "WHITESPACE: ", "IDENT:ab\\\ncd", "WHITESPACE: ", "PUNCTUATOR:=", "WHITESPACE: ",
+		"STRING:\"x\"", "PUNCTUATOR:;", "WHITESPACE: ", "WHITESPACE:/* c */"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %q got %q", want, got)
+	}
+	// 续行符不属于字面量
+	tks, _ := ScanString("compact.c", code, nil)
+	if tks[2].Literal() != "abcd" {
+		t.Errorf("want abcd got %q", tks[2].Literal())
+	}
+}
+
+// 跨越多个读取缓冲与批量分配的输入，两种扫描方式的结果一致
+func TestScanner_LargeInput(t *testing.T) {
+	code := benchSource(64 << 10)
+	s := NewStringScan("large.c", code, nil)
+	cs := NewStringScan("large.c", code, nil).(CompactScanner)
+	for {
+		tok, c := s.Scan(), cs.ScanCompact()
+		pos := tok.Position()
+		if tok.Type() != c.Typ || pos.Offset != int(c.Offset) {
+			t.Fatalf("want %s@%d got %s", c.Typ, c.Offset, token.String(tok))
+		}
+		if tok.Type() != token.WHITESPACE && tok.Literal() != c.Text(cs.File()) {
+			t.Fatalf("want %q got %s", c.Text(cs.File()), token.String(tok))
+		}
+		if want := cs.File().Position(pos.Offset); pos.Line != want.Line || pos.Column != want.Column {
+			t.Fatalf("want %d:%d got %s", want.Line, want.Column, token.String(tok))
+		}
+		if tok.Type() == token.EOF {
+			break
+		}
+	}
+}
+
+func TestScanner_Identifier(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		std  Standard
+		want []string
+		warn []errors.ErrCode
+		err  errors.ErrCode
+	}{
+		{"ascii", "abc _a1", StdDefault, []string{"abc", "_a1"}, nil, 0},
+		{"han", "变量 = 值", StdDefault, []string{"变量", "=", "值"}, nil, 0},
+		{"ucn", `caf\u00e9 caf\U000000E9 café`, StdDefault, []string{"café", "café", "café"}, nil, 0},
+		{"nfc", "cafe\\u0301 cafe\xcc\x81", StdC23, []string{"café", "café"}, nil, 0},
+		{"mark-start", `\u0301a`, StdC11, nil, nil, errors.ErrScanIdentUCN},
+		{"mark-start-c23", "\xcc\x81a", StdC23, []string{"\xcc\x81", "a"}, nil, 0},
+		{"full-width", "a（b）", StdC11, []string{"a", "（", "b", "）"}, nil, 0},
+		{"digit", "a١ ١", StdC23, []string{"a١", "١"}, []errors.ErrCode{errors.ErrScanIdentMixedScript}, 0},
+		{"ucn-invalid", `a\u0041`, StdDefault, nil, nil, errors.ErrScanIdentUCN},
+		{"confusable", "раypal", StdDefault, []string{"раypal"}, []errors.ErrCode{errors.ErrScanIdentConfusable}, 0},
+		{"fullwidth-letter", "ａｂｃ ａｂｃ", StdC23, []string{"ａｂｃ", "ａｂｃ"}, []errors.ErrCode{errors.ErrScanIdentConfusable}, 0},
+		{"mixed-script", "数据αβγ", StdDefault, []string{"数据αβγ"}, []errors.ErrCode{errors.ErrScanIdentMixedScript}, 0},
+		{"latin-han", "get数据", StdDefault, []string{"get数据"}, nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var warns []errors.ErrCode
+			opt := &Option{Std: tt.std, ErrorHandler: func(pos token.Position, typ errors.ErrorType, code errors.ErrCode, params ...interface{}) {
+				warns = append(warns, code)
+			}}
+			tks, err := ScanString("ident.c", tt.code, opt)
+			if tt.err != 0 {
+				if e, ok := err.(*errors.Error); !ok || e.Code != tt.err {
+					t.Fatalf("want error %v got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, tok := range tks {
+				if tok.Type() != token.WHITESPACE {
+					got = append(got, tok.Literal())
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("want %q got %q", tt.want, got)
+			}
+			if !reflect.DeepEqual(warns, tt.warn) {
+				t.Errorf("want warnings %v got %v", tt.warn, warns)
+			}
+		})
+	}
+}
+
+func TestScanner_Keywords(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		std     Standard
+		dialect Dialect
+		want    []string
+	}{
+		{"c89", "inline int _Bool", StdC89, DialectStd, []string{"IDENT:inline", "KEYWORD:int", "IDENT:_Bool"}},
+		{"c99", "inline _Bool _Atomic", StdC99, DialectStd, []string{"KEYWORD:inline", "KEYWORD:_Bool", "IDENT:_Atomic"}},
+		{"c11", "_Atomic _Generic bool", StdDefault, DialectStd, []string{"KEYWORD:_Atomic", "KEYWORD:_Generic", "IDENT:bool"}},
+		{"c23", "bool true nullptr alignof", StdC23, DialectStd, []string{"KEYWORD:_Bool=bool", "KEYWORD:true", "KEYWORD:nullptr", "KEYWORD:_Alignof=alignof"}},
+		{"std-gnu-words", "__inline__ asm", StdC11, DialectStd, []string{"IDENT:__inline__", "IDENT:asm"}},
+		{"gnu", "__inline__ __restrict __const__ __asm__ __alignof__", StdC11, DialectGNU, []string{
+			"KEYWORD:inline=__inline__", "KEYWORD:restrict=__restrict", "KEYWORD:const=__const__",
+			"KEYWORD:asm=__asm__", "KEYWORD:_Alignof=__alignof__",
+		}},
+		{"gnu89", "inline __typeof__ __attribute__", StdC89, DialectGNU, []string{"KEYWORD:inline", "KEYWORD:typeof=__typeof__", "KEYWORD:__attribute__"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tks, err := ScanString("keyword.c", tt.code, &Option{Std: tt.std, Dialect: tt.dialect})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, tok := range tks {
+				if tok.Type() == token.WHITESPACE {
+					continue
+				}
+				v := tok.Type().String() + ":" + tok.Literal()
+				if kw, ok := tok.(*KeywordToken); ok {
+					v += "=" + kw.Spelling
+				}
+				got = append(got, v)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("want %q got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestScanner_FullWidth(t *testing.T) {
+	code := "int\u3000a＝１；\nputs(“中文”)； // ；\nb【0】＋＋。c;"
+	type warn struct {
+		line, col int
+		old, new  string
+	}
+	var warns []warn
+	opt := &Option{PunctuatorFullWidthToHalfWidth: true, WarnFullWidth: true, ErrorHandler: func(pos token.Position, typ errors.ErrorType, code errors.ErrCode, params ...interface{}) {
+		if code == errors.ErrScanFullWidth {
+			warns = append(warns, warn{pos.Line, pos.Column, params[0].(string), params[1].(string)})
+		}
+	}}
+	tks, err := ScanString("full.c", code, opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, tok := range tks {
+		if tok.Type() != token.WHITESPACE && tok.Type() != token.NEWLINE {
+			got = append(got, tok.Literal())
+		}
+	}
+	want := []string{"int", "a", "=", "１", ";", "puts", "(", "“", "中文", "”", ")", ";", "b", "[", "0", "]", "++", ".", "c", ";"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %q got %q", want, got)
+	}
+	wantWarns := []warn{
+		{1, 4, "\u3000", " "}, {1, 6, "＝", "="}, {1, 8, "；", ";"},
+		{2, 6, "“", `"`}, {2, 9, "”", `"`}, {2, 11, "；", ";"},
+		{3, 2, "【", "["}, {3, 4, "】", "]"}, {3, 5, "＋", "+"}, {3, 6, "＋", "+"}, {3, 7, "。", "."},
+	}
+	if !reflect.DeepEqual(warns, wantWarns) {
+		t.Errorf("want warnings %v got %v", wantWarns, warns)
+	}
+}
+
+func TestScanFixIts(t *testing.T) {
+	code := "printf（“%d\\n”，　a）；/* ，*/ s = \"；\";\n"
+	fixes, src := ScanFixIts("fix.c", strings.NewReader(code), nil)
+	if string(src) != code {
+		t.Fatalf("want source %q got %q", code, src)
+	}
+	want := "printf(\"%d\\n\", a);/* ，*/ s = \"；\";\n"
+	if got := string(ApplyFixIts(src, fixes)); got != want {
+		t.Errorf("want %q got %q", want, got)
+	}
+	// 中文引号之间的内容是字符串，不修复
+	code = "printf（“hi，there”）；c = ‘，’；x = “a，b；\n"
+	fixes, src = ScanFixIts("fix.c", strings.NewReader(code), nil)
+	want = "printf(\"hi，there\");c = '，';x = \"a,b;\n"
+	if got := string(ApplyFixIts(src, fixes)); got != want {
+		t.Errorf("want %q got %q", want, got)
+	}
+}
+
+// 生成约 size 字节的测试代码
+func benchSource(size int) string {
+	const unit = `/* 计算校验和
+ * 包含较长的注释与字符串 */
+static unsigned int checksum(const unsigned char *data, unsigned long length) {
+    unsigned int sum = 0x1234u; // 初始值
+    for (unsigned long i = 0; i < length; i++) {
+        sum = (sum << 5) + sum + data[i] * 31 - 'a';
+    }
+    printf("checksum of %lu bytes is %08x, this string is long enough to matter\n", length, sum);
+    return sum >= 1.5e3 ? sum : ~sum;
+}
+`
+	var b strings.Builder
+	for b.Len() < size {
+		b.WriteString(unit)
+	}
+	return b.String()
+}
+
+// 4 MB 输入下，改写前的 Scan 约 10 MB/s、每次 660 万次分配；
+// 改写后 Scan 约 23~28 MB/s、2.4 千次分配，ScanCompact 约 37 MB/s，未达到 5 倍
+func benchmarkScan(b *testing.B, code string, scan func(s Scanner)) {
+	b.SetBytes(int64(len(code)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		scan(NewStringScan("bench.c", code, nil))
+	}
+}
+
+func BenchmarkScanner_Scan(b *testing.B) {
+	benchmarkScan(b, benchSource(4<<20), func(s Scanner) {
+		for s.Scan().Type() != token.EOF {
+		}
+	})
+}
+
+func BenchmarkScanner_ScanCompact(b *testing.B) {
+	benchmarkScan(b, benchSource(4<<20), func(s Scanner) {
+		cs := s.(CompactScanner)
+		for cs.ScanCompact().Typ != token.EOF {
+		}
+	})
+}
+
+func BenchmarkScanner_LongLiteral(b *testing.B) {
+	code := "/*" + strings.Repeat("comment ", 256<<10) + "*/\n\"" + strings.Repeat("string ", 256<<10) + "\"\n"
+	benchmarkScan(b, code, func(s Scanner) {
+		for s.Scan().Type() != token.EOF {
+		}
+	})
+}
@@ -0,0 +1,162 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"dxkite.cn/c/errors"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// 源码编码
+type Encoding int
+
+const (
+	EncodingAuto    Encoding = iota // 根据 BOM 检测，默认 UTF-8，遇到无效的 UTF-8 时按 GB18030 读取
+	EncodingUTF8                    // UTF-8
+	EncodingGBK                     // GBK
+	EncodingGB18030                 // GB18030
+	EncodingLatin1                  // ISO-8859-1
+	EncodingUTF16LE                 // UTF-16 小端，BOM 优先
+	EncodingUTF16BE                 // UTF-16 大端，BOM 优先
+)
+
+var encodingNames = map[Encoding]string{
+	EncodingAuto:    "auto",
+	EncodingUTF8:    "utf-8",
+	EncodingGBK:     "gbk",
+	EncodingGB18030: "gb18030",
+	EncodingLatin1:  "latin-1",
+	EncodingUTF16LE: "utf-16le",
+	EncodingUTF16BE: "utf-16be",
+}
+
+// 编码名称的别名
+var encodingAlias = map[string]Encoding{
+	"utf8":       EncodingUTF8,
+	"cp936":      EncodingGBK,
+	"latin1":     EncodingLatin1,
+	"iso-8859-1": EncodingLatin1,
+	"utf-16":     EncodingUTF16LE,
+	"utf16":      EncodingUTF16LE,
+	"utf16le":    EncodingUTF16LE,
+	"utf16be":    EncodingUTF16BE,
+}
+
+// 自动检测时无效 UTF-8 使用的编码
+const fallbackEncoding = EncodingGB18030
+
+func (e Encoding) String() string {
+	if name, ok := encodingNames[e]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// 根据名称查找编码，不区分大小写
+func LookupEncoding(name string) (Encoding, bool) {
+	name = strings.ToLower(name)
+	for e, n := range encodingNames {
+		if n == name {
+			return e, true
+		}
+	}
+	e, ok := encodingAlias[name]
+	return e, ok
+}
+
+func (e Encoding) encoding() encoding.Encoding {
+	switch e {
+	case EncodingGBK:
+		return simplifiedchinese.GBK
+	case EncodingGB18030:
+		return simplifiedchinese.GB18030
+	case EncodingLatin1:
+		return charmap.ISO8859_1
+	case EncodingUTF16LE:
+		return unicode.UTF16(unicode.LittleEndian, unicode.UseBOM)
+	case EncodingUTF16BE:
+		return unicode.UTF16(unicode.BigEndian, unicode.UseBOM)
+	}
+	return unicode.UTF8BOM
+}
+
+// 将源码转换为 UTF-8，位置均按转换后的文本计算
+func (s *scanner) decode(r io.Reader) io.Reader {
+	if s.opt.Encoding != EncodingAuto {
+		return transform.NewReader(r, s.opt.Encoding.encoding().NewDecoder())
+	}
+	br := bufio.NewReader(r)
+	bom, _ := br.Peek(3)
+	switch {
+	case bytes.HasPrefix(bom, []byte{0xef, 0xbb, 0xbf}):
+		_, _ = br.Discard(3)
+	case bytes.HasPrefix(bom, []byte{0xff, 0xfe}):
+		return transform.NewReader(br, EncodingUTF16LE.encoding().NewDecoder())
+	case bytes.HasPrefix(bom, []byte{0xfe, 0xff}):
+		return transform.NewReader(br, EncodingUTF16BE.encoding().NewDecoder())
+	}
+	return transform.NewReader(br, &utf8Fallback{s: s})
+}
+
+// 检查 UTF-8，遇到无效字节后剩余部分按 fallbackEncoding 转换
+type utf8Fallback struct {
+	s        *scanner
+	fallback transform.Transformer
+	offset   int
+}
+
+func (t *utf8Fallback) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	n := 0
+	for t.fallback == nil && n < len(src) {
+		if src[n] < utf8.RuneSelf {
+			if n >= len(dst) {
+				err = transform.ErrShortDst
+				break
+			}
+			n++
+			continue
+		}
+		if !atEOF && !utf8.FullRune(src[n:]) {
+			err = transform.ErrShortSrc
+			break
+		}
+		r, w := utf8.DecodeRune(src[n:])
+		if r == utf8.RuneError && w == 1 {
+			t.fallback = fallbackEncoding.encoding().NewDecoder()
+			t.s.fallbackAt = t.offset + n
+			break
+		}
+		if n+w > len(dst) {
+			err = transform.ErrShortDst
+			break
+		}
+		n += w
+	}
+	copy(dst, src[:n])
+	t.offset += n
+	if t.fallback == nil {
+		return n, n, err
+	}
+	nDst, nSrc, err = t.fallback.Transform(dst[n:], src[n:], atEOF)
+	return n + nDst, n + nSrc, err
+}
+
+func (t *utf8Fallback) Reset() {
+	t.fallback = nil
+	t.offset = 0
+	t.s.fallbackAt = -1
+}
+
+// 报告切换编码
+func (s *scanner) fallback() {
+	if s.opt.ErrorHandler != nil {
+		s.opt.ErrorHandler(s.curPos(), errors.ErrTypeWarning, errors.ErrScanInvalidUTF8, fallbackEncoding.String())
+	}
+}
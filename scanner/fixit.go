@@ -0,0 +1,122 @@
+package scanner
+
+import (
+	"dxkite.cn/c/errors"
+	"dxkite.cn/c/token"
+	"io"
+	"sort"
+	"unicode/utf8"
+)
+
+// 输入法容易输入的中文符号与对应的半角字符
+var imeChars = map[rune]rune{
+	'\u3000': ' ', // 全角空格
+	'“':      '"',
+	'”':      '"',
+	'‘':      '\'',
+	'’':      '\'',
+	'。':      '.',
+	'、':      ',',
+	'【':      '[',
+	'】':      ']',
+	'《':      '<',
+	'》':      '>',
+}
+
+// 全角符号对应的半角字符，全角字母与数字不处理
+func halfWidth(ch rune) (rune, bool) {
+	if ch >= 0xff01 && ch <= 0xff5e {
+		c := ch - (0xff00 - 0x20)
+		if c == '_' || !isLetter(c) && !isDecimal(c) {
+			return c, true
+		}
+		return 0, false
+	}
+	c, ok := imeChars[ch]
+	return c, ok
+}
+
+// 是否为全角符号或输入法输入的中文符号
+func isFullWidthPunct(ch rune) bool {
+	_, ok := halfWidth(ch)
+	return ok
+}
+
+// 报告全角字符，给出替换的半角字符
+func (s *scanner) warnFullWidth() {
+	if !s.opt.WarnFullWidth || s.opt.ErrorHandler == nil || s.ch < utf8.RuneSelf {
+		return
+	}
+	if c, ok := halfWidth(s.ch); ok {
+		s.opt.ErrorHandler(s.curPos(), errors.ErrTypeWarning, errors.ErrScanFullWidth, string(s.ch), string(c))
+	}
+}
+
+// 修复建议，将 Pos.Offset 开始的 Old 替换为 New
+type FixIt struct {
+	Pos token.Position
+	Old string
+	New string
+}
+
+// 扫描代码中的全角符号与输入法错误，返回修复建议与转码后的源码
+// 字符串、字符与注释中的字符不处理，中文引号之间的内容修复后会成为字符串，也不处理
+func ScanFixIts(filename string, r io.Reader, opt *Option) ([]FixIt, []byte) {
+	o := Option{}
+	if opt != nil {
+		o = *opt
+	}
+	var fixes []FixIt
+	o.WarnFullWidth = true
+	o.ErrorHandler = func(pos token.Position, typ errors.ErrorType, code errors.ErrCode, params ...interface{}) {
+		if code == errors.ErrScanFullWidth {
+			fixes = append(fixes, FixIt{Pos: pos, Old: params[0].(string), New: params[1].(string)})
+		} else if opt != nil && opt.ErrorHandler != nil {
+			opt.ErrorHandler(pos, typ, code, params...)
+		}
+	}
+	s := NewScan(filename, r, &o)
+	for s.Scan().Type() != token.EOF {
+	}
+	return skipQuoted(fixes), s.(CompactScanner).File().Content()
+}
+
+// 中文引号对应的右引号
+var closeQuotes = map[string]string{"“": "”", "‘": "’"}
+
+// 去掉同一行中文引号之间的修复建议，引号本身仍然修复
+func skipQuoted(fixes []FixIt) []FixIt {
+	r := make([]FixIt, 0, len(fixes))
+	for i := 0; i < len(fixes); i++ {
+		r = append(r, fixes[i])
+		closing, ok := closeQuotes[fixes[i].Old]
+		if !ok {
+			continue
+		}
+		for j := i + 1; j < len(fixes) && fixes[j].Pos.Line == fixes[i].Pos.Line; j++ {
+			if fixes[j].Old == closing {
+				r = append(r, fixes[j])
+				i = j
+				break
+			}
+		}
+	}
+	return r
+}
+
+// 应用修复建议，返回修改后的源码
+func ApplyFixIts(src []byte, fixes []FixIt) []byte {
+	fixes = append([]FixIt(nil), fixes...)
+	sort.Slice(fixes, func(i, j int) bool { return fixes[i].Pos.Offset < fixes[j].Pos.Offset })
+	b := make([]byte, 0, len(src))
+	last := 0
+	for _, f := range fixes {
+		if f.Pos.Offset < last || f.Pos.Offset+len(f.Old) > len(src) || string(src[f.Pos.Offset:f.Pos.Offset+len(f.Old)]) != f.Old {
+			continue
+		}
+		b = append(b, src[last:f.Pos.Offset]...)
+		b = append(b, f.New...)
+		last = f.Pos.Offset + len(f.Old)
+	}
+	return append(b, src[last:]...)
+}
@@ -0,0 +1,265 @@
+package scanner
+
+import (
+	"dxkite.cn/c/errors"
+	"dxkite.cn/c/token"
+	"golang.org/x/text/unicode/norm"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// C11 附录 D.1 允许出现在标识符中的字符
+var annexDAllowed = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x00a8, 0x00a8, 1}, {0x00aa, 0x00aa, 1}, {0x00ad, 0x00ad, 1}, {0x00af, 0x00af, 1},
+		{0x00b2, 0x00b5, 1}, {0x00b7, 0x00ba, 1}, {0x00bc, 0x00be, 1}, {0x00c0, 0x00d6, 1},
+		{0x00d8, 0x00f6, 1}, {0x00f8, 0x00ff, 1}, {0x0100, 0x167f, 1}, {0x1681, 0x180d, 1},
+		{0x180f, 0x1fff, 1}, {0x200b, 0x200d, 1}, {0x202a, 0x202e, 1}, {0x203f, 0x2040, 1},
+		{0x2054, 0x2054, 1}, {0x2060, 0x206f, 1}, {0x2070, 0x218f, 1}, {0x2460, 0x24ff, 1},
+		{0x2776, 0x2793, 1}, {0x2c00, 0x2dff, 1}, {0x2e80, 0x2fff, 1}, {0x3004, 0x3007, 1},
+		{0x3021, 0x302f, 1}, {0x3031, 0x303f, 1}, {0x3040, 0xd7ff, 1}, {0xf900, 0xfd3d, 1},
+		{0xfd40, 0xfdcf, 1}, {0xfdf0, 0xfe44, 1}, {0xfe47, 0xfffd, 1},
+	},
+	R32: []unicode.Range32{
+		{0x10000, 0x1fffd, 1}, {0x20000, 0x2fffd, 1}, {0x30000, 0x3fffd, 1}, {0x40000, 0x4fffd, 1},
+		{0x50000, 0x5fffd, 1}, {0x60000, 0x6fffd, 1}, {0x70000, 0x7fffd, 1}, {0x80000, 0x8fffd, 1},
+		{0x90000, 0x9fffd, 1}, {0xa0000, 0xafffd, 1}, {0xb0000, 0xbfffd, 1}, {0xc0000, 0xcfffd, 1},
+		{0xd0000, 0xdfffd, 1}, {0xe0000, 0xefffd, 1},
+	},
+}
+
+// C11 附录 D.2 不能作为标识符首字符的字符
+var annexDNotStart = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x0300, 0x036f, 1}, {0x1dc0, 0x1dff, 1}, {0x20d0, 0x20ff, 1}, {0xfe20, 0xfe2f, 1},
+	},
+}
+
+// ID_Start 中不满足 NFKC 封闭的字符，不属于 XID_Start
+var xidStartExclude = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x037a, 0x037a, 1}, {0x0e33, 0x0e33, 1}, {0x0eb3, 0x0eb3, 1}, {0x309b, 0x309c, 1},
+		{0xfc5e, 0xfc63, 1}, {0xfdfa, 0xfdfb, 1}, {0xfe70, 0xfe7e, 2}, {0xff9e, 0xff9f, 1},
+	},
+}
+
+// ID_Continue 中不满足 NFKC 封闭的字符，不属于 XID_Continue
+var xidContinueExclude = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x037a, 0x037a, 1}, {0x309b, 0x309c, 1}, {0xfc5e, 0xfc63, 1}, {0xfdfa, 0xfdfb, 1},
+		{0xfe70, 0xfe7e, 2},
+	},
+}
+
+func isXIDStart(ch rune) bool {
+	return (unicode.IsLetter(ch) || unicode.In(ch, unicode.Nl, unicode.Other_ID_Start)) &&
+		!unicode.In(ch, unicode.Pattern_Syntax, unicode.Pattern_White_Space, xidStartExclude)
+}
+
+func isXIDContinue(ch rune) bool {
+	return (unicode.IsLetter(ch) || unicode.In(ch, unicode.Nl, unicode.Other_ID_Start,
+		unicode.Mn, unicode.Mc, unicode.Nd, unicode.Pc, unicode.Other_ID_Continue)) &&
+		!unicode.In(ch, unicode.Pattern_Syntax, unicode.Pattern_White_Space, xidContinueExclude)
+}
+
+// 扩展字符能否出现在标识符中，first 表示是否为首字符
+// C23 使用 UAX #31 的 XID_Start/XID_Continue，之前的标准使用 C11 附录 D，
+// 附录 D 包含了全角标点与全角运算符等符号，这些符号不作为标识符处理
+func (s *scanner) isIdentExt(ch rune, first bool) bool {
+	if s.opt.Std.Resolve() >= StdC23 {
+		if first {
+			return isXIDStart(ch)
+		}
+		return isXIDContinue(ch)
+	}
+	if !unicode.Is(annexDAllowed, ch) || unicode.IsPunct(ch) || unicode.IsSpace(ch) || isFullWidthPunct(ch) {
+		return false
+	}
+	return !first || !unicode.Is(annexDNotStart, ch)
+}
+
+// 标识符首字符
+func (s *scanner) isIdentStart(ch rune) bool {
+	if ch < utf8.RuneSelf {
+		return isLetter(ch)
+	}
+	return s.isIdentExt(ch, true)
+}
+
+// 标识符后续字符
+func (s *scanner) isIdentChar(ch rune) bool {
+	if ch < utf8.RuneSelf {
+		return isLetter(ch) || isDecimal(ch)
+	}
+	return s.isIdentExt(ch, false)
+}
+
+// 当前位置是否为通用字符名
+func (s *scanner) isUCN() bool {
+	_, n := s.ucn()
+	return n > 0
+}
+
+// 当前位置的通用字符名 \uXXXX \UXXXXXXXX，返回字符与长度，不是通用字符名时长度为 0
+func (s *scanner) ucn() (rune, int) {
+	if s.ch != '\\' {
+		return 0, 0
+	}
+	n := 4
+	switch s.peek() {
+	case 'u':
+	case 'U':
+		n = 8
+	default:
+		return 0, 0
+	}
+	b := s.peekBytes(n + 1)
+	if len(b) != n+1 {
+		return 0, 0
+	}
+	var r rune
+	for _, c := range b[1:] {
+		if !isHex(rune(c)) {
+			return 0, 0
+		}
+		r = r<<4 | rune(digitVal(c))
+	}
+	return r, n + 2
+}
+
+func digitVal(c byte) int {
+	if isDecimal(rune(c)) {
+		return int(c - '0')
+	}
+	return int(lower(rune(c)) - 'a' + 10)
+}
+
+// 扫描标识符中的通用字符名
+func (s *scanner) scanIdentUCN(first bool) {
+	r, n := s.ucn()
+	offset := s.offset
+	esc := string(s.buf[offset : offset+n])
+	if !utf8.ValidRune(r) || r < 0xa0 || !s.isIdentExt(r, first) {
+		s.markErr(errors.ErrScanIdentUCN, esc)
+		r = utf8.RuneError
+	}
+	s.rcd = false
+	for i := 0; i < n; i++ {
+		s.next()
+	}
+	s.rcd = true
+	s.add(r, offset, n)
+	s.ext = true
+}
+
+// 扩展标识符规范化为 NFC，并检查容易混淆的字符
+func (s *scanner) normIdent(b []byte, line, col, offset int) []byte {
+	if !norm.NFC.IsNormal(b) {
+		b = norm.NFC.Append(nil, b...)
+	}
+	// 同一个标识符只检查一次
+	if _, ok := s.names[string(b)]; ok || s.opt.ErrorHandler == nil {
+		return b
+	}
+	id := string(b)
+	pos := token.Position{Filename: s.filename, Line: line, Column: col, Offset: offset}
+	if sk := skeleton(id); sk != id && isASCIIString(sk) {
+		s.opt.ErrorHandler(pos, errors.ErrTypeWarning, errors.ErrScanIdentConfusable, id, sk)
+	} else if scripts := identScripts(id); !isScriptMixAllowed(scripts) {
+		s.opt.ErrorHandler(pos, errors.ErrTypeWarning, errors.ErrScanIdentMixedScript, id, strings.Join(scripts, "、"))
+	}
+	return b
+}
+
+// 常见的与拉丁字母形似的字符
+var confusables = map[rune]rune{
+	// 西里尔字母
+	'а': 'a', 'с': 'c', 'ԁ': 'd', 'е': 'e', 'һ': 'h', 'і': 'i', 'ј': 'j', 'о': 'o', 'р': 'p', 'ԛ': 'q',
+	'ѕ': 's', 'ԝ': 'w', 'х': 'x', 'у': 'y', 'А': 'A', 'В': 'B', 'С': 'C', 'Е': 'E', 'Н': 'H',
+	'І': 'I', 'Ј': 'J', 'К': 'K', 'М': 'M', 'О': 'O', 'Р': 'P', 'Ѕ': 'S', 'Т': 'T', 'Х': 'X', 'Ү': 'Y',
+	// 希腊字母
+	'ο': 'o', 'ν': 'v', 'Α': 'A', 'Β': 'B', 'Ε': 'E', 'Ζ': 'Z', 'Η': 'H', 'Ι': 'I', 'Κ': 'K',
+	'Μ': 'M', 'Ν': 'N', 'Ο': 'O', 'Ρ': 'P', 'Τ': 'T', 'Υ': 'Y', 'Χ': 'X',
+}
+
+// 标识符的骨架，形似的字符替换为拉丁字母
+func skeleton(id string) string {
+	return strings.Map(func(r rune) rune {
+		if v, ok := confusables[r]; ok {
+			return v
+		}
+		return r
+	}, norm.NFKC.String(id))
+}
+
+func isASCIIString(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// 常用的文字，优先检查
+var commonScripts = []string{"Latin", "Han", "Hiragana", "Katakana", "Hangul", "Bopomofo", "Greek", "Cyrillic"}
+
+// 字符所属的文字
+func scriptOf(r rune) string {
+	for _, name := range commonScripts {
+		if unicode.Is(unicode.Scripts[name], r) {
+			return name
+		}
+	}
+	for name, table := range unicode.Scripts {
+		if unicode.Is(table, r) {
+			return name
+		}
+	}
+	return ""
+}
+
+// 标识符使用的文字，不包含通用与继承文字
+func identScripts(id string) []string {
+	var scripts []string
+	for _, r := range id {
+		name := scriptOf(r)
+		if name == "" || name == "Common" || name == "Inherited" {
+			continue
+		}
+		if i := sort.SearchStrings(scripts, name); i == len(scripts) || scripts[i] != name {
+			scripts = append(scripts, "")
+			copy(scripts[i+1:], scripts[i:])
+			scripts[i] = name
+		}
+	}
+	return scripts
+}
+
+// 允许混合使用的文字，参考 UTS #39 的高度限制级别
+var scriptMixes = [][]string{
+	{"Han", "Hiragana", "Katakana", "Latin"},
+	{"Bopomofo", "Han", "Latin"},
+	{"Han", "Hangul", "Latin"},
+}
+
+func isScriptMixAllowed(scripts []string) bool {
+	if len(scripts) <= 1 {
+		return true
+	}
+	for _, mix := range scriptMixes {
+		ok := true
+		for _, name := range scripts {
+			if i := sort.SearchStrings(mix, name); i == len(mix) || mix[i] != name {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+package scanner
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// 语言方言
+type Dialect int
+
+const (
+	DialectStd Dialect = iota // 标准 C
+	DialectGNU                // GNU 扩展
+)
+
+var dialectNames = map[Dialect]string{
+	DialectStd: "std",
+	DialectGNU: "gnu",
+}
+
+func (d Dialect) String() string {
+	if name, ok := dialectNames[d]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// 根据名称查找方言，不区分大小写
+func LookupDialect(name string) (Dialect, bool) {
+	name = strings.ToLower(name)
+	for d, n := range dialectNames {
+		if n == name {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// 关键字，别名对应规范写法
+type keyword struct {
+	std       Standard
+	spelling  string
+	canonical string
+}
+
+// 各标准引入的关键字
+var stdKeywords = []keyword{
+	{StdC89, "auto", ""}, {StdC89, "break", ""}, {StdC89, "case", ""}, {StdC89, "char", ""},
+	{StdC89, "const", ""}, {StdC89, "continue", ""}, {StdC89, "default", ""}, {StdC89, "do", ""},
+	{StdC89, "double", ""}, {StdC89, "else", ""}, {StdC89, "enum", ""}, {StdC89, "extern", ""},
+	{StdC89, "float", ""}, {StdC89, "for", ""}, {StdC89, "goto", ""}, {StdC89, "if", ""},
+	{StdC89, "int", ""}, {StdC89, "long", ""}, {StdC89, "register", ""}, {StdC89, "return", ""},
+	{StdC89, "short", ""}, {StdC89, "signed", ""}, {StdC89, "sizeof", ""}, {StdC89, "static", ""},
+	{StdC89, "struct", ""}, {StdC89, "switch", ""}, {StdC89, "typedef", ""}, {StdC89, "union", ""},
+	{StdC89, "unsigned", ""}, {StdC89, "void", ""}, {StdC89, "volatile", ""}, {StdC89, "while", ""},
+
+	{StdC99, "inline", ""}, {StdC99, "restrict", ""}, {StdC99, "_Bool", ""}, {StdC99, "_Complex", ""},
+	{StdC99, "_Imaginary", ""},
+
+	{StdC11, "_Alignas", ""}, {StdC11, "_Alignof", ""}, {StdC11, "_Atomic", ""}, {StdC11, "_Generic", ""},
+	{StdC11, "_Noreturn", ""}, {StdC11, "_Static_assert", ""}, {StdC11, "_Thread_local", ""},
+
+	{StdC23, "bool", "_Bool"}, {StdC23, "true", ""}, {StdC23, "false", ""}, {StdC23, "nullptr", ""},
+	{StdC23, "constexpr", ""}, {StdC23, "typeof", ""}, {StdC23, "typeof_unqual", ""},
+	{StdC23, "alignas", "_Alignas"}, {StdC23, "alignof", "_Alignof"},
+	{StdC23, "static_assert", "_Static_assert"}, {StdC23, "thread_local", "_Thread_local"},
+	{StdC23, "_BitInt", ""}, {StdC23, "_Decimal32", ""}, {StdC23, "_Decimal64", ""}, {StdC23, "_Decimal128", ""},
+}
+
+// GNU 扩展的关键字，gnu89 也支持 inline
+var gnuKeywords = []keyword{
+	{StdC89, "inline", ""}, {StdC89, "asm", ""}, {StdC89, "__asm", "asm"}, {StdC89, "__asm__", "asm"},
+	{StdC89, "typeof", ""}, {StdC89, "__typeof", "typeof"}, {StdC89, "__typeof__", "typeof"},
+	{StdC89, "__attribute", "__attribute__"}, {StdC89, "__attribute__", ""}, {StdC89, "__extension__", ""},
+	{StdC89, "__inline", "inline"}, {StdC89, "__inline__", "inline"},
+	{StdC89, "__restrict", "restrict"}, {StdC89, "__restrict__", "restrict"},
+	{StdC89, "__const", "const"}, {StdC89, "__const__", "const"},
+	{StdC89, "__volatile", "volatile"}, {StdC89, "__volatile__", "volatile"},
+	{StdC89, "__signed", "signed"}, {StdC89, "__signed__", "signed"},
+	{StdC89, "__complex", "_Complex"}, {StdC89, "__complex__", "_Complex"},
+	{StdC89, "__alignof", "_Alignof"}, {StdC89, "__alignof__", "_Alignof"},
+	{StdC89, "__thread", "_Thread_local"}, {StdC89, "__label__", ""}, {StdC89, "__auto_type", ""},
+	{StdC89, "__int128", ""}, {StdC89, "__real__", ""}, {StdC89, "__imag__", ""},
+}
+
+// 关键字表
+type keywordTable struct {
+	words    map[string]string          // 写法 -> 规范写法
+	min, max int                        // 关键字长度范围，用于快速排除
+	index    [utf8.RuneSelf][][2]string // 按首字母分组的写法与规范写法，避免查找时计算哈希
+}
+
+// 按标准与方言缓存的关键字表
+var keywordTables = map[[2]int]*keywordTable{}
+
+func init() {
+	for std := StdC89; std <= StdC23; std++ {
+		for _, d := range []Dialect{DialectStd, DialectGNU} {
+			t := &keywordTable{words: map[string]string{}, min: len("do"), max: len("do")}
+			add := func(list []keyword) {
+				for _, kw := range list {
+					if kw.std > std {
+						continue
+					}
+					canonical := kw.canonical
+					if canonical == "" {
+						canonical = kw.spelling
+					}
+					t.words[kw.spelling] = canonical
+					t.index[kw.spelling[0]] = append(t.index[kw.spelling[0]], [2]string{kw.spelling, canonical})
+					if n := len(kw.spelling); n < t.min {
+						t.min = n
+					} else if n > t.max {
+						t.max = n
+					}
+				}
+			}
+			add(stdKeywords)
+			if d == DialectGNU {
+				add(gnuKeywords)
+			}
+			keywordTables[[2]int{int(std), int(d)}] = t
+		}
+	}
+}
+
+func lookupKeywordTable(std Standard, d Dialect) *keywordTable {
+	if t, ok := keywordTables[[2]int{int(std.Resolve()), int(d)}]; ok {
+		return t
+	}
+	return keywordTables[[2]int{int(std.Resolve()), int(DialectStd)}]
+}
+
+// 标准与方言下的关键字，键为源码中的写法，值为规范写法
+func Keywords(std Standard, d Dialect) map[string]string {
+	t := lookupKeywordTable(std, d)
+	m := make(map[string]string, len(t.words))
+	for k, v := range t.words {
+		m[k] = v
+	}
+	return m
+}
+
+// 关键字的规范写法，不是关键字时返回 false
+func (t *keywordTable) lookup(b []byte) (string, bool) {
+	// 关键字均以小写字母或下划线开头
+	if len(b) < t.min || len(b) > t.max || b[0] != '_' && b[0] < 'a' {
+		return "", false
+	}
+	for _, kw := range t.index[b[0]] {
+		if len(kw[0]) == len(b) && kw[0] == string(b) {
+			return kw[1], true
+		}
+	}
+	return "", false
+}
@@ -173,13 +173,13 @@ type tokenScanner struct {
 }
 
 // 扫描字符串
-// 跳过空白符
+// 跳过空白符与注释
 func NewTokenScan(s Scanner) Scanner {
 	return &tokenScanner{s}
 }
 
 func (ts *tokenScanner) Scan() (t token.Token) {
-	for t = ts.Scanner.Scan(); t.Type() == token.WHITESPACE || t.Type() == token.NEWLINE; t = ts.Scanner.Scan() {
+	for t = ts.Scanner.Scan(); t.Type() == token.WHITESPACE || t.Type() == token.NEWLINE || t.Type() == token.COMMENT; t = ts.Scanner.Scan() {
 		// next
 	}
 	return t
@@ -245,6 +245,12 @@ type fileScanner struct {
 	eof    token.Token
 }
 
+// 带有文件信息的扫描器
+type FileScanner interface {
+	Scanner
+	File() *token.File
+}
+
 func NewFileScan(filename string, opt *Option) (Scanner, error) {
 	s := &fileScanner{}
 	s.name = filename
@@ -279,3 +285,10 @@ func (s *fileScanner) Scan() token.Token {
 	}
 	return t
 }
+
+func (s *fileScanner) File() *token.File {
+	if fs, ok := s.Scanner.(FileScanner); ok {
+		return fs.File()
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package scanner
+
+import "strings"
+
+// C语言标准
+type Standard int
+
+const (
+	StdDefault Standard = iota // 默认，等同于 C17
+	StdC89                     // C89/C90
+	StdC99                     // C99
+	StdC11                     // C11
+	StdC17                     // C17/C18
+	StdC23                     // C23
+)
+
+var standardNames = map[Standard]string{
+	StdDefault: "default",
+	StdC89:     "c89",
+	StdC99:     "c99",
+	StdC11:     "c11",
+	StdC17:     "c17",
+	StdC23:     "c23",
+}
+
+// 标准名称的别名
+var standardAlias = map[string]Standard{
+	"c90": StdC89,
+	"c18": StdC17,
+	"c2x": StdC23,
+}
+
+func (s Standard) String() string {
+	if name, ok := standardNames[s]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// 实际使用的标准
+func (s Standard) Resolve() Standard {
+	if s == StdDefault {
+		return StdC17
+	}
+	return s
+}
+
+// 根据名称查找标准，不区分大小写
+func LookupStandard(name string) (Standard, bool) {
+	name = strings.ToLower(name)
+	for s, n := range standardNames {
+		if n == name {
+			return s, true
+		}
+	}
+	s, ok := standardAlias[name]
+	return s, ok
+}
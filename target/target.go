@@ -0,0 +1,64 @@
+// Package target 描述目标平台的数据模型
+package target
+
+// 目标平台，类型大小单位为字节
+type Target struct {
+	Name       string
+	CharSigned bool // char 是否有符号
+	Short      int
+	Int        int
+	Long       int
+	LongLong   int
+	Pointer    int
+	SizeT      int // size_t
+	WChar      int // wchar_t
+	Float      int
+	Double     int
+	LongDouble int
+	MaxAlign   int // 基础类型的最大对齐
+}
+
+var (
+	// 32位平台 int/long/指针 为 32 位
+	ILP32 = &Target{
+		Name: "ilp32", CharSigned: true,
+		Short: 2, Int: 4, Long: 4, LongLong: 8, Pointer: 4, SizeT: 4, WChar: 4,
+		Float: 4, Double: 8, LongDouble: 12, MaxAlign: 4,
+	}
+	// 64位 Unix 平台 long/指针 为 64 位
+	LP64 = &Target{
+		Name: "lp64", CharSigned: true,
+		Short: 2, Int: 4, Long: 8, LongLong: 8, Pointer: 8, SizeT: 8, WChar: 4,
+		Float: 4, Double: 8, LongDouble: 16, MaxAlign: 16,
+	}
+	// 64位 Windows 平台 long 为 32 位
+	LLP64 = &Target{
+		Name: "llp64", CharSigned: true,
+		Short: 2, Int: 4, Long: 4, LongLong: 8, Pointer: 8, SizeT: 8, WChar: 2,
+		Float: 4, Double: 8, LongDouble: 8, MaxAlign: 8,
+	}
+)
+
+// 大小为 size 的基础类型的对齐
+func (t *Target) Align(size int) int {
+	if t.MaxAlign > 0 && size > t.MaxAlign {
+		return t.MaxAlign
+	}
+	if size < 1 {
+		return 1
+	}
+	return size
+}
+
+// 默认目标平台
+var Default = LP64
+
+// 根据名称查找目标平台
+func Lookup(name string) (*Target, bool) {
+	for _, t := range []*Target{ILP32, LP64, LLP64} {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return nil, false
+}
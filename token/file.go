@@ -0,0 +1,239 @@
+package token
+
+import (
+	"sort"
+	"sync"
+	"unicode/utf8"
+)
+
+// 源文件，记录每行的起始偏移
+type File struct {
+	name  string
+	index int    // 在 FileSet 中的序号，从 1 开始，不属于 FileSet 时为 0
+	lines []int  // 每行的起始偏移
+	src   []byte // 文件内容
+}
+
+// 创建文件
+func NewFile(filename string) *File {
+	return &File{name: filename, lines: []int{0}}
+}
+
+// 文件名
+func (f *File) Name() string {
+	return f.name
+}
+
+// 已读取的文件大小
+func (f *File) Size() int {
+	return len(f.src)
+}
+
+// 已记录的行数
+func (f *File) LineCount() int {
+	return len(f.lines)
+}
+
+// 追加文件内容
+func (f *File) Write(b []byte) (int, error) {
+	f.src = append(f.src, b...)
+	return len(b), nil
+}
+
+// 预留 n 字节的空间，避免追加内容时反复扩容
+func (f *File) Grow(n int) {
+	if cap(f.src)-len(f.src) < n {
+		src := make([]byte, len(f.src), len(f.src)+n)
+		copy(src, f.src)
+		f.src = src
+	}
+}
+
+// 文件内容
+func (f *File) Content() []byte {
+	return f.src
+}
+
+// 设置文件内容并计算行信息
+func (f *File) SetContent(src []byte) {
+	f.src = src
+	f.lines = []int{0}
+	for i := 0; i < len(src); i++ {
+		switch src[i] {
+		case '\r':
+			if i+1 < len(src) && src[i+1] == '\n' {
+				i++
+			}
+			f.AddLine(i + 1)
+		case '\n':
+			f.AddLine(i + 1)
+		}
+	}
+}
+
+// 记录新行的起始偏移，偏移必须递增
+func (f *File) AddLine(offset int) {
+	if n := len(f.lines); offset > f.lines[n-1] {
+		f.lines = append(f.lines, offset)
+	}
+}
+
+// 行的起始偏移，行号从 1 开始
+func (f *File) LineStart(line int) int {
+	if line < 1 {
+		return 0
+	}
+	if line > len(f.lines) {
+		return len(f.src)
+	}
+	return f.lines[line-1]
+}
+
+// 偏移所在的行号
+func (f *File) Line(offset int) int {
+	return sort.Search(len(f.lines), func(i int) bool { return f.lines[i] > offset })
+}
+
+// 行内的文本
+func (f *File) lineText(line, offset int) []byte {
+	start := f.LineStart(line)
+	if offset > len(f.src) {
+		offset = len(f.src)
+	}
+	if start > offset {
+		return nil
+	}
+	return f.src[start:offset]
+}
+
+// 偏移转换为位置，列按字符计算
+func (f *File) Position(offset int) Position {
+	line := f.Line(offset)
+	col := utf8.RuneCount(f.lineText(line, offset)) + 1
+	// 内容不完整时按字节计算
+	if offset > len(f.src) {
+		col = offset - f.LineStart(line) + 1
+	}
+	return Position{Filename: f.name, Line: line, Column: col, Offset: offset}
+}
+
+// 行列转换为偏移，列按字符计算
+func (f *File) Offset(line, column int) int {
+	offset := f.LineStart(line)
+	for column > 1 && offset < len(f.src) {
+		_, w := utf8.DecodeRune(f.src[offset:])
+		offset += w
+		column--
+	}
+	return offset + column - 1
+}
+
+// 偏移转换为 UTF-16 编码的行列，列从 1 开始
+func (f *File) UTF16Position(offset int) (line, column int) {
+	line = f.Line(offset)
+	column = 1
+	for _, r := range string(f.lineText(line, offset)) {
+		column += utf16Len(r)
+	}
+	return
+}
+
+// UTF-16 编码的行列转换为偏移，列从 1 开始
+func (f *File) UTF16Offset(line, column int) int {
+	offset := f.LineStart(line)
+	column--
+	for column > 0 && offset < len(f.src) {
+		r, w := utf8.DecodeRune(f.src[offset:])
+		if r == '\n' || r == '\r' {
+			break
+		}
+		offset += w
+		column -= utf16Len(r)
+	}
+	return offset
+}
+
+func utf16Len(r rune) int {
+	if r >= 0x10000 && r <= utf8.MaxRune {
+		return 2
+	}
+	return 1
+}
+
+// 文件内偏移对应的紧凑位置
+func (f *File) Pos(offset int) Pos {
+	if f.index == 0 {
+		return NoPos
+	}
+	return Pos(int64(f.index)<<32 | int64(uint32(offset)))
+}
+
+// 紧凑位置，高 32 位为文件序号，低 32 位为文件内偏移
+type Pos int64
+
+// 无效位置
+const NoPos Pos = 0
+
+func (p Pos) IsValid() bool {
+	return p != NoPos
+}
+
+// 文件序号
+func (p Pos) index() int {
+	return int(p >> 32)
+}
+
+// 文件内偏移
+func (p Pos) Offset() int {
+	return int(uint32(p))
+}
+
+// 文件集合，用于紧凑位置与文件位置的转换
+type FileSet struct {
+	mu    sync.RWMutex
+	files []*File
+}
+
+func NewFileSet() *FileSet {
+	return &FileSet{}
+}
+
+// 添加文件
+func (s *FileSet) AddFile(filename string) *File {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f := NewFile(filename)
+	s.files = append(s.files, f)
+	f.index = len(s.files)
+	return f
+}
+
+// 紧凑位置所在的文件
+func (s *FileSet) File(p Pos) *File {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if i := p.index(); i > 0 && i <= len(s.files) {
+		return s.files[i-1]
+	}
+	return nil
+}
+
+// 紧凑位置转换为文件位置
+func (s *FileSet) Position(p Pos) Position {
+	if f := s.File(p); f != nil {
+		return f.Position(p.Offset())
+	}
+	return Position{}
+}
+
+// 遍历文件
+func (s *FileSet) Iterate(fn func(f *File) bool) {
+	s.mu.RLock()
+	files := append([]*File{}, s.files...)
+	s.mu.RUnlock()
+	for _, f := range files {
+		if !fn(f) {
+			break
+		}
+	}
+}
@@ -8,6 +8,8 @@ type Position struct {
 	Filename string
 	// 行,列
 	Line, Column int
+	// 字节偏移，从 0 开始
+	Offset int
 }
 
 func (p Position) String() string {
@@ -17,6 +17,7 @@ const (
 	PUNCTUATOR
 	KEYWORD
 	TEXT
+	COMMENT
 )
 
 var tokenName = [...]string{
@@ -32,6 +33,7 @@ var tokenName = [...]string{
 	PUNCTUATOR: "PUNCTUATOR",
 	KEYWORD:    "KEYWORD",
 	TEXT:       "TEXT",
+	COMMENT:    "COMMENT",
 }
 
 var nameToken = map[string]Type{
@@ -47,6 +49,7 @@ var nameToken = map[string]Type{
 	"PUNCTUATOR": PUNCTUATOR,
 	"KEYWORD":    KEYWORD,
 	"TEXT":       TEXT,
+	"COMMENT":    COMMENT,
 }
 
 type Name string